@@ -0,0 +1,20 @@
+-- +migrate Up
+delete from votes a using votes b
+where a.id > b.id and a.transfer_index = b.transfer_index and a.rarimo_transaction = b.rarimo_transaction;
+
+alter table votes add constraint votes_transfer_index_rarimo_transaction_key unique (transfer_index, rarimo_transaction);
+
+delete from approvals a using approvals b
+where a.id > b.id and a.transfer_index = b.transfer_index and a.rarimo_transaction = b.rarimo_transaction;
+
+alter table approvals add constraint approvals_transfer_index_rarimo_transaction_key unique (transfer_index, rarimo_transaction);
+
+delete from rejections a using rejections b
+where a.id > b.id and a.transfer_index = b.transfer_index and a.rarimo_transaction = b.rarimo_transaction;
+
+alter table rejections add constraint rejections_transfer_index_rarimo_transaction_key unique (transfer_index, rarimo_transaction);
+
+-- +migrate Down
+alter table rejections drop constraint if exists rejections_transfer_index_rarimo_transaction_key;
+alter table approvals drop constraint if exists approvals_transfer_index_rarimo_transaction_key;
+alter table votes drop constraint if exists votes_transfer_index_rarimo_transaction_key;
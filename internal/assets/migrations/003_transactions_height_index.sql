@@ -0,0 +1,5 @@
+-- +migrate Up
+create index if not exists transactions_block_height_index on transactions(block_height, index);
+
+-- +migrate Down
+drop index if exists transactions_block_height_index;
@@ -0,0 +1,21 @@
+-- +migrate Up
+create table if not exists operations(
+    id bigserial primary key,
+    index bytea unique,
+    type integer not null,
+    status integer not null default 0,
+    creator text,
+    rarimo_tx bytea references transactions(hash),
+    rarimo_tx_timestamp timestamp without time zone not null default now(), -- for sorting purposes without joining
+    details jsonb not null default '{}'::jsonb,
+    created_at timestamp without time zone not null default now(),
+    updated_at timestamp without time zone not null default now()
+);
+
+create index if not exists operations_type on operations using btree(type);
+create index if not exists operations_status on operations using btree(status);
+
+-- +migrate Down
+drop index if exists operations_status;
+drop index if exists operations_type;
+drop table if exists operations;
@@ -0,0 +1,36 @@
+-- +migrate Up
+create table if not exists identity_transfers(
+    id bigserial primary key,
+    operation_index bytea unique,
+    type integer not null,
+    status integer not null default 0,
+    chain text not null,
+    contract text not null,
+    identity_id text,
+    state_hash text,
+    state_created_at timestamp without time zone,
+    state_created_at_block bigint,
+    replaced_state_hash text,
+    gist_hash text,
+    gist_created_at timestamp without time zone,
+    gist_created_at_block bigint,
+    replaced_gist_hash text,
+    state_root_hash text,
+    rarimo_tx bytea references transactions(hash),
+    rarimo_tx_timestamp timestamp without time zone not null default now(), -- for sorting purposes without joining
+    created_at timestamp without time zone not null default now(),
+    updated_at timestamp without time zone not null default now()
+);
+
+-- latest signed state of the destination chain lookups
+create index if not exists identity_transfers_chain_status on identity_transfers using btree(chain, status, rarimo_tx_timestamp);
+create index if not exists identity_transfers_identity_id on identity_transfers using btree(identity_id);
+create index if not exists identity_transfers_state_hash on identity_transfers using btree(state_hash);
+create index if not exists identity_transfers_gist_hash on identity_transfers using btree(gist_hash);
+
+-- +migrate Down
+drop index if exists identity_transfers_gist_hash;
+drop index if exists identity_transfers_state_hash;
+drop index if exists identity_transfers_identity_id;
+drop index if exists identity_transfers_chain_status;
+drop table if exists identity_transfers;
@@ -0,0 +1,13 @@
+-- +migrate Up
+create table if not exists seeds(
+    seed text primary key,
+    item bigint not null references items(id),
+    created_tx text, -- tx hash or "block:<height>" for end block events, null for genesis and backfilled seeds
+    created_at timestamp without time zone not null default now()
+);
+
+create index if not exists seeds_item on seeds using btree(item);
+
+-- +migrate Down
+drop index if exists seeds_item;
+drop table if exists seeds;
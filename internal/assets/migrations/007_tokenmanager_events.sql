@@ -0,0 +1,27 @@
+-- +migrate Up
+alter table collections add column if not exists deleted_at timestamp without time zone;
+alter table items add column if not exists deleted_at timestamp without time zone;
+
+create table if not exists tokenmanager_events(
+    id bigserial primary key,
+    type text not null,
+    collection_index text,
+    item_index text,
+    chain text,
+    details jsonb not null default '{}'::jsonb,
+    rarimo_tx text, -- tx hash or "block:<height>" for end block events, null for the events indexed without the source
+    height bigint,
+    event_key text unique, -- deduplicates redelivered and backfilled events, null if the source is unknown
+    created_at timestamp without time zone not null default now()
+);
+
+create index if not exists tokenmanager_events_collection_index on tokenmanager_events using btree(collection_index);
+create index if not exists tokenmanager_events_item_index on tokenmanager_events using btree(item_index);
+
+-- +migrate Down
+drop index if exists tokenmanager_events_item_index;
+drop index if exists tokenmanager_events_collection_index;
+drop table if exists tokenmanager_events;
+
+alter table items drop column if exists deleted_at;
+alter table collections drop column if exists deleted_at;
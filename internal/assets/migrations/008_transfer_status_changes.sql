@@ -0,0 +1,13 @@
+-- +migrate Up
+create table if not exists transfer_status_changes(
+    id bigserial primary key,
+    transfer_index bytea not null, -- operation index, so the statuses of the other operations are recorded too
+    status integer not null,
+    rarimo_transaction bytea,
+    rarimo_tx_timestamp timestamp without time zone, -- block time of the transaction, null if unknown
+    created_at timestamp without time zone not null default now(),
+    unique (transfer_index, status)
+);
+
+-- +migrate Down
+drop table if exists transfer_status_changes;
@@ -0,0 +1,20 @@
+-- +migrate Up
+create table if not exists stuck_transfer_alerts(
+    id bigserial primary key,
+    transfer_index bytea not null,
+    kind text not null, -- initialized, not_withdrawn or withdrawal_failed
+    origin text not null,
+    chain text not null, -- destination chain of the transfer, thresholds are configured per it
+    status integer not null, -- transfer status at the moment of the detection
+    since timestamp without time zone not null, -- time the transfer got stuck in the state
+    detected_at timestamp without time zone not null default now(),
+    notified_at timestamp without time zone,
+    resolved_at timestamp without time zone,
+    unique (transfer_index, kind)
+);
+
+create index if not exists stuck_transfer_alerts_chain on stuck_transfer_alerts using btree(chain);
+
+-- +migrate Down
+drop index if exists stuck_transfer_alerts_chain;
+drop table if exists stuck_transfer_alerts;
@@ -0,0 +1,22 @@
+-- +migrate Up
+create table if not exists item_metadata(
+    item bigint not null references items(id) on delete cascade,
+    network int not null,
+    metadata jsonb, -- normalized nft metadata, null until it is fetched successfully
+    fetched_at timestamp without time zone,
+    failures int not null default 0, -- consecutive failed fetches, the next one is delayed exponentially by them
+    last_error text,
+    next_fetch_at timestamp without time zone not null default now(),
+    created_at timestamp without time zone not null default now(),
+    updated_at timestamp without time zone not null default now(),
+    primary key (item, network)
+);
+
+create index if not exists item_metadata_next_fetch_at on item_metadata using btree(next_fetch_at);
+
+CREATE TRIGGER set_updated_at BEFORE UPDATE ON item_metadata FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+-- +migrate Down
+drop trigger if exists set_updated_at on item_metadata;
+drop index if exists item_metadata_next_fetch_at;
+drop table if exists item_metadata;
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func DeadLetterQueues(ctx context.Context, cfg config.Config) error {
+	names, err := msgs.NewDeadLetterQueues(cfg.Log(), cfg.Queues()).Names(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get dead letter queues")
+	}
+
+	return printJSON(names)
+}
+
+func DeadLetterList(ctx context.Context, cfg config.Config, queue string) error {
+	letters, err := msgs.NewDeadLetterQueue(cfg.Log(), cfg.Queues(), queue).List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list dead letters")
+	}
+
+	// messages could be huge, so only summary is printed, use inspect to get them
+	type summary struct {
+		msgs.DeadLetter
+		Messages int `json:"messages"`
+	}
+
+	summaries := make([]summary, len(letters))
+	for i, letter := range letters {
+		summaries[i] = summary{DeadLetter: letter, Messages: len(letter.Messages)}
+	}
+
+	return printJSON(summaries)
+}
+
+func DeadLetterInspect(ctx context.Context, cfg config.Config, queue, id string) error {
+	letter, err := msgs.NewDeadLetterQueue(cfg.Log(), cfg.Queues(), queue).Get(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get dead letter")
+	}
+
+	if letter == nil {
+		return errors.From(errors.New("dead letter not found"), logan.F{
+			"queue": queue,
+			"id":    id,
+		})
+	}
+
+	return printJSON(letter)
+}
+
+// DeadLetterReplay publishes dead letter messages back into the queue. If id is empty, all the letters are replayed.
+func DeadLetterReplay(ctx context.Context, cfg config.Config, queue, id string) error {
+	dlq := msgs.NewDeadLetterQueue(cfg.Log(), cfg.Queues(), queue)
+
+	return forEachDeadLetter(ctx, dlq, id, func(id string) (bool, error) {
+		return dlq.Replay(ctx, id)
+	})
+}
+
+// DeadLetterDiscard removes dead letters without replaying them. If id is empty, all the letters are discarded.
+func DeadLetterDiscard(ctx context.Context, cfg config.Config, queue, id string) error {
+	dlq := msgs.NewDeadLetterQueue(cfg.Log(), cfg.Queues(), queue)
+
+	return forEachDeadLetter(ctx, dlq, id, func(id string) (bool, error) {
+		return dlq.Discard(ctx, id)
+	})
+}
+
+func forEachDeadLetter(ctx context.Context, dlq *msgs.DeadLetterQueue, id string, f func(id string) (bool, error)) error {
+	ids := []string{id}
+
+	if id == "" {
+		letters, err := dlq.List(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to list dead letters")
+		}
+
+		ids = make([]string, len(letters))
+		for i, letter := range letters {
+			ids[i] = letter.ID
+		}
+	}
+
+	for _, id := range ids {
+		ok, err := f(id)
+		if err != nil {
+			return errors.Wrap(err, "failed to process dead letter", logan.F{
+				"id": id,
+			})
+		}
+
+		if !ok {
+			return errors.From(errors.New("dead letter not found"), logan.F{
+				"queue": dlq.Queue(),
+				"id":    id,
+			})
+		}
+	}
+
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return errors.Wrap(encoder.Encode(v), "failed to print")
+}
@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/rarimo/horizon-svc/internal/services/api"
 
@@ -21,12 +22,14 @@ import (
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
-func Run(args []string) {
+// Run returns false if the command failed, so the process exits with the non-zero code
+func Run(args []string) (ok bool) {
 	log := logan.New()
 
 	defer func() {
 		if rvr := recover(); rvr != nil {
 			log.WithRecover(rvr).Error("app panicked")
+			ok = false
 		}
 	}()
 
@@ -34,6 +37,8 @@ func Run(args []string) {
 	log = cfg.Log()
 
 	app := kingpin.New("horizon", "")
+	shutdownTimeout := app.Flag("shutdown-timeout", "time to wait for the services to stop after the signal, consumers drain the messages being handled within it").
+		Default("1m").Duration()
 
 	runCmd := app.Command("run", "run command")
 	apiCmd := runCmd.Command("api", "run API")
@@ -62,11 +67,62 @@ func Run(args []string) {
 	// routines for indexing bridge contracts
 	bridgeProducerCmd := runCmd.Command("bridge_events_producer", "run bridge events producer")
 	withdrawalsIndexerCmd := runCmd.Command("withdrawals_indexer", "run withdrawals indexer")
+	nftMetadataIndexerCmd := runCmd.Command("nft_metadata_indexer", "run indexer storing and refreshing metadata of the nft items")
+
+	// routines for monitoring indexed data
+	stuckTransfersDetectorCmd := runCmd.Command("stuck_transfers_detector", "run detector of the transfers stuck on the way to the destination chain")
+	reconcilerCmd := runCmd.Command("reconciler", "run periodic reconciliation of collections and items with the core state")
+
+	servicesCmd := runCmd.Command("services", "run several services in one process restarting the crashed ones")
+	servicesEnable := servicesCmd.Flag("enable", "comma separated names of the services to run, the ones from the config by default").String()
 
 	migrateCmd := app.Command("migrate", "migrate command")
 	migrateUpCmd := migrateCmd.Command("up", "migrate db up")
 	migrateDownCmd := migrateCmd.Command("down", "migrate db down")
 
+	backfillCmd := app.Command("backfill", "re-index historical block range of the rarimo chain")
+	backfillModule := backfillCmd.Arg("module", "module to backfill").Required().Enum(services.BackfillRarimoCore, services.BackfillTokenManager)
+	backfillFrom := backfillCmd.Flag("from", "first block to backfill").Required().Int64()
+	backfillTo := backfillCmd.Flag("to", "last block to backfill (inclusive)").Required().Int64()
+	backfillRangeSize := backfillCmd.Flag("range-size", "max number of blocks handled at once").Default("100").Int64()
+	backfillInline := backfillCmd.Flag("inline", "process block ranges in this process instead of publishing them to the queue").Bool()
+	backfillMissingOnly := backfillCmd.Flag("missing-only", "handle only events missing in the database, requires --inline").Bool()
+
+	bootstrapCmd := app.Command("bootstrap", "import the tokenmanager and rarimocore state of the core at the height and start indexing from the next block")
+	bootstrapHeight := bootstrapCmd.Flag("height", "block height of the core state to import").Required().Int64()
+	bootstrapCursorKeys := bootstrapCmd.Flag("cursor-key", "block range producer cursor to set to the next block, repeatable, the configured one by default").Strings()
+	bootstrapForce := bootstrapCmd.Flag("force", "move the block range cursors back if they are ahead of the height").Bool()
+
+	snapshotCmd := app.Command("snapshot", "export or import the snapshot of the database and the producers cursors")
+	snapshotExportCmd := snapshotCmd.Command("export", "write the consistent snapshot of the database tables and the cursors to the archive")
+	snapshotExportOutput := snapshotExportCmd.Flag("output", "path of the snapshot archive to write").Short('o').Required().String()
+	snapshotExportCursors := snapshotExportCmd.Flag("cursor", "redis key pattern of the cursors to export, repeatable, all the cursors by default").Strings()
+	snapshotImportCmd := snapshotCmd.Command("import", "restore the database tables and the cursors from the archive")
+	snapshotImportInput := snapshotImportCmd.Flag("input", "path of the snapshot archive to read").Short('i').Required().String()
+	snapshotImportForce := snapshotImportCmd.Flag("force", "truncate the tables which are not empty").Bool()
+
+	reindexCmd := app.Command("reindex-from-db", "re-derive transfers, votes, approvals, rejections and confirmations from the stored transactions")
+	reindexFrom := reindexCmd.Flag("from", "first block height of the transactions to reindex").Int64()
+	reindexTo := reindexCmd.Flag("to", "last block height of the transactions to reindex (inclusive)").Int64()
+	reindexPageSize := reindexCmd.Flag("page-size", "number of transactions handled at once").Default("100").Uint64()
+
+	reconcileCmd := app.Command("reconcile", "compare collections and items with the core state once and print the report")
+	reconcileRepair := reconcileCmd.Flag("repair", "repair the found differences").Bool()
+
+	dlqCmd := app.Command("dlq", "manage messages which failed to be handled after all the retries")
+	dlqQueuesCmd := dlqCmd.Command("queues", "list queues which have dead letters")
+	dlqListCmd := dlqCmd.Command("list", "list dead letters of the queue")
+	dlqListQueue := dlqListCmd.Arg("queue", "queue name").Required().String()
+	dlqInspectCmd := dlqCmd.Command("inspect", "print dead letter with its messages")
+	dlqInspectQueue := dlqInspectCmd.Arg("queue", "queue name").Required().String()
+	dlqInspectID := dlqInspectCmd.Arg("id", "dead letter id").Required().String()
+	dlqReplayCmd := dlqCmd.Command("replay", "publish dead letter messages back into the queue")
+	dlqReplayQueue := dlqReplayCmd.Arg("queue", "queue name").Required().String()
+	dlqReplayID := dlqReplayCmd.Arg("id", "dead letter id, all the letters are replayed if omitted").String()
+	dlqDiscardCmd := dlqCmd.Command("discard", "remove dead letter without replaying")
+	dlqDiscardQueue := dlqDiscardCmd.Arg("queue", "queue name").Required().String()
+	dlqDiscardID := dlqDiscardCmd.Arg("id", "dead letter id, all the letters are discarded if omitted").String()
+
 	cmd, err := app.Parse(args[1:])
 	if err != nil {
 		panic(errors.Wrap(err, "failed to parse args"))
@@ -153,6 +209,25 @@ func Run(args []string) {
 	case bridgeProducerCmd.FullCommand():
 		cfg.Log().Info("starting bridge events producer")
 		run(bridge_producer.RunBridgeEventsProducer)
+	case nftMetadataIndexerCmd.FullCommand():
+		cfg.Log().Info("starting nft metadata indexer")
+		run(services.RunNftMetadataIndexer)
+	case stuckTransfersDetectorCmd.FullCommand():
+		cfg.Log().Info("starting stuck transfers detector")
+		run(services.RunStuckTransfersDetector)
+	case servicesCmd.FullCommand():
+		super, err := NewServicesSupervisor(cfg, *servicesEnable)
+		if err != nil {
+			panic(errors.Wrap(err, "failed to start services"))
+		}
+
+		cfg.Log().Info("starting supervised services")
+		run(func(ctx context.Context, _ config.Config) {
+			super.Run(ctx)
+		})
+	case reconcilerCmd.FullCommand():
+		cfg.Log().Info("starting reconciler")
+		run(services.RunReconciler)
 	case migrateUpCmd.FullCommand():
 		if err := MigrateUp(cfg); err != nil {
 			panic(errors.Wrap(err, "failed to migrate up"))
@@ -161,6 +236,72 @@ func Run(args []string) {
 		if err := MigrateDown(cfg); err != nil {
 			panic(errors.Wrap(err, "failed to migrate down"))
 		}
+	case backfillCmd.FullCommand():
+		cfg.Log().Info("starting backfill")
+		opts := services.BackfillOpts{
+			Module:      *backfillModule,
+			From:        *backfillFrom,
+			To:          *backfillTo,
+			RangeSize:   *backfillRangeSize,
+			Inline:      *backfillInline,
+			MissingOnly: *backfillMissingOnly,
+		}
+		if err := services.RunBackfill(ctx, cfg, opts); err != nil {
+			panic(errors.Wrap(err, "failed to backfill"))
+		}
+		cfg.Log().Info("backfill finished")
+	case bootstrapCmd.FullCommand():
+		cfg.Log().Info("starting bootstrap")
+		opts := services.BootstrapOpts{
+			Height:     *bootstrapHeight,
+			CursorKeys: *bootstrapCursorKeys,
+			Force:      *bootstrapForce,
+		}
+		if err := services.RunBootstrap(ctx, cfg, opts); err != nil {
+			panic(errors.Wrap(err, "failed to bootstrap"))
+		}
+	case snapshotExportCmd.FullCommand():
+		if err := SnapshotExport(ctx, cfg, *snapshotExportOutput, *snapshotExportCursors); err != nil {
+			panic(errors.Wrap(err, "failed to export snapshot"))
+		}
+	case snapshotImportCmd.FullCommand():
+		if err := SnapshotImport(ctx, cfg, *snapshotImportInput, *snapshotImportForce); err != nil {
+			panic(errors.Wrap(err, "failed to import snapshot"))
+		}
+	case reindexCmd.FullCommand():
+		cfg.Log().Info("starting reindex from database")
+		opts := services.ReindexOpts{
+			FromHeight: *reindexFrom,
+			ToHeight:   *reindexTo,
+			PageSize:   *reindexPageSize,
+		}
+		if err := services.RunReindexFromDB(ctx, cfg, opts); err != nil {
+			panic(errors.Wrap(err, "failed to reindex from database"))
+		}
+	case reconcileCmd.FullCommand():
+		if err := Reconcile(ctx, cfg, *reconcileRepair); err != nil {
+			panic(errors.Wrap(err, "failed to reconcile"))
+		}
+	case dlqQueuesCmd.FullCommand():
+		if err := DeadLetterQueues(ctx, cfg); err != nil {
+			panic(errors.Wrap(err, "failed to list dead letter queues"))
+		}
+	case dlqListCmd.FullCommand():
+		if err := DeadLetterList(ctx, cfg, *dlqListQueue); err != nil {
+			panic(errors.Wrap(err, "failed to list dead letters"))
+		}
+	case dlqInspectCmd.FullCommand():
+		if err := DeadLetterInspect(ctx, cfg, *dlqInspectQueue, *dlqInspectID); err != nil {
+			panic(errors.Wrap(err, "failed to inspect dead letter"))
+		}
+	case dlqReplayCmd.FullCommand():
+		if err := DeadLetterReplay(ctx, cfg, *dlqReplayQueue, *dlqReplayID); err != nil {
+			panic(errors.Wrap(err, "failed to replay dead letters"))
+		}
+	case dlqDiscardCmd.FullCommand():
+		if err := DeadLetterDiscard(ctx, cfg, *dlqDiscardQueue, *dlqDiscardID); err != nil {
+			panic(errors.Wrap(err, "failed to discard dead letters"))
+		}
 	default:
 		panic(fmt.Errorf("unknown command %s", cmd))
 	}
@@ -178,8 +319,19 @@ func Run(args []string) {
 	case <-wgch:
 		cfg.Log().Warn("all services stopped")
 	case <-gracefulStop:
-		cfg.Log().Info("received signal to stop")
+		cfg.Log().WithField("shutdown_timeout", shutdownTimeout.String()).
+			Info("received signal to stop, waiting for the services to drain")
 		cancel()
-		<-wgch
+
+		select {
+		case <-wgch:
+			cfg.Log().Info("all services stopped")
+		case <-gracefulStop:
+			cfg.Log().Warn("received second signal, stopping without waiting for the services")
+		case <-time.After(*shutdownTimeout):
+			cfg.Log().Warn("services did not stop in shutdown timeout, stopping without waiting for them")
+		}
 	}
+
+	return true
 }
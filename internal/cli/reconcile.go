@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/services"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// Reconcile compares the tokenmanager tables with the core state once and prints the report, the report is also
+// stored to be served by the admin endpoints
+func Reconcile(ctx context.Context, cfg config.Config, repair bool) error {
+	report, err := services.NewReconciler(cfg, repair).ReconcileOnce(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to reconcile with core")
+	}
+
+	return printJSON(report)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/services"
+	"github.com/rarimo/horizon-svc/internal/services/api"
+	"github.com/rarimo/horizon-svc/internal/services/bridge_producer"
+	"github.com/rarimo/horizon-svc/internal/services/supervisor"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// knownServices are the services which could be run by the supervisor, named as the run subcommands
+func knownServices(cfg config.Config) []supervisor.Service {
+	service := func(name string, run func(context.Context, config.Config), dependsOn ...string) supervisor.Service {
+		return supervisor.Service{
+			Name:      name,
+			Run:       func(ctx context.Context) { run(ctx, cfg) },
+			DependsOn: dependsOn,
+		}
+	}
+
+	// items and collections indexers would save the same genesis entries if run at once
+	var genesisMu sync.Mutex
+	withGenesis := func(run func(context.Context, config.Config)) func(context.Context, config.Config) {
+		return func(ctx context.Context, cfg config.Config) {
+			func() {
+				genesisMu.Lock()
+				defer genesisMu.Unlock()
+
+				ParseAndSaveGenesis(ctx, cfg)
+			}()
+
+			run(ctx, cfg)
+		}
+	}
+
+	return []supervisor.Service{
+		service("api", api.Run),
+
+		// block range producer feeds one of the operations producers depending on its queue in the config
+		service("block_range_producer", services.RunBlockRangeProducer,
+			"rarimocore_operations_producer", "tokenmanager_operations_producer"),
+
+		service("rarimocore_operations_producer", services.RunRarimoCoreOpProducer,
+			"transfers_indexer", "approval_indexer", "rejection_indexer", "confirmations_indexer", "votes_indexer"),
+		service("transfers_indexer", services.RunTransfersIndexer),
+		service("approval_indexer", services.RunApprovalIndexer),
+		service("rejection_indexer", services.RunRejectionIndexer),
+		service("confirmations_indexer", services.RunConfirmationsIndexer),
+		service("votes_indexer", services.RunVotesIndexer),
+
+		service("tokenmanager_operations_producer", services.RunTokenManagerEventsProducer,
+			"items_indexer", "collections_indexer"),
+		service("items_indexer", withGenesis(services.RunItemsIndexer)),
+		service("collections_indexer", withGenesis(services.RunCollectionsIndexer)),
+
+		service("bridge_events_producer", bridge_producer.RunBridgeEventsProducer, "withdrawals_indexer"),
+		service("withdrawals_indexer", services.RunWithdrawalsIndexer),
+		service("nft_metadata_indexer", services.RunNftMetadataIndexer),
+
+		service("stuck_transfers_detector", services.RunStuckTransfersDetector),
+		service("reconciler", services.RunReconciler),
+	}
+}
+
+// NewServicesSupervisor returns the supervisor of the services enabled by the comma separated list, or by the
+// config if it is empty, failing if any of them is unknown, their dependencies are cyclic or nothing is enabled
+func NewServicesSupervisor(cfg config.Config, enable string) (*supervisor.Supervisor, error) {
+	enabled := cfg.Services().Enable
+	if enable != "" {
+		enabled = strings.Split(enable, ",")
+	}
+
+	for i := range enabled {
+		enabled[i] = strings.TrimSpace(enabled[i])
+	}
+
+	super, err := supervisor.New(cfg.Log(), supervisor.Opts{
+		MinBackoff:      cfg.Services().MinBackoff,
+		MaxBackoff:      cfg.Services().MaxBackoff,
+		StableAfter:     cfg.Services().StableAfter,
+		ShutdownTimeout: cfg.Services().ShutdownTimeout,
+	}, knownServices(cfg), enabled)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create supervisor")
+	}
+
+	return super, nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/services"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// SnapshotExport writes the snapshot of the database and the cursors to the file and prints its manifest
+func SnapshotExport(ctx context.Context, cfg config.Config, output string, cursors []string) error {
+	file, err := os.Create(output)
+	if err != nil {
+		return errors.Wrap(err, "failed to create snapshot file")
+	}
+	defer file.Close()
+
+	manifest, err := services.ExportSnapshot(ctx, cfg, services.SnapshotExportOpts{
+		Output:  file,
+		Cursors: cursors,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to export snapshot")
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close snapshot file")
+	}
+
+	return printJSON(manifest)
+}
+
+// SnapshotImport restores the database and the cursors from the snapshot file and prints its manifest
+func SnapshotImport(ctx context.Context, cfg config.Config, input string, force bool) error {
+	archive, err := zip.OpenReader(input)
+	if err != nil {
+		return errors.Wrap(err, "failed to open snapshot file")
+	}
+	defer archive.Close()
+
+	manifest, err := services.ImportSnapshot(ctx, cfg, services.SnapshotImportOpts{
+		Input: &archive.Reader,
+		Force: force,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to import snapshot")
+	}
+
+	return printJSON(manifest)
+}
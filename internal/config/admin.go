@@ -0,0 +1,33 @@
+package config
+
+import (
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// AdminConfig configures access to the admin API endpoints. Admin endpoints
+// are not mounted at all if the token is not set.
+type AdminConfig struct {
+	Token string `fig:"token"`
+}
+
+func (c AdminConfig) Disabled() bool {
+	return c.Token == ""
+}
+
+func (c *config) Admin() AdminConfig {
+	return c.admin.Do(func() interface{} {
+		var cfg AdminConfig
+
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(c.getter, "admin")).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to figure out admin config"))
+		}
+
+		return cfg
+	}).(AdminConfig)
+}
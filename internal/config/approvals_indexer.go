@@ -32,7 +32,7 @@ func (c *config) ApprovalsIndexer() ApprovalsIndexerConfig {
 		}
 
 		cfg.ApprovalsConsumer = msgs.NewConsumerer(newPathGetter(c.getter), yamlName+".approvals_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return cfg
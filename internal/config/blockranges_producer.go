@@ -9,6 +9,9 @@ import (
 	"gitlab.com/distributed_lab/kit/kv"
 )
 
+// SpecialCaseBlockRange is published on every start of the producer.
+//
+// Deprecated: use backfill command instead.
 type SpecialCaseBlockRange struct {
 	From int64 `fig:"from,required"`
 	To   int64 `fig:"to,required"`
@@ -21,11 +24,17 @@ type BlockRangesProducerConfig struct {
 	BlockRangeLimit   int64                   `fig:"block_range_limit,required"`
 	BlockTime         time.Duration           `fig:"block_time,required"`
 	SpecialCaseBlocks []SpecialCaseBlockRange `fig:"special_case_blocks"`
+	// Subscribe enables publishing of the new blocks right after the NewBlock event is received
+	// over tendermint websocket, polling is still used to catch up and after disconnects
+	Subscribe           bool          `fig:"subscribe"`
+	SubscriptionTimeout time.Duration `fig:"subscription_timeout"`
 }
 
 func (c *config) BlockRangeProducer() *BlockRangesProducerConfig {
 	return c.blockRangeProducer.Do(func() interface{} {
-		var result BlockRangesProducerConfig
+		result := BlockRangesProducerConfig{
+			SubscriptionTimeout: time.Minute,
+		}
 		serviceName := "block_ranges_producer"
 
 		blockRangesProducerData := kv.MustGetStringMap(c.getter, serviceName)
@@ -32,6 +32,12 @@ type BridgeProducerChainConfig struct {
 	ID          int  `fig:"id,required"`
 	SkipCatchup bool `fig:"skip_catchup"`
 	BatchSize   int  `fig:"batch_size"`
+	// Confirmations is the number of blocks to wait before publishing events from the block (only for EVM)
+	Confirmations uint64 `fig:"confirmations"`
+	// ReorgDepth is the number of blocks after confirmations to check for reorgs (only for EVM)
+	ReorgDepth uint64 `fig:"reorg_depth"`
+	// BlockWindow is the max number of blocks to request logs for at once (only for EVM)
+	BlockWindow uint64 `fig:"block_window"`
 }
 
 type BridgeProducerConfig struct {
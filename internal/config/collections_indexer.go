@@ -31,7 +31,7 @@ func (c *config) CollectionsIndexer() CollectionsIndexerConfig {
 		}
 
 		result.CollectionEventsConsumer = msgs.NewConsumerer(newPathGetter(c.getter), serviceName+".collection_events_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return result
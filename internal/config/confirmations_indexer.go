@@ -32,7 +32,7 @@ func (c *config) ConfirmationsIndexer() ConfirmationsIndexerConfig {
 		}
 
 		result.ConfirmationsConsumer = msgs.NewConsumerer(newPathGetter(c.getter), yamlName+".confirmations_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return result
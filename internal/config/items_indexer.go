@@ -31,7 +31,7 @@ func (c *config) ItemsIndexer() ItemsIndexerConfig {
 		}
 
 		result.ItemEventsConsumer = msgs.NewConsumerer(newPathGetter(c.getter), serviceName+".item_events_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return result
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// LeaderElectionConfig configures the redis lease held by the single replica of the
+// singleton producers, other replicas wait in standby until the lease is released or expires
+type LeaderElectionConfig struct {
+	Enabled  bool          `fig:"enabled"`
+	LeaseTTL time.Duration `fig:"lease_ttl"`
+}
+
+func (c *config) LeaderElection() LeaderElectionConfig {
+	return c.leaderElection.Do(func() interface{} {
+		cfg := LeaderElectionConfig{
+			LeaseTTL: 15 * time.Second,
+		}
+
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(c.getter, "leader_election")).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to figure out leader election config"))
+		}
+
+		if cfg.LeaseTTL < time.Second {
+			panic(errors.New("leader election lease_ttl must be at least 1s"))
+		}
+
+		return cfg
+	}).(LeaderElectionConfig)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/rarimo/horizon-svc/internal/data/pg"
 	"github.com/rarimo/horizon-svc/internal/metadata_fetcher"
 	"github.com/rarimo/horizon-svc/pkg/ipfs"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"github.com/rarimo/horizon-svc/pkg/rd"
 	thttp "github.com/tendermint/tendermint/rpc/client/http"
 	"gitlab.com/distributed_lab/kit/comfig"
@@ -32,6 +33,7 @@ type Config interface {
 	mem.Chainer
 	nearprovider.Nearer
 
+	Queues() *msgs.Queues
 	NewStorage() data.Storage
 	CachedStorage() data.Storage
 	MetadataFetcher() metadata_fetcher.Client
@@ -58,6 +60,12 @@ type Config interface {
 	WithdrawalsIndexer() *WithdrawalsIndexerConfig
 
 	RateLimiter() *RateLimiterConfig
+	Admin() AdminConfig
+	LeaderElection() LeaderElectionConfig
+	StuckTransfersDetector() *StuckTransfersDetectorConfig
+	Reconciler() *ReconcilerConfig
+	Services() *ServicesConfig
+	NftMetadataIndexer() *NftMetadataIndexerConfig
 }
 
 type config struct {
@@ -70,27 +78,34 @@ type config struct {
 	mem.Chainer
 	nearprovider.Nearer
 
-	chainGateway         comfig.Once
-	chains               comfig.Once
-	metadataFetcher      comfig.Once
-	tendermint           comfig.Once
-	cosmos               comfig.Once
-	core                 comfig.Once
-	transfersIndexer     comfig.Once
-	blockRangeProducer   comfig.Once
-	rarimocoreProducer   comfig.Once
-	tokenmanagerProducer comfig.Once
-	confirmationsIndexer comfig.Once
-	approvalsIndexer     comfig.Once
-	rejectionsIndexer    comfig.Once
-	votesIndexer         comfig.Once
-	itemsIndexer         comfig.Once
-	collectionsIndexer   comfig.Once
-	cachedstorage        comfig.Once
-	genesis              comfig.Once
-	bridgeProducer       comfig.Once
-	withdrawalsIndexer   comfig.Once
-	rateLimiter          comfig.Once
+	chainGateway           comfig.Once
+	chains                 comfig.Once
+	metadataFetcher        comfig.Once
+	tendermint             comfig.Once
+	cosmos                 comfig.Once
+	core                   comfig.Once
+	transfersIndexer       comfig.Once
+	blockRangeProducer     comfig.Once
+	rarimocoreProducer     comfig.Once
+	tokenmanagerProducer   comfig.Once
+	confirmationsIndexer   comfig.Once
+	approvalsIndexer       comfig.Once
+	rejectionsIndexer      comfig.Once
+	votesIndexer           comfig.Once
+	itemsIndexer           comfig.Once
+	collectionsIndexer     comfig.Once
+	cachedstorage          comfig.Once
+	genesis                comfig.Once
+	bridgeProducer         comfig.Once
+	withdrawalsIndexer     comfig.Once
+	rateLimiter            comfig.Once
+	admin                  comfig.Once
+	queues                 comfig.Once
+	leaderElection         comfig.Once
+	stuckTransfersDetector comfig.Once
+	reconciler             comfig.Once
+	services               comfig.Once
+	nftMetadataIndexer     comfig.Once
 
 	getter kv.Getter
 }
@@ -120,6 +135,15 @@ func (c *config) CachedStorage() data.Storage {
 	}).(data.Storage)
 }
 
+func (c *config) Queues() *msgs.Queues {
+	return c.queues.Do(func() interface{} {
+		return msgs.NewQueueser(c.getter, msgs.QueueserOpts{
+			Log:         c.Log().WithField("who", "queues"),
+			RedisClient: c.RedisClient(),
+		}).Queues()
+	}).(*msgs.Queues)
+}
+
 func (c *config) Core() core.Core {
 	return c.core.Do(func() interface{} {
 		return core.NewCore(c.Cosmos())
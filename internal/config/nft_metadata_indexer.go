@@ -0,0 +1,57 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/lorem"
+)
+
+type NftMetadataIndexerConfig struct {
+	RunnerName string `fig:"runner_name"`
+	// Period is how often the new on-chain items are looked up and the due metadata is fetched
+	Period time.Duration `fig:"period"`
+	// BatchSize is the max number of items fetched per period
+	BatchSize uint64 `fig:"batch_size"`
+	// FetchTimeout limits fetching of the metadata of one item from the chain and IPFS
+	FetchTimeout time.Duration `fig:"fetch_timeout"`
+	// RefreshPeriod is the time the fetched metadata is kept before it is fetched again
+	RefreshPeriod time.Duration `fig:"refresh_period"`
+	// MinRetryPeriod and MaxRetryPeriod bound the exponential backoff of the items failed to be fetched
+	MinRetryPeriod time.Duration `fig:"min_retry_period"`
+	MaxRetryPeriod time.Duration `fig:"max_retry_period"`
+}
+
+func (c *config) NftMetadataIndexer() *NftMetadataIndexerConfig {
+	return c.nftMetadataIndexer.Do(func() interface{} {
+		cfg := NftMetadataIndexerConfig{
+			Period:         10 * time.Second,
+			BatchSize:      100,
+			FetchTimeout:   30 * time.Second,
+			RefreshPeriod:  24 * time.Hour,
+			MinRetryPeriod: time.Minute,
+			MaxRetryPeriod: 24 * time.Hour,
+		}
+		yamlName := "nft_metadata_indexer"
+
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(c.getter, yamlName)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to figure out "+yamlName))
+		}
+
+		if cfg.MinRetryPeriod <= 0 || cfg.MaxRetryPeriod < cfg.MinRetryPeriod {
+			panic(errors.New(yamlName + " retry periods must be positive and min one must not exceed max one"))
+		}
+
+		if isEmptyOrRandom(cfg.RunnerName) {
+			cfg.RunnerName = yamlName + "_" + lorem.RandomName()
+		}
+
+		return &cfg
+	}).(*NftMetadataIndexerConfig)
+}
@@ -38,7 +38,7 @@ func (c *config) RarimoCoreProducer() *RarimoCoreProducerConfig {
 		}
 
 		result.BlockRangeConsumer = msgs.NewConsumerer(newPathGetter(c.getter), serviceName+".block_range_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return &result
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/lorem"
+)
+
+type ReconcilerConfig struct {
+	RunnerName string        `fig:"runner_name"`
+	Period     time.Duration `fig:"period"`
+	// Repair makes the reconciler fix the differences with core, otherwise they are only reported
+	Repair bool `fig:"repair"`
+	// PageSize is the number of collections or items loaded from the database at once
+	PageSize uint64 `fig:"page_size"`
+	// MaxDifferences limits the number of differences stored in the report, their counts are not limited
+	MaxDifferences int `fig:"max_differences"`
+}
+
+func (c *config) Reconciler() *ReconcilerConfig {
+	return c.reconciler.Do(func() interface{} {
+		cfg := ReconcilerConfig{
+			Period:         time.Hour,
+			PageSize:       100,
+			MaxDifferences: 1000,
+		}
+		yamlName := "reconciler"
+
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(c.getter, yamlName)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to figure out "+yamlName))
+		}
+
+		// pages are loaded until the short one, so the empty ones would never end the pass
+		if cfg.PageSize == 0 {
+			panic(errors.New(yamlName + " page_size must be positive"))
+		}
+
+		if isEmptyOrRandom(cfg.RunnerName) {
+			cfg.RunnerName = yamlName + "_" + lorem.RandomName()
+		}
+
+		return &cfg
+	}).(*ReconcilerConfig)
+}
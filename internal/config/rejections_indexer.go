@@ -32,7 +32,7 @@ func (c *config) RejectionsIndexer() RejectionsIndexerConfig {
 		}
 
 		cfg.RejectionsConsumer = msgs.NewConsumerer(newPathGetter(c.getter), yamlName+".rejections_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return cfg
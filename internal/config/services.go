@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// ServicesConfig configures the supervisor running several services in one process
+type ServicesConfig struct {
+	// Enable lists the names of the services to run, the same as the names of the run subcommands
+	Enable []string `fig:"enable"`
+	// MinBackoff and MaxBackoff bound the exponential delay before the crashed service is restarted
+	MinBackoff time.Duration `fig:"min_backoff"`
+	MaxBackoff time.Duration `fig:"max_backoff"`
+	// StableAfter is the time the service has to run for the backoff to be reset on its next crash
+	StableAfter time.Duration `fig:"stable_after"`
+	// ShutdownTimeout is the max time to wait for the service to stop before stopping the next one
+	ShutdownTimeout time.Duration `fig:"shutdown_timeout"`
+}
+
+func (c *config) Services() *ServicesConfig {
+	return c.services.Do(func() interface{} {
+		cfg := ServicesConfig{
+			MinBackoff:      time.Second,
+			MaxBackoff:      time.Minute,
+			StableAfter:     time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+		}
+		yamlName := "services"
+
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(c.getter, yamlName)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to figure out "+yamlName))
+		}
+
+		if cfg.MinBackoff <= 0 || cfg.MaxBackoff < cfg.MinBackoff {
+			panic(errors.New(yamlName + " backoff must be positive and max_backoff must not be less than min_backoff"))
+		}
+
+		return &cfg
+	}).(*ServicesConfig)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/lorem"
+)
+
+// StuckTransferThresholds are the durations the transfer may stay in the state before it is reported as stuck,
+// zero durations of the per-chain thresholds fall back to the default ones
+type StuckTransferThresholds struct {
+	// Initialized is the max time the transfer may wait for the approval or rejection
+	Initialized time.Duration `fig:"initialized"`
+	// NotWithdrawn is the max time the signed transfer may wait for the withdrawal
+	NotWithdrawn time.Duration `fig:"not_withdrawn"`
+}
+
+type StuckTransfersChainConfig struct {
+	// Name of the transfer destination chain
+	Name       string                  `fig:"name,required"`
+	Thresholds StuckTransferThresholds `fig:"thresholds"`
+}
+
+type StuckTransfersWebhookConfig struct {
+	URL     string        `fig:"url"`
+	Timeout time.Duration `fig:"timeout"`
+}
+
+type StuckTransfersDetectorConfig struct {
+	RunnerName string                       `fig:"runner_name"`
+	Period     time.Duration                `fig:"period"`
+	Thresholds StuckTransferThresholds      `fig:"thresholds"`
+	Chains     []*StuckTransfersChainConfig `fig:"chains"`
+	// Webhook receives the new alerts as JSON, they are only logged if the url is not set
+	Webhook StuckTransfersWebhookConfig `fig:"webhook"`
+}
+
+// ChainThresholds returns the thresholds of the destination chain with the defaults applied
+func (c *StuckTransfersDetectorConfig) ChainThresholds(chain string) StuckTransferThresholds {
+	result := c.Thresholds
+
+	for _, cfg := range c.Chains {
+		if !strings.EqualFold(cfg.Name, chain) {
+			continue
+		}
+
+		if cfg.Thresholds.Initialized != 0 {
+			result.Initialized = cfg.Thresholds.Initialized
+		}
+
+		if cfg.Thresholds.NotWithdrawn != 0 {
+			result.NotWithdrawn = cfg.Thresholds.NotWithdrawn
+		}
+	}
+
+	return result
+}
+
+func (c *config) StuckTransfersDetector() *StuckTransfersDetectorConfig {
+	return c.stuckTransfersDetector.Do(func() interface{} {
+		cfg := StuckTransfersDetectorConfig{
+			Period: time.Minute,
+			Thresholds: StuckTransferThresholds{
+				Initialized:  30 * time.Minute,
+				NotWithdrawn: time.Hour,
+			},
+			Webhook: StuckTransfersWebhookConfig{
+				Timeout: 10 * time.Second,
+			},
+		}
+		yamlName := "stuck_transfers_detector"
+
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(c.getter, yamlName)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to figure out "+yamlName))
+		}
+
+		if cfg.Thresholds.Initialized <= 0 || cfg.Thresholds.NotWithdrawn <= 0 {
+			panic(errors.New(yamlName + " thresholds must be positive"))
+		}
+
+		if isEmptyOrRandom(cfg.RunnerName) {
+			cfg.RunnerName = yamlName + "_" + lorem.RandomName()
+		}
+
+		return &cfg
+	}).(*StuckTransfersDetectorConfig)
+}
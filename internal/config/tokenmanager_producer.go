@@ -33,7 +33,7 @@ func (c *config) TokenManagerProducer() *TokenManagerProducerConfig {
 		}
 
 		result.BlockRangeConsumer = msgs.NewConsumerer(newPathGetter(c.getter), serviceName+".block_range_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return &result
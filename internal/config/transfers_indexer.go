@@ -37,7 +37,7 @@ func (c *config) TransfersIndexer() TransfersIndexerConfig {
 		}
 
 		result.TransfersConsumer = msgs.NewConsumerer(newPathGetter(c.getter), yamlName+".transfers_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return result
@@ -32,7 +32,7 @@ func (c *config) VotesIndexer() VotesIndexerConfig {
 		}
 
 		cfg.VotesConsumer = msgs.NewConsumerer(newPathGetter(c.getter), yamlName+".votes_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return cfg
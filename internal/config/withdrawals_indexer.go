@@ -31,7 +31,7 @@ func (c *config) WithdrawalsIndexer() *WithdrawalsIndexerConfig {
 		}
 
 		cfg.WithdrawalsConsumer = msgs.NewConsumerer(newPathGetter(c.getter), yamlName+".withdrawals_consumer", msgs.ConsumererOpts{
-			RedisClient: c.RedisClient(),
+			Queues: c.Queues(),
 		}).Consumer()
 
 		return &cfg
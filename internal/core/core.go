@@ -2,9 +2,13 @@ package core
 
 import (
 	"context"
+	"strconv"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	rarimocoretypes "github.com/rarimo/rarimo-core/x/rarimocore/types"
 	tokenmanagertypes "github.com/rarimo/rarimo-core/x/tokenmanager/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type Core interface {
@@ -27,6 +31,21 @@ type Tokenmanager interface {
 type Rarimocore interface {
 	GetConfirmation(ctx context.Context, root string) (*rarimocoretypes.Confirmation, error)
 	GetOperation(ctx context.Context, index string) (*rarimocoretypes.Operation, error)
+	// GetOperationProof returns the merkle path of the signed operation and the signature of the root,
+	// or nil if the operation was not signed yet
+	GetOperationProof(ctx context.Context, index string) (*rarimocoretypes.QueryGetOperationProofResponse, error)
+	// GetOperationsPage returns the page of operations starting from the key and the key of the next page,
+	// which is empty for the last one
+	GetOperationsPage(ctx context.Context, key []byte) ([]rarimocoretypes.Operation, []byte, error)
+	// GetConfirmationsPage returns the page of confirmations starting from the key and the key of the next page,
+	// which is empty for the last one
+	GetConfirmationsPage(ctx context.Context, key []byte) ([]rarimocoretypes.Confirmation, []byte, error)
+}
+
+// AtHeight makes the core queries with the returned context to be done against the state at the block height,
+// core node has to keep the state of the height, so pruning has to be disabled for the old ones
+func AtHeight(ctx context.Context, height int64) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
 }
 
 type corer struct {
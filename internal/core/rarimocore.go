@@ -2,6 +2,8 @@ package core
 
 import (
 	"context"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
 	rarimocoretypes "github.com/rarimo/rarimo-core/x/rarimocore/types"
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
@@ -46,3 +48,49 @@ func (r *rarimocore) GetOperation(ctx context.Context, index string) (*rarimocor
 
 	return &resp.Operation, nil
 }
+
+func (r *rarimocore) GetOperationProof(ctx context.Context, index string) (*rarimocoretypes.QueryGetOperationProofResponse, error) {
+	resp, err := r.rc.OperationProof(ctx, &rarimocoretypes.QueryGetOperationProofRequest{
+		Index: index,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get operation proof from core", logan.F{
+			"index": index,
+		})
+	}
+
+	return resp, nil
+}
+
+func (r *rarimocore) GetOperationsPage(ctx context.Context, key []byte) ([]rarimocoretypes.Operation, []byte, error) {
+	resp, err := r.rc.OperationAll(ctx, &rarimocoretypes.QueryAllOperationRequest{
+		Pagination: &query.PageRequest{Key: key, Limit: PageLimit},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get operations page from core")
+	}
+
+	return resp.Operation, nextKey(resp.Pagination), nil
+}
+
+func (r *rarimocore) GetConfirmationsPage(ctx context.Context, key []byte) ([]rarimocoretypes.Confirmation, []byte, error) {
+	resp, err := r.rc.ConfirmationAll(ctx, &rarimocoretypes.QueryAllConfirmationRequest{
+		Pagination: &query.PageRequest{Key: key, Limit: PageLimit},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get confirmations page from core")
+	}
+
+	return resp.Confirmation, nextKey(resp.Pagination), nil
+}
+
+func nextKey(page *query.PageResponse) []byte {
+	if page == nil {
+		return nil
+	}
+
+	return page.NextKey
+}
@@ -86,6 +86,10 @@ func (q *CollectionChainMappingQ) CollectionChainMappingByCollectionNetworkCtx(c
 	return ccm, nil
 }
 
+func (q *CollectionChainMappingQ) CollectionChainMappingsByCollectionCtx(ctx context.Context, collection int64, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+	return q.raw.CollectionChainMappingsByCollectionCtx(ctx, collection, isForUpdate)
+}
+
 func (q *CollectionChainMappingQ) UpsertCtx(ctx context.Context, ccm *data.CollectionChainMapping) error {
 	err := q.cache.Invalidate(ctx,
 		store.WithInvalidateTags(ccmCacheTags(ccm.Collection, ccm.Network)))
@@ -63,6 +63,21 @@ func (q *CollectionQ) CollectionByIndexCtx(ctx context.Context, index []byte, is
 	return collection, nil
 }
 
+// SelectCtx is used to page through all the collections, so its results are not cached
+func (q *CollectionQ) SelectCtx(ctx context.Context, selector data.CollectionsSelector) ([]data.Collection, error) {
+	return q.raw.SelectCtx(ctx, selector)
+}
+
+func (q *CollectionQ) UpdateCtx(ctx context.Context, c *data.Collection) error {
+	err := q.cache.Invalidate(ctx,
+		store.WithInvalidateTags([]string{collectionIDCacheKey(c.ID)})) // since we are updating by id
+	if err != nil {
+		q.log.WithError(err).Error("failed to invalidate collection cache")
+	}
+
+	return q.raw.UpdateCtx(ctx, c)
+}
+
 func (q *CollectionQ) DeleteCtx(ctx context.Context, c *data.Collection) error {
 	err := q.cache.Invalidate(ctx,
 		store.WithInvalidateTags([]string{collectionIDCacheKey(c.ID)})) // since we are deleting by id
@@ -121,6 +121,19 @@ func (q *ItemChainMappingQ) ItemChainMappingByItemNetworkCtx(ctx context.Context
 	return icm, nil
 }
 
+func (q *ItemChainMappingQ) ItemChainMappingsByItemCtx(ctx context.Context, item int64, isForUpdate bool) ([]data.ItemChainMapping, error) {
+	return q.raw.ItemChainMappingsByItemCtx(ctx, item, isForUpdate)
+}
+
+func (q *ItemChainMappingQ) UpsertCtx(ctx context.Context, icm *data.ItemChainMapping) error {
+	err := q.cache.Invalidate(ctx, store.WithInvalidateTags(icmCacheTags(icm.Item, icm.Network)))
+	if err != nil {
+		q.log.WithError(err).Error("failed to invalidate item chain mapping cache")
+	}
+
+	return q.raw.UpsertCtx(ctx, icm)
+}
+
 func (q *ItemChainMappingQ) DeleteByItemCtx(ctx context.Context, item int64) error {
 	err := q.cache.Invalidate(ctx,
 		store.WithInvalidateTags([]string{icmItemCacheKey(item)}))
@@ -91,6 +91,11 @@ func (q *ItemQ) ItemByIndexCtx(ctx context.Context, index []byte, isForUpdate bo
 	return item, nil
 }
 
+// SelectCtx is used to page through all the items, so its results are not cached
+func (q *ItemQ) SelectCtx(ctx context.Context, selector data.ItemsSelector) ([]data.Item, error) {
+	return q.raw.SelectCtx(ctx, selector)
+}
+
 func (q *ItemQ) UpdateCtx(ctx context.Context, i *data.Item) error {
 	err := q.cache.Invalidate(ctx,
 		store.WithInvalidateTags([]string{itemIDCacheKey(i.ID)})) // since we are updating by id
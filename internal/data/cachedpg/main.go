@@ -50,6 +50,10 @@ func (s *Storage) TransferQ() data.TransferQ {
 	}
 }
 
+func (s *Storage) TransferStatusChangeQ() data.TransferStatusChangeQ {
+	return s.raw.TransferStatusChangeQ() // timeline is changed with every status, so it is not cached
+}
+
 func (s *Storage) ConfirmationQ() data.ConfirmationQ {
 	return &ConfirmationsQ{
 		log:   s.log.WithField("who", "confirmations-cached-q"),
@@ -86,6 +90,14 @@ func (s *Storage) RejectionQ() data.RejectionQ {
 	}
 }
 
+func (s *Storage) OperationQ() data.OperationQ {
+	return s.raw.OperationQ() // governance operations are rare and are not requested often, so they are not cached
+}
+
+func (s *Storage) IdentityTransferQ() data.IdentityTransferQ {
+	return s.raw.IdentityTransferQ() // relayers need the latest state, so identity transfers are not cached
+}
+
 func (s *Storage) CollectionQ() data.CollectionQ {
 	return &CollectionQ{
 		log:   s.log.WithField("who", "collections-cached-q"),
@@ -118,6 +130,22 @@ func (s *Storage) ItemChainMappingQ() data.ItemChainMappingQ {
 	}
 }
 
+func (s *Storage) ItemMetadatumQ() data.ItemMetadatumQ {
+	return s.raw.ItemMetadatumQ() // metadata is stored for the API to serve it without fetching, so it is not cached
+}
+
+func (s *Storage) SeedQ() data.SeedQ {
+	return s.raw.SeedQ() // seeds are looked up by external integrations only, so they are not cached
+}
+
+func (s *Storage) StuckTransferAlertQ() data.StuckTransferAlertQ {
+	return s.raw.StuckTransferAlertQ() // alerts are changed by every detector run, so they are not cached
+}
+
+func (s *Storage) TokenmanagerEventQ() data.TokenmanagerEventQ {
+	return s.raw.TokenmanagerEventQ() // history is append-only and requested rarely, so it is not cached
+}
+
 func (s *Storage) WithdrawalQ() data.WithdrawalQ {
 	return &WithdrawalQ{
 		log:   s.log.WithField("who", "withdrawal-cached-q"),
@@ -98,18 +98,22 @@ func (q *TransfersQ) TransferByIndexCtx(ctx context.Context, index []byte, isFor
 	return transfer, nil
 }
 
-func (q *TransfersQ) SetStatusByIndexCtx(ctx context.Context, status int, indexes ...string) error {
-	err := q.raw.SetStatusByIndexCtx(ctx, status, indexes...)
+func (q *TransfersQ) SetStatusByIndexCtx(ctx context.Context, status int, indexes ...string) ([]string, error) {
+	moved, err := q.raw.SetStatusByIndexCtx(ctx, status, indexes...)
 	if err != nil {
-		return errors.Wrap(err, "failed to set status")
+		return nil, errors.Wrap(err, "failed to set status")
 	}
 
-	opts := store.WithInvalidateTags(transferIndiciesTags(indexes))
+	if len(moved) == 0 {
+		return moved, nil
+	}
+
+	opts := store.WithInvalidateTags(transferIndiciesTags(moved))
 	if err := q.cache.Invalidate(ctx, opts); err != nil {
 		q.log.WithError(err).Error("failed to invalidate transfers cache")
 	}
 
-	return nil
+	return moved, nil
 }
 
 func (q *TransfersQ) cacheEveryTransfer(ctx context.Context, transfers []data.Transfer) error {
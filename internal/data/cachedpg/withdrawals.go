@@ -21,6 +21,19 @@ func (q *WithdrawalQ) InsertBatchCtx(ctx context.Context, withdrawals ...data.Wi
 	return q.raw.InsertBatchCtx(ctx, withdrawals...)
 }
 
+func (q *WithdrawalQ) RetractCtx(ctx context.Context, origin []byte, hash string) error {
+	if err := q.raw.RetractCtx(ctx, origin, hash); err != nil {
+		return errors.Wrap(err, "failed to retract withdrawal")
+	}
+
+	err := q.cache.Invalidate(ctx, store.WithInvalidateTags([]string{makeWithdrawalCacheOriginKey(hash)}))
+	if err != nil {
+		q.log.WithError(err).Error("failed to invalidate retracted withdrawal cache")
+	}
+
+	return nil
+}
+
 func (q *WithdrawalQ) WithdrawalByOriginCtx(ctx context.Context, origin []byte, _ bool) (*data.Withdrawal, error) {
 	var withdrawal *data.Withdrawal
 
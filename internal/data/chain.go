@@ -2,6 +2,8 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
 )
@@ -20,3 +22,25 @@ type Chain struct {
 	Icon        *string                  `fig:"icon"`
 	ChainParams json.RawMessage          `fig:"chain_params"`
 }
+
+type chainExplorerParams struct {
+	ExplorerURL string `json:"explorer_url"`
+}
+
+// ExplorerTxURL returns the link to the transaction in the chain explorer set by the `explorer_url` chain param or
+// nil if the explorer is not configured
+func (c Chain) ExplorerTxURL(hash string) *string {
+	var params chainExplorerParams
+	if err := json.Unmarshal(c.ChainParams, &params); err != nil || params.ExplorerURL == "" {
+		return nil
+	}
+
+	path := "tx"
+	switch c.Type {
+	case tokenmanager.NetworkType_Near, tokenmanager.NetworkType_Other, tokenmanager.NetworkType_Rarimo:
+		path = "transactions"
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", strings.TrimRight(params.ExplorerURL, "/"), path, hash)
+	return &url
+}
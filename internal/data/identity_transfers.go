@@ -0,0 +1,23 @@
+package data
+
+import (
+	"github.com/tendermint/tendermint/libs/bytes"
+	"gitlab.com/distributed_lab/kit/pgdb"
+)
+
+type IdentityTransferSelector struct {
+	Type       *int
+	Status     *int
+	Chain      *string
+	IdentityID *string
+	StateHash  *string
+	GISTHash   *string
+
+	PageCursor uint64
+	PageSize   uint64
+	Sort       pgdb.Sorts
+}
+
+func (t IdentityTransfer) RarimoTxHash() string {
+	return bytes.HexBytes(t.RarimoTx).String()
+}
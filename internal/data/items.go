@@ -1,6 +1,17 @@
 package data
 
+import "gitlab.com/distributed_lab/kit/pgdb"
+
 type ItemsSelector struct {
-	Network    *string `json:"network,omitempty"`
-	Collection *int64  `json:"collection,omitempty"`
+	Collection *int64 `json:"collection,omitempty"`
+
+	PageCursor uint64     `json:"page_cursor,omitempty"`
+	PageSize   uint64     `json:"page_size,omitempty"`
+	Sort       pgdb.Sorts `json:"sort"`
+}
+
+type CollectionsSelector struct {
+	PageCursor uint64     `json:"page_cursor,omitempty"`
+	PageSize   uint64     `json:"page_size,omitempty"`
+	Sort       pgdb.Sorts `json:"sort"`
 }
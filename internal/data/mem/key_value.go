@@ -0,0 +1,49 @@
+package mem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rarimo/horizon-svc/internal/data"
+)
+
+// NewKeyValueQ returns key-value storage which lives only in the process memory, it is used
+// by the one-shot commands which should not depend on redis
+func NewKeyValueQ() data.KeyValueQ {
+	return &keyValueQ{
+		values: make(map[string]data.KeyValue),
+	}
+}
+
+type keyValueQ struct {
+	mu     sync.RWMutex
+	values map[string]data.KeyValue
+}
+
+func (q *keyValueQ) Upsert(_ context.Context, kv data.KeyValue) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.values[kv.Key] = kv
+	return nil
+}
+
+func (q *keyValueQ) Get(_ context.Context, key string) (*data.KeyValue, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	kv, ok := q.values[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &kv, nil
+}
+
+func (q *keyValueQ) Remove(_ context.Context, key string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.values, key)
+	return nil
+}
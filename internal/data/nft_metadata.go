@@ -1,5 +1,33 @@
 package data
 
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"github.com/rarimo/xo/types/xo"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// NftTokenTypes are the token types of the collections which items have the nft metadata
+var NftTokenTypes = []tokenmanager.Type{
+	tokenmanager.Type_ERC721,
+	tokenmanager.Type_ERC1155,
+	tokenmanager.Type_NEAR_NFT,
+	tokenmanager.Type_METAPLEX_NFT,
+}
+
+func IsNftTokenType(tokenType tokenmanager.Type) bool {
+	for _, nftType := range NftTokenTypes {
+		if tokenType == nftType {
+			return true
+		}
+	}
+
+	return false
+}
+
 type NftMetadata struct {
 	Name         string         `json:"name"`
 	ImageURL     string         `json:"image_url"`
@@ -27,3 +55,41 @@ func (m *NftMetadata) Merge(metadata *NftMetadata) {
 		m.Attributes = metadata.Attributes
 	}
 }
+
+// SetFetched stores the fetched metadata in the entry resetting its failures, so it is fetched again after the
+// refresh period
+func (m *ItemMetadatum) SetFetched(metadata NftMetadata, refreshPeriod time.Duration) error {
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal metadata")
+	}
+
+	now := time.Now().UTC()
+
+	m.Metadata = xo.NullJsonb{Jsonb: raw, Valid: true}
+	m.FetchedAt = sql.NullTime{Time: now, Valid: true}
+	m.Failures = 0
+	m.LastError = sql.NullString{}
+	m.NextFetchAt = now.Add(refreshPeriod)
+	m.UpdatedAt = now
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+
+	return nil
+}
+
+// NftMetadata returns the stored metadata or nil if it was not fetched yet
+func (m ItemMetadatum) NftMetadata() (*NftMetadata, error) {
+	if !m.Metadata.Valid {
+		return nil, nil
+	}
+
+	var metadata NftMetadata
+	if err := json.Unmarshal(m.Metadata.Jsonb, &metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal metadata")
+	}
+
+	return &metadata, nil
+}
@@ -0,0 +1,24 @@
+package data
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	"gitlab.com/distributed_lab/kit/pgdb"
+)
+
+type OperationSelector struct {
+	Type    *int
+	Status  *int
+	Creator *string
+	Before  *time.Time
+	After   *time.Time
+
+	PageCursor uint64
+	PageSize   uint64
+	Sort       pgdb.Sorts
+}
+
+func (o Operation) RarimoTxHash() string {
+	return bytes.HexBytes(o.RarimoTx).String()
+}
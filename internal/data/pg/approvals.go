@@ -9,6 +9,10 @@ import (
 )
 
 func (q ApprovalQ) InsertBatchCtx(ctx context.Context, approvals ...data.Approval) error {
+	if len(approvals) == 0 {
+		return nil
+	}
+
 	stmt := squirrel.Insert("public.approvals").
 		Columns("transfer_index", "rarimo_transaction", "created_at")
 
@@ -17,5 +21,8 @@ func (q ApprovalQ) InsertBatchCtx(ctx context.Context, approvals ...data.Approva
 			Values(approval.TransferIndex, approval.RarimoTransaction, approval.CreatedAt)
 	}
 
+	// redelivered messages must not duplicate rows
+	stmt = stmt.Suffix("ON CONFLICT(transfer_index, rarimo_transaction) DO NOTHING")
+
 	return q.db.ExecContext(ctx, stmt)
 }
@@ -9,6 +9,10 @@ import (
 )
 
 func (q ConfirmationQ) InsertBatchCtx(ctx context.Context, confirmations ...data.Confirmation) error {
+	if len(confirmations) == 0 {
+		return nil
+	}
+
 	stmt := squirrel.Insert("public.confirmations").
 		Columns("transfer_index", "rarimo_transaction", "created_at")
 
@@ -17,5 +21,7 @@ func (q ConfirmationQ) InsertBatchCtx(ctx context.Context, confirmations ...data
 			Values(confirmation.TransferIndex, confirmation.RarimoTransaction, confirmation.CreatedAt)
 	}
 
+	stmt = stmt.Suffix("ON CONFLICT(transfer_index) DO NOTHING") // transfer is confirmed only once
+
 	return q.db.ExecContext(ctx, stmt)
 }
@@ -0,0 +1,103 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func (q IdentityTransferQ) UpsertBatchCtx(ctx context.Context, transfers ...data.IdentityTransfer) error {
+	transfers = uniqueByKey(transfers, func(row data.IdentityTransfer) []byte { return row.OperationIndex })
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	stmt := squirrel.Insert("public.identity_transfers").
+		Columns("operation_index", "type", "status",
+			"chain", "contract", "identity_id",
+			"state_hash", "state_created_at", "state_created_at_block",
+			"replaced_state_hash", "gist_hash", "gist_created_at",
+			"gist_created_at_block", "replaced_gist_hash", "state_root_hash",
+			"rarimo_tx", "rarimo_tx_timestamp", "created_at",
+			"updated_at")
+
+	for _, transfer := range transfers {
+		stmt = stmt.Values(
+			transfer.OperationIndex, transfer.Type, transfer.Status,
+			transfer.Chain, transfer.Contract, transfer.IdentityID,
+			transfer.StateHash, transfer.StateCreatedAt, transfer.StateCreatedAtBlock,
+			transfer.ReplacedStateHash, transfer.GistHash, transfer.GistCreatedAt,
+			transfer.GistCreatedAtBlock, transfer.ReplacedGistHash, transfer.StateRootHash,
+			transfer.RarimoTx, transfer.RarimoTxTimestamp, transfer.CreatedAt,
+			transfer.UpdatedAt)
+	}
+
+	stmt = stmt.Suffix(
+		`ON CONFLICT(operation_index) DO ` +
+			`UPDATE SET ` +
+			greatestStatus("identity_transfers") + `, updated_at = EXCLUDED.updated_at, type = EXCLUDED.type, chain = EXCLUDED.chain, contract = EXCLUDED.contract, identity_id = EXCLUDED.identity_id, state_hash = EXCLUDED.state_hash, state_created_at = EXCLUDED.state_created_at, state_created_at_block = EXCLUDED.state_created_at_block, replaced_state_hash = EXCLUDED.replaced_state_hash, gist_hash = EXCLUDED.gist_hash, gist_created_at = EXCLUDED.gist_created_at, gist_created_at_block = EXCLUDED.gist_created_at_block, replaced_gist_hash = EXCLUDED.replaced_gist_hash, state_root_hash = EXCLUDED.state_root_hash, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp `)
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+// SetStatusByIndexCtx moves status forward the same way as TransferQ.SetStatusByIndexCtx
+func (q IdentityTransferQ) SetStatusByIndexCtx(ctx context.Context, status int, indexes ...string) ([]string, error) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	stmt := squirrel.
+		Update("public.identity_transfers").
+		Set("status", status).
+		Where(squirrel.Eq{"operation_index": indexes}).
+		Where(squirrel.Lt{"status": status}).
+		Suffix("RETURNING operation_index")
+
+	var moved []string
+
+	if err := q.db.SelectContext(ctx, &moved, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to set transfers status")
+	}
+
+	return moved, nil
+}
+
+func (q IdentityTransferQ) SelectCtx(ctx context.Context, selector data.IdentityTransferSelector) ([]data.IdentityTransfer, error) {
+	stmt := squirrel.Select("*").From("public.identity_transfers")
+
+	if selector.Type != nil {
+		stmt = stmt.Where(squirrel.Eq{"type": selector.Type})
+	}
+
+	if selector.Status != nil {
+		stmt = stmt.Where(squirrel.Eq{"status": selector.Status})
+	}
+
+	if selector.Chain != nil {
+		stmt = stmt.Where(squirrel.Eq{"chain": selector.Chain})
+	}
+
+	if selector.IdentityID != nil {
+		stmt = stmt.Where(squirrel.Eq{"identity_id": selector.IdentityID})
+	}
+
+	if selector.StateHash != nil {
+		stmt = stmt.Where(squirrel.Eq{"state_hash": selector.StateHash})
+	}
+
+	if selector.GISTHash != nil {
+		stmt = stmt.Where(squirrel.Eq{"gist_hash": selector.GISTHash})
+	}
+
+	stmt = applyTransfersPagination(stmt, selector.Sort, selector.PageCursor, selector.PageSize)
+
+	var transfers []data.IdentityTransfer
+
+	if err := q.db.SelectContext(ctx, &transfers, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select identity transfers")
+	}
+
+	return transfers, nil
+}
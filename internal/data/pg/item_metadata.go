@@ -0,0 +1,52 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func (q ItemMetadatumQ) InsertMissingCtx(ctx context.Context, tokenTypes ...int) error {
+	if len(tokenTypes) == 0 {
+		return nil
+	}
+
+	missing := squirrel.Select("m.item", "m.network").
+		From("public.item_chain_mappings m").
+		Join("public.items i ON i.id = m.item").
+		Join("public.collection_chain_mappings c ON c.collection = i.collection AND c.network = m.network").
+		LeftJoin("public.item_metadata im ON im.item = m.item AND im.network = m.network").
+		Where("im.item IS NULL").
+		Where("i.deleted_at IS NULL").
+		Where(squirrel.Eq{"c.token_type": tokenTypes})
+
+	stmt := squirrel.Insert("public.item_metadata").
+		Columns("item", "network").
+		Select(missing).
+		Suffix("ON CONFLICT (item, network) DO NOTHING")
+
+	if err := q.db.ExecContext(ctx, stmt); err != nil {
+		return errors.Wrap(err, "failed to insert missing item metadata")
+	}
+
+	return nil
+}
+
+func (q ItemMetadatumQ) SelectDueCtx(ctx context.Context, before time.Time, limit uint64) ([]data.ItemMetadatum, error) {
+	stmt := squirrel.Select(colsItemMetadatum).
+		From("public.item_metadata").
+		Where(squirrel.LtOrEq{"next_fetch_at": before}).
+		OrderBy("next_fetch_at").
+		Limit(limit)
+
+	var result []data.ItemMetadatum
+
+	if err := q.db.SelectContext(ctx, &result, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select item metadata to fetch")
+	}
+
+	return result, nil
+}
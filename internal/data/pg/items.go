@@ -1 +1,70 @@
 package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func (q ItemQ) SelectCtx(ctx context.Context, selector data.ItemsSelector) ([]data.Item, error) {
+	stmt := squirrel.Select("*").From("public.items")
+
+	if selector.Collection != nil {
+		stmt = stmt.Where(squirrel.Eq{"collection": selector.Collection})
+	}
+
+	stmt = applyIDPagination(stmt, selector.Sort, selector.PageCursor, selector.PageSize)
+
+	var items []data.Item
+
+	if err := q.db.SelectContext(ctx, &items, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select items")
+	}
+
+	return items, nil
+}
+
+func (q CollectionQ) SelectCtx(ctx context.Context, selector data.CollectionsSelector) ([]data.Collection, error) {
+	stmt := applyIDPagination(
+		squirrel.Select("*").From("public.collections"),
+		selector.Sort, selector.PageCursor, selector.PageSize)
+
+	var collections []data.Collection
+
+	if err := q.db.SelectContext(ctx, &collections, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select collections")
+	}
+
+	return collections, nil
+}
+
+// applyIDPagination applies the cursor pagination by the id column, sorted by id ascending by default
+func applyIDPagination(stmt squirrel.SelectBuilder, sorts pgdb.Sorts, cursor, limit uint64) squirrel.SelectBuilder {
+	if limit != 0 {
+		stmt = stmt.Limit(limit)
+	}
+
+	if len(sorts) == 0 {
+		sorts = pgdb.Sorts{"id"}
+	}
+
+	stmt = sorts.ApplyTo(stmt, map[string]string{
+		"id": "id",
+	})
+
+	if cursor != 0 {
+		comp := ">"
+		if strings.HasPrefix(string(sorts[0]), "-") {
+			comp = "<"
+		}
+
+		stmt = stmt.Where(fmt.Sprintf("id %s ?", comp), cursor)
+	}
+
+	return stmt
+}
@@ -0,0 +1,92 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func (q OperationQ) UpsertBatchCtx(ctx context.Context, operations ...data.Operation) error {
+	operations = uniqueByKey(operations, func(row data.Operation) []byte { return row.Index })
+	if len(operations) == 0 {
+		return nil
+	}
+
+	stmt := squirrel.Insert("public.operations").
+		Columns("index", "type", "status",
+			"creator", "rarimo_tx", "rarimo_tx_timestamp",
+			"details", "created_at", "updated_at")
+
+	for _, operation := range operations {
+		stmt = stmt.Values(
+			operation.Index, operation.Type, operation.Status,
+			operation.Creator, operation.RarimoTx, operation.RarimoTxTimestamp,
+			operation.Details, operation.CreatedAt, operation.UpdatedAt)
+	}
+
+	stmt = stmt.Suffix(
+		`ON CONFLICT(index) DO ` +
+			`UPDATE SET ` +
+			greatestStatus("operations") + `, updated_at = EXCLUDED.updated_at, type = EXCLUDED.type, creator = EXCLUDED.creator, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, details = EXCLUDED.details `)
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+// SetStatusByIndexCtx moves status forward the same way as TransferQ.SetStatusByIndexCtx
+func (q OperationQ) SetStatusByIndexCtx(ctx context.Context, status int, indexes ...string) ([]string, error) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	stmt := squirrel.
+		Update("public.operations").
+		Set("status", status).
+		Where(squirrel.Eq{"index": indexes}).
+		Where(squirrel.Lt{"status": status}).
+		Suffix("RETURNING index")
+
+	var moved []string
+
+	if err := q.db.SelectContext(ctx, &moved, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to set operations status")
+	}
+
+	return moved, nil
+}
+
+func (q OperationQ) SelectCtx(ctx context.Context, selector data.OperationSelector) ([]data.Operation, error) {
+	stmt := squirrel.Select("*").From("public.operations")
+
+	if selector.Type != nil {
+		stmt = stmt.Where(squirrel.Eq{"type": selector.Type})
+	}
+
+	if selector.Status != nil {
+		stmt = stmt.Where(squirrel.Eq{"status": selector.Status})
+	}
+
+	if selector.Creator != nil {
+		stmt = stmt.Where(squirrel.Eq{"creator": selector.Creator})
+	}
+
+	if selector.Before != nil {
+		stmt = stmt.Where(squirrel.Lt{"rarimo_tx_timestamp": selector.Before})
+	}
+
+	if selector.After != nil {
+		stmt = stmt.Where(squirrel.Gt{"rarimo_tx_timestamp": selector.After})
+	}
+
+	// operations are sorted and paginated the same way as transfers
+	stmt = applyTransfersPagination(stmt, selector.Sort, selector.PageCursor, selector.PageSize)
+
+	var operations []data.Operation
+
+	if err := q.db.SelectContext(ctx, &operations, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select operations")
+	}
+
+	return operations, nil
+}
@@ -9,6 +9,10 @@ import (
 )
 
 func (q RejectionQ) InsertBatchCtx(ctx context.Context, rejections ...data.Rejection) error {
+	if len(rejections) == 0 {
+		return nil
+	}
+
 	stmt := squirrel.Insert("public.rejections").
 		Columns("transfer_index", "rarimo_transaction", "created_at")
 
@@ -17,5 +21,8 @@ func (q RejectionQ) InsertBatchCtx(ctx context.Context, rejections ...data.Rejec
 			Values(rejection.TransferIndex, rejection.RarimoTransaction, rejection.CreatedAt)
 	}
 
+	// redelivered messages must not duplicate rows
+	stmt = stmt.Suffix("ON CONFLICT(transfer_index, rarimo_transaction) DO NOTHING")
+
 	return q.db.ExecContext(ctx, stmt)
 }
@@ -6,6 +6,7 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/rarimo/horizon-svc/internal/data"
 	"gitlab.com/distributed_lab/kit/pgdb"
@@ -150,19 +151,19 @@ func (s Storage) CollectionQ() data.CollectionQ {
 	return NewCollectionQ(s.DB())
 }
 
-var colsCollection = `id, index, metadata, created_at, updated_at`
+var colsCollection = `id, index, metadata, created_at, updated_at, deleted_at`
 
 // InsertCtx inserts a Collection to the database.
 func (q CollectionQ) InsertCtx(ctx context.Context, c *data.Collection) error {
 	// insert (primary key generated and returned by database)
 	sqlstr := `INSERT INTO public.collections (` +
-		`index, metadata, created_at, updated_at` +
+		`index, metadata, created_at, updated_at, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5` +
 		`) RETURNING id`
 		// run
 
-	err := q.db.GetRawContext(ctx, &c.ID, sqlstr, c.Index, c.Metadata, c.CreatedAt, c.UpdatedAt)
+	err := q.db.GetRawContext(ctx, &c.ID, sqlstr, c.Index, c.Metadata, c.CreatedAt, c.UpdatedAt, c.DeletedAt)
 	if err != nil {
 		return errors.Wrap(err, "failed to execute insert")
 	}
@@ -179,10 +180,10 @@ func (q CollectionQ) Insert(c *data.Collection) error {
 func (q CollectionQ) UpdateCtx(ctx context.Context, c *data.Collection) error {
 	// update with composite primary key
 	sqlstr := `UPDATE public.collections SET ` +
-		`index = $1, metadata = $2, updated_at = $3 ` +
-		`WHERE id = $4`
+		`index = $1, metadata = $2, updated_at = $3, deleted_at = $4 ` +
+		`WHERE id = $5`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, c.Index, c.Metadata, c.UpdatedAt, c.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, c.Index, c.Metadata, c.UpdatedAt, c.DeletedAt, c.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
@@ -195,15 +196,15 @@ func (q CollectionQ) Update(c *data.Collection) error {
 func (q CollectionQ) UpsertCtx(ctx context.Context, c *data.Collection) error {
 	// upsert
 	sqlstr := `INSERT INTO public.collections (` +
-		`id, index, metadata, created_at, updated_at` +
+		`id, index, metadata, created_at, updated_at, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5` +
+		`$1, $2, $3, $4, $5, $6` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`index = EXCLUDED.index, metadata = EXCLUDED.metadata, updated_at = EXCLUDED.updated_at `
+		`index = EXCLUDED.index, metadata = EXCLUDED.metadata, updated_at = EXCLUDED.updated_at, deleted_at = EXCLUDED.deleted_at `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, c.ID, c.Index, c.Metadata, c.CreatedAt, c.UpdatedAt); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, c.ID, c.Index, c.Metadata, c.CreatedAt, c.UpdatedAt, c.DeletedAt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
@@ -507,6 +508,102 @@ func (q GorpMigrationQ) DeleteCtx(ctx context.Context, gm *data.GorpMigration) e
 // Delete deletes the GorpMigration from the database.
 func (q GorpMigrationQ) Delete(gm *data.GorpMigration) error {
 	return q.DeleteCtx(context.Background(), gm)
+} // IdentityTransferQ represents helper struct to access row of 'identity_transfers'.
+type IdentityTransferQ struct {
+	db *pgdb.DB
+}
+
+// NewIdentityTransferQ  - creates new instance
+func NewIdentityTransferQ(db *pgdb.DB) IdentityTransferQ {
+	return IdentityTransferQ{
+		db,
+	}
+}
+
+// IdentityTransferQ  - creates new instance of IdentityTransferQ
+func (s Storage) IdentityTransferQ() data.IdentityTransferQ {
+	return NewIdentityTransferQ(s.DB())
+}
+
+var colsIdentityTransfer = `id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at`
+
+// InsertCtx inserts a IdentityTransfer to the database.
+func (q IdentityTransferQ) InsertCtx(ctx context.Context, it *data.IdentityTransfer) error {
+	// insert (primary key generated and returned by database)
+	sqlstr := `INSERT INTO public.identity_transfers (` +
+		`operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19` +
+		`) RETURNING id`
+		// run
+
+	err := q.db.GetRawContext(ctx, &it.ID, sqlstr, it.OperationIndex, it.Type, it.Status, it.Chain, it.Contract, it.IdentityID, it.StateHash, it.StateCreatedAt, it.StateCreatedAtBlock, it.ReplacedStateHash, it.GistHash, it.GistCreatedAt, it.GistCreatedAtBlock, it.ReplacedGistHash, it.StateRootHash, it.RarimoTx, it.RarimoTxTimestamp, it.CreatedAt, it.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute insert")
+	}
+
+	return nil
+}
+
+// Insert insert a IdentityTransfer to the database.
+func (q IdentityTransferQ) Insert(it *data.IdentityTransfer) error {
+	return q.InsertCtx(context.Background(), it)
+}
+
+// UpdateCtx updates a IdentityTransfer in the database.
+func (q IdentityTransferQ) UpdateCtx(ctx context.Context, it *data.IdentityTransfer) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.identity_transfers SET ` +
+		`operation_index = $1, type = $2, status = $3, chain = $4, contract = $5, identity_id = $6, state_hash = $7, state_created_at = $8, state_created_at_block = $9, replaced_state_hash = $10, gist_hash = $11, gist_created_at = $12, gist_created_at_block = $13, replaced_gist_hash = $14, state_root_hash = $15, rarimo_tx = $16, rarimo_tx_timestamp = $17, updated_at = $18 ` +
+		`WHERE id = $19`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, it.OperationIndex, it.Type, it.Status, it.Chain, it.Contract, it.IdentityID, it.StateHash, it.StateCreatedAt, it.StateCreatedAtBlock, it.ReplacedStateHash, it.GistHash, it.GistCreatedAt, it.GistCreatedAtBlock, it.ReplacedGistHash, it.StateRootHash, it.RarimoTx, it.RarimoTxTimestamp, it.UpdatedAt, it.ID)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a IdentityTransfer in the database.
+func (q IdentityTransferQ) Update(it *data.IdentityTransfer) error {
+	return q.UpdateCtx(context.Background(), it)
+}
+
+// UpsertCtx performs an upsert for IdentityTransfer.
+func (q IdentityTransferQ) UpsertCtx(ctx context.Context, it *data.IdentityTransfer) error {
+	// upsert
+	sqlstr := `INSERT INTO public.identity_transfers (` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20` +
+		`)` +
+		` ON CONFLICT (id) DO ` +
+		`UPDATE SET ` +
+		`operation_index = EXCLUDED.operation_index, type = EXCLUDED.type, status = EXCLUDED.status, chain = EXCLUDED.chain, contract = EXCLUDED.contract, identity_id = EXCLUDED.identity_id, state_hash = EXCLUDED.state_hash, state_created_at = EXCLUDED.state_created_at, state_created_at_block = EXCLUDED.state_created_at_block, replaced_state_hash = EXCLUDED.replaced_state_hash, gist_hash = EXCLUDED.gist_hash, gist_created_at = EXCLUDED.gist_created_at, gist_created_at_block = EXCLUDED.gist_created_at_block, replaced_gist_hash = EXCLUDED.replaced_gist_hash, state_root_hash = EXCLUDED.state_root_hash, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, updated_at = EXCLUDED.updated_at `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, it.ID, it.OperationIndex, it.Type, it.Status, it.Chain, it.Contract, it.IdentityID, it.StateHash, it.StateCreatedAt, it.StateCreatedAtBlock, it.ReplacedStateHash, it.GistHash, it.GistCreatedAt, it.GistCreatedAtBlock, it.ReplacedGistHash, it.StateRootHash, it.RarimoTx, it.RarimoTxTimestamp, it.CreatedAt, it.UpdatedAt); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for IdentityTransfer.
+func (q IdentityTransferQ) Upsert(it *data.IdentityTransfer) error {
+	return q.UpsertCtx(context.Background(), it)
+}
+
+// DeleteCtx deletes the IdentityTransfer from the database.
+func (q IdentityTransferQ) DeleteCtx(ctx context.Context, it *data.IdentityTransfer) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.identity_transfers ` +
+		`WHERE id = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, it.ID); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the IdentityTransfer from the database.
+func (q IdentityTransferQ) Delete(it *data.IdentityTransfer) error {
+	return q.DeleteCtx(context.Background(), it)
 } // ItemQ represents helper struct to access row of 'items'.
 type ItemQ struct {
 	db *pgdb.DB
@@ -524,19 +621,19 @@ func (s Storage) ItemQ() data.ItemQ {
 	return NewItemQ(s.DB())
 }
 
-var colsItem = `id, index, collection, metadata, created_at, updated_at`
+var colsItem = `id, index, collection, metadata, created_at, updated_at, deleted_at`
 
 // InsertCtx inserts a Item to the database.
 func (q ItemQ) InsertCtx(ctx context.Context, i *data.Item) error {
 	// insert (primary key generated and returned by database)
 	sqlstr := `INSERT INTO public.items (` +
-		`index, collection, metadata, created_at, updated_at` +
+		`index, collection, metadata, created_at, updated_at, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5` +
+		`$1, $2, $3, $4, $5, $6` +
 		`) RETURNING id`
 		// run
 
-	err := q.db.GetRawContext(ctx, &i.ID, sqlstr, i.Index, i.Collection, i.Metadata, i.CreatedAt, i.UpdatedAt)
+	err := q.db.GetRawContext(ctx, &i.ID, sqlstr, i.Index, i.Collection, i.Metadata, i.CreatedAt, i.UpdatedAt, i.DeletedAt)
 	if err != nil {
 		return errors.Wrap(err, "failed to execute insert")
 	}
@@ -553,10 +650,10 @@ func (q ItemQ) Insert(i *data.Item) error {
 func (q ItemQ) UpdateCtx(ctx context.Context, i *data.Item) error {
 	// update with composite primary key
 	sqlstr := `UPDATE public.items SET ` +
-		`index = $1, collection = $2, metadata = $3, updated_at = $4 ` +
-		`WHERE id = $5`
+		`index = $1, collection = $2, metadata = $3, updated_at = $4, deleted_at = $5 ` +
+		`WHERE id = $6`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, i.Index, i.Collection, i.Metadata, i.UpdatedAt, i.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, i.Index, i.Collection, i.Metadata, i.UpdatedAt, i.DeletedAt, i.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
@@ -569,15 +666,15 @@ func (q ItemQ) Update(i *data.Item) error {
 func (q ItemQ) UpsertCtx(ctx context.Context, i *data.Item) error {
 	// upsert
 	sqlstr := `INSERT INTO public.items (` +
-		`id, index, collection, metadata, created_at, updated_at` +
+		`id, index, collection, metadata, created_at, updated_at, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`index = EXCLUDED.index, collection = EXCLUDED.collection, metadata = EXCLUDED.metadata, updated_at = EXCLUDED.updated_at `
+		`index = EXCLUDED.index, collection = EXCLUDED.collection, metadata = EXCLUDED.metadata, updated_at = EXCLUDED.updated_at, deleted_at = EXCLUDED.deleted_at `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, i.ID, i.Index, i.Collection, i.Metadata, i.CreatedAt, i.UpdatedAt); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, i.ID, i.Index, i.Collection, i.Metadata, i.CreatedAt, i.UpdatedAt, i.DeletedAt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
@@ -694,6 +791,193 @@ func (q ItemChainMappingQ) DeleteCtx(ctx context.Context, icm *data.ItemChainMap
 // Delete deletes the ItemChainMapping from the database.
 func (q ItemChainMappingQ) Delete(icm *data.ItemChainMapping) error {
 	return q.DeleteCtx(context.Background(), icm)
+} // ItemMetadatumQ represents helper struct to access row of 'item_metadata'.
+type ItemMetadatumQ struct {
+	db *pgdb.DB
+}
+
+// NewItemMetadatumQ  - creates new instance
+func NewItemMetadatumQ(db *pgdb.DB) ItemMetadatumQ {
+	return ItemMetadatumQ{
+		db,
+	}
+}
+
+// ItemMetadatumQ  - creates new instance of ItemMetadatumQ
+func (s Storage) ItemMetadatumQ() data.ItemMetadatumQ {
+	return NewItemMetadatumQ(s.DB())
+}
+
+var colsItemMetadatum = `item, network, metadata, fetched_at, failures, last_error, next_fetch_at, created_at, updated_at`
+
+// InsertCtx inserts a ItemMetadatum to the database.
+func (q ItemMetadatumQ) InsertCtx(ctx context.Context, im *data.ItemMetadatum) error {
+	// sql insert query, primary key must be provided
+	sqlstr := `INSERT INTO public.item_metadata (` +
+		`item, network, metadata, fetched_at, failures, last_error, next_fetch_at, created_at, updated_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
+		`)`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, im.Item, im.Network, im.Metadata, im.FetchedAt, im.Failures, im.LastError, im.NextFetchAt, im.CreatedAt, im.UpdatedAt)
+	return errors.Wrap(err, "failed to execute insert query")
+}
+
+// Insert insert a ItemMetadatum to the database.
+func (q ItemMetadatumQ) Insert(im *data.ItemMetadatum) error {
+	return q.InsertCtx(context.Background(), im)
+}
+
+// UpdateCtx updates a ItemMetadatum in the database.
+func (q ItemMetadatumQ) UpdateCtx(ctx context.Context, im *data.ItemMetadatum) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.item_metadata SET ` +
+		`metadata = $1, fetched_at = $2, failures = $3, last_error = $4, next_fetch_at = $5, updated_at = $6 ` +
+		`WHERE item = $7 AND network = $8`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, im.Metadata, im.FetchedAt, im.Failures, im.LastError, im.NextFetchAt, im.UpdatedAt, im.Item, im.Network)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a ItemMetadatum in the database.
+func (q ItemMetadatumQ) Update(im *data.ItemMetadatum) error {
+	return q.UpdateCtx(context.Background(), im)
+}
+
+// UpsertCtx performs an upsert for ItemMetadatum.
+func (q ItemMetadatumQ) UpsertCtx(ctx context.Context, im *data.ItemMetadatum) error {
+	// upsert
+	sqlstr := `INSERT INTO public.item_metadata (` +
+		`item, network, metadata, fetched_at, failures, last_error, next_fetch_at, created_at, updated_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
+		`)` +
+		` ON CONFLICT (item, network) DO ` +
+		`UPDATE SET ` +
+		`metadata = EXCLUDED.metadata, fetched_at = EXCLUDED.fetched_at, failures = EXCLUDED.failures, last_error = EXCLUDED.last_error, next_fetch_at = EXCLUDED.next_fetch_at, updated_at = EXCLUDED.updated_at `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, im.Item, im.Network, im.Metadata, im.FetchedAt, im.Failures, im.LastError, im.NextFetchAt, im.CreatedAt, im.UpdatedAt); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for ItemMetadatum.
+func (q ItemMetadatumQ) Upsert(im *data.ItemMetadatum) error {
+	return q.UpsertCtx(context.Background(), im)
+}
+
+// DeleteCtx deletes the ItemMetadatum from the database.
+func (q ItemMetadatumQ) DeleteCtx(ctx context.Context, im *data.ItemMetadatum) error {
+	// delete with composite primary key
+	sqlstr := `DELETE FROM public.item_metadata ` +
+		`WHERE item = $1 AND network = $2`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, im.Item, im.Network); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the ItemMetadatum from the database.
+func (q ItemMetadatumQ) Delete(im *data.ItemMetadatum) error {
+	return q.DeleteCtx(context.Background(), im)
+} // OperationQ represents helper struct to access row of 'operations'.
+type OperationQ struct {
+	db *pgdb.DB
+}
+
+// NewOperationQ  - creates new instance
+func NewOperationQ(db *pgdb.DB) OperationQ {
+	return OperationQ{
+		db,
+	}
+}
+
+// OperationQ  - creates new instance of OperationQ
+func (s Storage) OperationQ() data.OperationQ {
+	return NewOperationQ(s.DB())
+}
+
+var colsOperation = `id, index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at`
+
+// InsertCtx inserts a Operation to the database.
+func (q OperationQ) InsertCtx(ctx context.Context, o *data.Operation) error {
+	// insert (primary key generated and returned by database)
+	sqlstr := `INSERT INTO public.operations (` +
+		`index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
+		`) RETURNING id`
+		// run
+
+	err := q.db.GetRawContext(ctx, &o.ID, sqlstr, o.Index, o.Type, o.Status, o.Creator, o.RarimoTx, o.RarimoTxTimestamp, o.Details, o.CreatedAt, o.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute insert")
+	}
+
+	return nil
+}
+
+// Insert insert a Operation to the database.
+func (q OperationQ) Insert(o *data.Operation) error {
+	return q.InsertCtx(context.Background(), o)
+}
+
+// UpdateCtx updates a Operation in the database.
+func (q OperationQ) UpdateCtx(ctx context.Context, o *data.Operation) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.operations SET ` +
+		`index = $1, type = $2, status = $3, creator = $4, rarimo_tx = $5, rarimo_tx_timestamp = $6, details = $7, updated_at = $8 ` +
+		`WHERE id = $9`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, o.Index, o.Type, o.Status, o.Creator, o.RarimoTx, o.RarimoTxTimestamp, o.Details, o.UpdatedAt, o.ID)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a Operation in the database.
+func (q OperationQ) Update(o *data.Operation) error {
+	return q.UpdateCtx(context.Background(), o)
+}
+
+// UpsertCtx performs an upsert for Operation.
+func (q OperationQ) UpsertCtx(ctx context.Context, o *data.Operation) error {
+	// upsert
+	sqlstr := `INSERT INTO public.operations (` +
+		`id, index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
+		`)` +
+		` ON CONFLICT (id) DO ` +
+		`UPDATE SET ` +
+		`index = EXCLUDED.index, type = EXCLUDED.type, status = EXCLUDED.status, creator = EXCLUDED.creator, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, details = EXCLUDED.details, updated_at = EXCLUDED.updated_at `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, o.ID, o.Index, o.Type, o.Status, o.Creator, o.RarimoTx, o.RarimoTxTimestamp, o.Details, o.CreatedAt, o.UpdatedAt); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for Operation.
+func (q OperationQ) Upsert(o *data.Operation) error {
+	return q.UpsertCtx(context.Background(), o)
+}
+
+// DeleteCtx deletes the Operation from the database.
+func (q OperationQ) DeleteCtx(ctx context.Context, o *data.Operation) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.operations ` +
+		`WHERE id = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, o.ID); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the Operation from the database.
+func (q OperationQ) Delete(o *data.Operation) error {
+	return q.DeleteCtx(context.Background(), o)
 } // RejectionQ represents helper struct to access row of 'rejections'.
 type RejectionQ struct {
 	db *pgdb.DB
@@ -790,127 +1074,127 @@ func (q RejectionQ) DeleteCtx(ctx context.Context, r *data.Rejection) error {
 // Delete deletes the Rejection from the database.
 func (q RejectionQ) Delete(r *data.Rejection) error {
 	return q.DeleteCtx(context.Background(), r)
-} // TransactionQ represents helper struct to access row of 'transactions'.
-type TransactionQ struct {
+} // SeedQ represents helper struct to access row of 'seeds'.
+type SeedQ struct {
 	db *pgdb.DB
 }
 
-// NewTransactionQ  - creates new instance
-func NewTransactionQ(db *pgdb.DB) TransactionQ {
-	return TransactionQ{
+// NewSeedQ  - creates new instance
+func NewSeedQ(db *pgdb.DB) SeedQ {
+	return SeedQ{
 		db,
 	}
 }
 
-// TransactionQ  - creates new instance of TransactionQ
-func (s Storage) TransactionQ() data.TransactionQ {
-	return NewTransactionQ(s.DB())
+// SeedQ  - creates new instance of SeedQ
+func (s Storage) SeedQ() data.SeedQ {
+	return NewSeedQ(s.DB())
 }
 
-var colsTransaction = `hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at`
+var colsSeed = `seed, item, created_tx, created_at`
 
-// InsertCtx inserts a Transaction to the database.
-func (q TransactionQ) InsertCtx(ctx context.Context, t *data.Transaction) error {
+// InsertCtx inserts a Seed to the database.
+func (q SeedQ) InsertCtx(ctx context.Context, s *data.Seed) error {
 	// sql insert query, primary key must be provided
-	sqlstr := `INSERT INTO public.transactions (` +
-		`hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at` +
+	sqlstr := `INSERT INTO public.seeds (` +
+		`seed, item, created_tx, created_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7` +
+		`$1, $2, $3, $4` +
 		`)`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, t.Hash, t.BlockHeight, t.Index, t.RawTx, t.TxResult, t.TxTimestamp, t.CreatedAt)
+	err := q.db.ExecRawContext(ctx, sqlstr, s.Seed, s.Item, s.CreatedTx, s.CreatedAt)
 	return errors.Wrap(err, "failed to execute insert query")
 }
 
-// Insert insert a Transaction to the database.
-func (q TransactionQ) Insert(t *data.Transaction) error {
-	return q.InsertCtx(context.Background(), t)
+// Insert insert a Seed to the database.
+func (q SeedQ) Insert(s *data.Seed) error {
+	return q.InsertCtx(context.Background(), s)
 }
 
-// UpdateCtx updates a Transaction in the database.
-func (q TransactionQ) UpdateCtx(ctx context.Context, t *data.Transaction) error {
+// UpdateCtx updates a Seed in the database.
+func (q SeedQ) UpdateCtx(ctx context.Context, s *data.Seed) error {
 	// update with composite primary key
-	sqlstr := `UPDATE public.transactions SET ` +
-		`block_height = $1, index = $2, raw_tx = $3, tx_result = $4, tx_timestamp = $5 ` +
-		`WHERE hash = $6`
+	sqlstr := `UPDATE public.seeds SET ` +
+		`item = $1, created_tx = $2 ` +
+		`WHERE seed = $3`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, t.BlockHeight, t.Index, t.RawTx, t.TxResult, t.TxTimestamp, t.Hash)
+	err := q.db.ExecRawContext(ctx, sqlstr, s.Item, s.CreatedTx, s.Seed)
 	return errors.Wrap(err, "failed to execute update")
 }
 
-// Update updates a Transaction in the database.
-func (q TransactionQ) Update(t *data.Transaction) error {
-	return q.UpdateCtx(context.Background(), t)
+// Update updates a Seed in the database.
+func (q SeedQ) Update(s *data.Seed) error {
+	return q.UpdateCtx(context.Background(), s)
 }
 
-// UpsertCtx performs an upsert for Transaction.
-func (q TransactionQ) UpsertCtx(ctx context.Context, t *data.Transaction) error {
+// UpsertCtx performs an upsert for Seed.
+func (q SeedQ) UpsertCtx(ctx context.Context, s *data.Seed) error {
 	// upsert
-	sqlstr := `INSERT INTO public.transactions (` +
-		`hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at` +
+	sqlstr := `INSERT INTO public.seeds (` +
+		`seed, item, created_tx, created_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7` +
+		`$1, $2, $3, $4` +
 		`)` +
-		` ON CONFLICT (hash) DO ` +
+		` ON CONFLICT (seed) DO ` +
 		`UPDATE SET ` +
-		`block_height = EXCLUDED.block_height, index = EXCLUDED.index, raw_tx = EXCLUDED.raw_tx, tx_result = EXCLUDED.tx_result, tx_timestamp = EXCLUDED.tx_timestamp `
+		`item = EXCLUDED.item, created_tx = EXCLUDED.created_tx `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, t.Hash, t.BlockHeight, t.Index, t.RawTx, t.TxResult, t.TxTimestamp, t.CreatedAt); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, s.Seed, s.Item, s.CreatedTx, s.CreatedAt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
 }
 
-// Upsert performs an upsert for Transaction.
-func (q TransactionQ) Upsert(t *data.Transaction) error {
-	return q.UpsertCtx(context.Background(), t)
+// Upsert performs an upsert for Seed.
+func (q SeedQ) Upsert(s *data.Seed) error {
+	return q.UpsertCtx(context.Background(), s)
 }
 
-// DeleteCtx deletes the Transaction from the database.
-func (q TransactionQ) DeleteCtx(ctx context.Context, t *data.Transaction) error {
+// DeleteCtx deletes the Seed from the database.
+func (q SeedQ) DeleteCtx(ctx context.Context, s *data.Seed) error {
 	// delete with single primary key
-	sqlstr := `DELETE FROM public.transactions ` +
-		`WHERE hash = $1`
+	sqlstr := `DELETE FROM public.seeds ` +
+		`WHERE seed = $1`
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, t.Hash); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, s.Seed); err != nil {
 		return errors.Wrap(err, "failed to exec delete stmt")
 	}
 	return nil
 }
 
-// Delete deletes the Transaction from the database.
-func (q TransactionQ) Delete(t *data.Transaction) error {
-	return q.DeleteCtx(context.Background(), t)
-} // TransferQ represents helper struct to access row of 'transfers'.
-type TransferQ struct {
+// Delete deletes the Seed from the database.
+func (q SeedQ) Delete(s *data.Seed) error {
+	return q.DeleteCtx(context.Background(), s)
+} // StuckTransferAlertQ represents helper struct to access row of 'stuck_transfer_alerts'.
+type StuckTransferAlertQ struct {
 	db *pgdb.DB
 }
 
-// NewTransferQ  - creates new instance
-func NewTransferQ(db *pgdb.DB) TransferQ {
-	return TransferQ{
+// NewStuckTransferAlertQ  - creates new instance
+func NewStuckTransferAlertQ(db *pgdb.DB) StuckTransferAlertQ {
+	return StuckTransferAlertQ{
 		db,
 	}
 }
 
-// TransferQ  - creates new instance of TransferQ
-func (s Storage) TransferQ() data.TransferQ {
-	return NewTransferQ(s.DB())
+// StuckTransferAlertQ  - creates new instance of StuckTransferAlertQ
+func (s Storage) StuckTransferAlertQ() data.StuckTransferAlertQ {
+	return NewStuckTransferAlertQ(s.DB())
 }
 
-var colsTransfer = `id, index, status, created_at, updated_at, creator, rarimo_tx, rarimo_tx_timestamp, origin, tx, event_id, from_chain, to_chain, receiver, amount, bundle_data, bundle_salt, item_index`
+var colsStuckTransferAlert = `id, transfer_index, kind, origin, chain, status, since, detected_at, notified_at, resolved_at`
 
-// InsertCtx inserts a Transfer to the database.
-func (q TransferQ) InsertCtx(ctx context.Context, t *data.Transfer) error {
+// InsertCtx inserts a StuckTransferAlert to the database.
+func (q StuckTransferAlertQ) InsertCtx(ctx context.Context, sta *data.StuckTransferAlert) error {
 	// insert (primary key generated and returned by database)
-	sqlstr := `INSERT INTO public.transfers (` +
-		`index, status, created_at, updated_at, creator, rarimo_tx, rarimo_tx_timestamp, origin, tx, event_id, from_chain, to_chain, receiver, amount, bundle_data, bundle_salt, item_index` +
+	sqlstr := `INSERT INTO public.stuck_transfer_alerts (` +
+		`transfer_index, kind, origin, chain, status, since, detected_at, notified_at, resolved_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
 		`) RETURNING id`
 		// run
 
-	err := q.db.GetRawContext(ctx, &t.ID, sqlstr, t.Index, t.Status, t.CreatedAt, t.UpdatedAt, t.Creator, t.RarimoTx, t.RarimoTxTimestamp, t.Origin, t.Tx, t.EventID, t.FromChain, t.ToChain, t.Receiver, t.Amount, t.BundleData, t.BundleSalt, t.ItemIndex)
+	err := q.db.GetRawContext(ctx, &sta.ID, sqlstr, sta.TransferIndex, sta.Kind, sta.Origin, sta.Chain, sta.Status, sta.Since, sta.DetectedAt, sta.NotifiedAt, sta.ResolvedAt)
 	if err != nil {
 		return errors.Wrap(err, "failed to execute insert")
 	}
@@ -918,95 +1202,95 @@ func (q TransferQ) InsertCtx(ctx context.Context, t *data.Transfer) error {
 	return nil
 }
 
-// Insert insert a Transfer to the database.
-func (q TransferQ) Insert(t *data.Transfer) error {
-	return q.InsertCtx(context.Background(), t)
+// Insert insert a StuckTransferAlert to the database.
+func (q StuckTransferAlertQ) Insert(sta *data.StuckTransferAlert) error {
+	return q.InsertCtx(context.Background(), sta)
 }
 
-// UpdateCtx updates a Transfer in the database.
-func (q TransferQ) UpdateCtx(ctx context.Context, t *data.Transfer) error {
+// UpdateCtx updates a StuckTransferAlert in the database.
+func (q StuckTransferAlertQ) UpdateCtx(ctx context.Context, sta *data.StuckTransferAlert) error {
 	// update with composite primary key
-	sqlstr := `UPDATE public.transfers SET ` +
-		`index = $1, status = $2, updated_at = $3, creator = $4, rarimo_tx = $5, rarimo_tx_timestamp = $6, origin = $7, tx = $8, event_id = $9, from_chain = $10, to_chain = $11, receiver = $12, amount = $13, bundle_data = $14, bundle_salt = $15, item_index = $16 ` +
-		`WHERE id = $17`
+	sqlstr := `UPDATE public.stuck_transfer_alerts SET ` +
+		`transfer_index = $1, kind = $2, origin = $3, chain = $4, status = $5, since = $6, detected_at = $7, notified_at = $8, resolved_at = $9 ` +
+		`WHERE id = $10`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, t.Index, t.Status, t.UpdatedAt, t.Creator, t.RarimoTx, t.RarimoTxTimestamp, t.Origin, t.Tx, t.EventID, t.FromChain, t.ToChain, t.Receiver, t.Amount, t.BundleData, t.BundleSalt, t.ItemIndex, t.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, sta.TransferIndex, sta.Kind, sta.Origin, sta.Chain, sta.Status, sta.Since, sta.DetectedAt, sta.NotifiedAt, sta.ResolvedAt, sta.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
-// Update updates a Transfer in the database.
-func (q TransferQ) Update(t *data.Transfer) error {
-	return q.UpdateCtx(context.Background(), t)
+// Update updates a StuckTransferAlert in the database.
+func (q StuckTransferAlertQ) Update(sta *data.StuckTransferAlert) error {
+	return q.UpdateCtx(context.Background(), sta)
 }
 
-// UpsertCtx performs an upsert for Transfer.
-func (q TransferQ) UpsertCtx(ctx context.Context, t *data.Transfer) error {
+// UpsertCtx performs an upsert for StuckTransferAlert.
+func (q StuckTransferAlertQ) UpsertCtx(ctx context.Context, sta *data.StuckTransferAlert) error {
 	// upsert
-	sqlstr := `INSERT INTO public.transfers (` +
-		`id, index, status, created_at, updated_at, creator, rarimo_tx, rarimo_tx_timestamp, origin, tx, event_id, from_chain, to_chain, receiver, amount, bundle_data, bundle_salt, item_index` +
+	sqlstr := `INSERT INTO public.stuck_transfer_alerts (` +
+		`id, transfer_index, kind, origin, chain, status, since, detected_at, notified_at, resolved_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`index = EXCLUDED.index, status = EXCLUDED.status, updated_at = EXCLUDED.updated_at, creator = EXCLUDED.creator, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, origin = EXCLUDED.origin, tx = EXCLUDED.tx, event_id = EXCLUDED.event_id, from_chain = EXCLUDED.from_chain, to_chain = EXCLUDED.to_chain, receiver = EXCLUDED.receiver, amount = EXCLUDED.amount, bundle_data = EXCLUDED.bundle_data, bundle_salt = EXCLUDED.bundle_salt, item_index = EXCLUDED.item_index `
+		`transfer_index = EXCLUDED.transfer_index, kind = EXCLUDED.kind, origin = EXCLUDED.origin, chain = EXCLUDED.chain, status = EXCLUDED.status, since = EXCLUDED.since, detected_at = EXCLUDED.detected_at, notified_at = EXCLUDED.notified_at, resolved_at = EXCLUDED.resolved_at `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, t.ID, t.Index, t.Status, t.CreatedAt, t.UpdatedAt, t.Creator, t.RarimoTx, t.RarimoTxTimestamp, t.Origin, t.Tx, t.EventID, t.FromChain, t.ToChain, t.Receiver, t.Amount, t.BundleData, t.BundleSalt, t.ItemIndex); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, sta.ID, sta.TransferIndex, sta.Kind, sta.Origin, sta.Chain, sta.Status, sta.Since, sta.DetectedAt, sta.NotifiedAt, sta.ResolvedAt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
 }
 
-// Upsert performs an upsert for Transfer.
-func (q TransferQ) Upsert(t *data.Transfer) error {
-	return q.UpsertCtx(context.Background(), t)
+// Upsert performs an upsert for StuckTransferAlert.
+func (q StuckTransferAlertQ) Upsert(sta *data.StuckTransferAlert) error {
+	return q.UpsertCtx(context.Background(), sta)
 }
 
-// DeleteCtx deletes the Transfer from the database.
-func (q TransferQ) DeleteCtx(ctx context.Context, t *data.Transfer) error {
+// DeleteCtx deletes the StuckTransferAlert from the database.
+func (q StuckTransferAlertQ) DeleteCtx(ctx context.Context, sta *data.StuckTransferAlert) error {
 	// delete with single primary key
-	sqlstr := `DELETE FROM public.transfers ` +
+	sqlstr := `DELETE FROM public.stuck_transfer_alerts ` +
 		`WHERE id = $1`
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, t.ID); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, sta.ID); err != nil {
 		return errors.Wrap(err, "failed to exec delete stmt")
 	}
 	return nil
 }
 
-// Delete deletes the Transfer from the database.
-func (q TransferQ) Delete(t *data.Transfer) error {
-	return q.DeleteCtx(context.Background(), t)
-} // VoteQ represents helper struct to access row of 'votes'.
-type VoteQ struct {
+// Delete deletes the StuckTransferAlert from the database.
+func (q StuckTransferAlertQ) Delete(sta *data.StuckTransferAlert) error {
+	return q.DeleteCtx(context.Background(), sta)
+} // TokenmanagerEventQ represents helper struct to access row of 'tokenmanager_events'.
+type TokenmanagerEventQ struct {
 	db *pgdb.DB
 }
 
-// NewVoteQ  - creates new instance
-func NewVoteQ(db *pgdb.DB) VoteQ {
-	return VoteQ{
+// NewTokenmanagerEventQ  - creates new instance
+func NewTokenmanagerEventQ(db *pgdb.DB) TokenmanagerEventQ {
+	return TokenmanagerEventQ{
 		db,
 	}
 }
 
-// VoteQ  - creates new instance of VoteQ
-func (s Storage) VoteQ() data.VoteQ {
-	return NewVoteQ(s.DB())
+// TokenmanagerEventQ  - creates new instance of TokenmanagerEventQ
+func (s Storage) TokenmanagerEventQ() data.TokenmanagerEventQ {
+	return NewTokenmanagerEventQ(s.DB())
 }
 
-var colsVote = `id, transfer_index, choice, rarimo_transaction, created_at`
+var colsTokenmanagerEvent = `id, type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at`
 
-// InsertCtx inserts a Vote to the database.
-func (q VoteQ) InsertCtx(ctx context.Context, v *data.Vote) error {
+// InsertCtx inserts a TokenmanagerEvent to the database.
+func (q TokenmanagerEventQ) InsertCtx(ctx context.Context, te *data.TokenmanagerEvent) error {
 	// insert (primary key generated and returned by database)
-	sqlstr := `INSERT INTO public.votes (` +
-		`transfer_index, choice, rarimo_transaction, created_at` +
+	sqlstr := `INSERT INTO public.tokenmanager_events (` +
+		`type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
 		`) RETURNING id`
 		// run
 
-	err := q.db.GetRawContext(ctx, &v.ID, sqlstr, v.TransferIndex, v.Choice, v.RarimoTransaction, v.CreatedAt)
+	err := q.db.GetRawContext(ctx, &te.ID, sqlstr, te.Type, te.CollectionIndex, te.ItemIndex, te.Chain, te.Details, te.RarimoTx, te.Height, te.EventKey, te.CreatedAt)
 	if err != nil {
 		return errors.Wrap(err, "failed to execute insert")
 	}
@@ -1014,172 +1298,1175 @@ func (q VoteQ) InsertCtx(ctx context.Context, v *data.Vote) error {
 	return nil
 }
 
-// Insert insert a Vote to the database.
-func (q VoteQ) Insert(v *data.Vote) error {
-	return q.InsertCtx(context.Background(), v)
+// Insert insert a TokenmanagerEvent to the database.
+func (q TokenmanagerEventQ) Insert(te *data.TokenmanagerEvent) error {
+	return q.InsertCtx(context.Background(), te)
 }
 
-// UpdateCtx updates a Vote in the database.
-func (q VoteQ) UpdateCtx(ctx context.Context, v *data.Vote) error {
+// UpdateCtx updates a TokenmanagerEvent in the database.
+func (q TokenmanagerEventQ) UpdateCtx(ctx context.Context, te *data.TokenmanagerEvent) error {
 	// update with composite primary key
-	sqlstr := `UPDATE public.votes SET ` +
-		`transfer_index = $1, choice = $2, rarimo_transaction = $3 ` +
-		`WHERE id = $4`
+	sqlstr := `UPDATE public.tokenmanager_events SET ` +
+		`type = $1, collection_index = $2, item_index = $3, chain = $4, details = $5, rarimo_tx = $6, height = $7, event_key = $8 ` +
+		`WHERE id = $9`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, v.TransferIndex, v.Choice, v.RarimoTransaction, v.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, te.Type, te.CollectionIndex, te.ItemIndex, te.Chain, te.Details, te.RarimoTx, te.Height, te.EventKey, te.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
-// Update updates a Vote in the database.
-func (q VoteQ) Update(v *data.Vote) error {
-	return q.UpdateCtx(context.Background(), v)
+// Update updates a TokenmanagerEvent in the database.
+func (q TokenmanagerEventQ) Update(te *data.TokenmanagerEvent) error {
+	return q.UpdateCtx(context.Background(), te)
 }
 
-// UpsertCtx performs an upsert for Vote.
-func (q VoteQ) UpsertCtx(ctx context.Context, v *data.Vote) error {
+// UpsertCtx performs an upsert for TokenmanagerEvent.
+func (q TokenmanagerEventQ) UpsertCtx(ctx context.Context, te *data.TokenmanagerEvent) error {
 	// upsert
-	sqlstr := `INSERT INTO public.votes (` +
-		`id, transfer_index, choice, rarimo_transaction, created_at` +
+	sqlstr := `INSERT INTO public.tokenmanager_events (` +
+		`id, type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`transfer_index = EXCLUDED.transfer_index, choice = EXCLUDED.choice, rarimo_transaction = EXCLUDED.rarimo_transaction `
+		`type = EXCLUDED.type, collection_index = EXCLUDED.collection_index, item_index = EXCLUDED.item_index, chain = EXCLUDED.chain, details = EXCLUDED.details, rarimo_tx = EXCLUDED.rarimo_tx, height = EXCLUDED.height, event_key = EXCLUDED.event_key `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, v.ID, v.TransferIndex, v.Choice, v.RarimoTransaction, v.CreatedAt); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, te.ID, te.Type, te.CollectionIndex, te.ItemIndex, te.Chain, te.Details, te.RarimoTx, te.Height, te.EventKey, te.CreatedAt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
 }
 
-// Upsert performs an upsert for Vote.
-func (q VoteQ) Upsert(v *data.Vote) error {
-	return q.UpsertCtx(context.Background(), v)
+// Upsert performs an upsert for TokenmanagerEvent.
+func (q TokenmanagerEventQ) Upsert(te *data.TokenmanagerEvent) error {
+	return q.UpsertCtx(context.Background(), te)
 }
 
-// DeleteCtx deletes the Vote from the database.
-func (q VoteQ) DeleteCtx(ctx context.Context, v *data.Vote) error {
+// DeleteCtx deletes the TokenmanagerEvent from the database.
+func (q TokenmanagerEventQ) DeleteCtx(ctx context.Context, te *data.TokenmanagerEvent) error {
 	// delete with single primary key
-	sqlstr := `DELETE FROM public.votes ` +
+	sqlstr := `DELETE FROM public.tokenmanager_events ` +
 		`WHERE id = $1`
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, v.ID); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, te.ID); err != nil {
 		return errors.Wrap(err, "failed to exec delete stmt")
 	}
 	return nil
 }
 
-// Delete deletes the Vote from the database.
-func (q VoteQ) Delete(v *data.Vote) error {
-	return q.DeleteCtx(context.Background(), v)
-} // WithdrawalQ represents helper struct to access row of 'withdrawals'.
-type WithdrawalQ struct {
+// Delete deletes the TokenmanagerEvent from the database.
+func (q TokenmanagerEventQ) Delete(te *data.TokenmanagerEvent) error {
+	return q.DeleteCtx(context.Background(), te)
+} // TransactionQ represents helper struct to access row of 'transactions'.
+type TransactionQ struct {
 	db *pgdb.DB
 }
 
-// NewWithdrawalQ  - creates new instance
-func NewWithdrawalQ(db *pgdb.DB) WithdrawalQ {
-	return WithdrawalQ{
+// NewTransactionQ  - creates new instance
+func NewTransactionQ(db *pgdb.DB) TransactionQ {
+	return TransactionQ{
 		db,
 	}
 }
 
-// WithdrawalQ  - creates new instance of WithdrawalQ
-func (s Storage) WithdrawalQ() data.WithdrawalQ {
-	return NewWithdrawalQ(s.DB())
+// TransactionQ  - creates new instance of TransactionQ
+func (s Storage) TransactionQ() data.TransactionQ {
+	return NewTransactionQ(s.DB())
 }
 
-var colsWithdrawal = `origin, hash, success, created_at`
+var colsTransaction = `hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at`
 
-// InsertCtx inserts a Withdrawal to the database.
-func (q WithdrawalQ) InsertCtx(ctx context.Context, w *data.Withdrawal) error {
+// InsertCtx inserts a Transaction to the database.
+func (q TransactionQ) InsertCtx(ctx context.Context, t *data.Transaction) error {
 	// sql insert query, primary key must be provided
-	sqlstr := `INSERT INTO public.withdrawals (` +
-		`origin, hash, success, created_at` +
+	sqlstr := `INSERT INTO public.transactions (` +
+		`hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5, $6, $7` +
 		`)`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, w.Origin, w.Hash, w.Success, w.CreatedAt)
+	err := q.db.ExecRawContext(ctx, sqlstr, t.Hash, t.BlockHeight, t.Index, t.RawTx, t.TxResult, t.TxTimestamp, t.CreatedAt)
 	return errors.Wrap(err, "failed to execute insert query")
 }
 
-// Insert insert a Withdrawal to the database.
-func (q WithdrawalQ) Insert(w *data.Withdrawal) error {
-	return q.InsertCtx(context.Background(), w)
+// Insert insert a Transaction to the database.
+func (q TransactionQ) Insert(t *data.Transaction) error {
+	return q.InsertCtx(context.Background(), t)
 }
 
-// UpdateCtx updates a Withdrawal in the database.
-func (q WithdrawalQ) UpdateCtx(ctx context.Context, w *data.Withdrawal) error {
+// UpdateCtx updates a Transaction in the database.
+func (q TransactionQ) UpdateCtx(ctx context.Context, t *data.Transaction) error {
 	// update with composite primary key
-	sqlstr := `UPDATE public.withdrawals SET ` +
-		`hash = $1, success = $2 ` +
-		`WHERE origin = $3`
+	sqlstr := `UPDATE public.transactions SET ` +
+		`block_height = $1, index = $2, raw_tx = $3, tx_result = $4, tx_timestamp = $5 ` +
+		`WHERE hash = $6`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, w.Hash, w.Success, w.Origin)
+	err := q.db.ExecRawContext(ctx, sqlstr, t.BlockHeight, t.Index, t.RawTx, t.TxResult, t.TxTimestamp, t.Hash)
 	return errors.Wrap(err, "failed to execute update")
 }
 
-// Update updates a Withdrawal in the database.
-func (q WithdrawalQ) Update(w *data.Withdrawal) error {
-	return q.UpdateCtx(context.Background(), w)
+// Update updates a Transaction in the database.
+func (q TransactionQ) Update(t *data.Transaction) error {
+	return q.UpdateCtx(context.Background(), t)
 }
 
-// UpsertCtx performs an upsert for Withdrawal.
-func (q WithdrawalQ) UpsertCtx(ctx context.Context, w *data.Withdrawal) error {
+// UpsertCtx performs an upsert for Transaction.
+func (q TransactionQ) UpsertCtx(ctx context.Context, t *data.Transaction) error {
 	// upsert
-	sqlstr := `INSERT INTO public.withdrawals (` +
-		`origin, hash, success, created_at` +
+	sqlstr := `INSERT INTO public.transactions (` +
+		`hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5, $6, $7` +
 		`)` +
-		` ON CONFLICT (origin) DO ` +
+		` ON CONFLICT (hash) DO ` +
 		`UPDATE SET ` +
-		`hash = EXCLUDED.hash, success = EXCLUDED.success `
+		`block_height = EXCLUDED.block_height, index = EXCLUDED.index, raw_tx = EXCLUDED.raw_tx, tx_result = EXCLUDED.tx_result, tx_timestamp = EXCLUDED.tx_timestamp `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, w.Origin, w.Hash, w.Success, w.CreatedAt); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, t.Hash, t.BlockHeight, t.Index, t.RawTx, t.TxResult, t.TxTimestamp, t.CreatedAt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
 }
 
-// Upsert performs an upsert for Withdrawal.
-func (q WithdrawalQ) Upsert(w *data.Withdrawal) error {
-	return q.UpsertCtx(context.Background(), w)
+// Upsert performs an upsert for Transaction.
+func (q TransactionQ) Upsert(t *data.Transaction) error {
+	return q.UpsertCtx(context.Background(), t)
+}
+
+// DeleteCtx deletes the Transaction from the database.
+func (q TransactionQ) DeleteCtx(ctx context.Context, t *data.Transaction) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.transactions ` +
+		`WHERE hash = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, t.Hash); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the Transaction from the database.
+func (q TransactionQ) Delete(t *data.Transaction) error {
+	return q.DeleteCtx(context.Background(), t)
+} // TransferQ represents helper struct to access row of 'transfers'.
+type TransferQ struct {
+	db *pgdb.DB
+}
+
+// NewTransferQ  - creates new instance
+func NewTransferQ(db *pgdb.DB) TransferQ {
+	return TransferQ{
+		db,
+	}
+}
+
+// TransferQ  - creates new instance of TransferQ
+func (s Storage) TransferQ() data.TransferQ {
+	return NewTransferQ(s.DB())
+}
+
+var colsTransfer = `id, index, status, created_at, updated_at, creator, rarimo_tx, rarimo_tx_timestamp, origin, tx, event_id, from_chain, to_chain, receiver, amount, bundle_data, bundle_salt, item_index`
+
+// InsertCtx inserts a Transfer to the database.
+func (q TransferQ) InsertCtx(ctx context.Context, t *data.Transfer) error {
+	// insert (primary key generated and returned by database)
+	sqlstr := `INSERT INTO public.transfers (` +
+		`index, status, created_at, updated_at, creator, rarimo_tx, rarimo_tx_timestamp, origin, tx, event_id, from_chain, to_chain, receiver, amount, bundle_data, bundle_salt, item_index` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17` +
+		`) RETURNING id`
+		// run
+
+	err := q.db.GetRawContext(ctx, &t.ID, sqlstr, t.Index, t.Status, t.CreatedAt, t.UpdatedAt, t.Creator, t.RarimoTx, t.RarimoTxTimestamp, t.Origin, t.Tx, t.EventID, t.FromChain, t.ToChain, t.Receiver, t.Amount, t.BundleData, t.BundleSalt, t.ItemIndex)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute insert")
+	}
+
+	return nil
+}
+
+// Insert insert a Transfer to the database.
+func (q TransferQ) Insert(t *data.Transfer) error {
+	return q.InsertCtx(context.Background(), t)
+}
+
+// UpdateCtx updates a Transfer in the database.
+func (q TransferQ) UpdateCtx(ctx context.Context, t *data.Transfer) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.transfers SET ` +
+		`index = $1, status = $2, updated_at = $3, creator = $4, rarimo_tx = $5, rarimo_tx_timestamp = $6, origin = $7, tx = $8, event_id = $9, from_chain = $10, to_chain = $11, receiver = $12, amount = $13, bundle_data = $14, bundle_salt = $15, item_index = $16 ` +
+		`WHERE id = $17`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, t.Index, t.Status, t.UpdatedAt, t.Creator, t.RarimoTx, t.RarimoTxTimestamp, t.Origin, t.Tx, t.EventID, t.FromChain, t.ToChain, t.Receiver, t.Amount, t.BundleData, t.BundleSalt, t.ItemIndex, t.ID)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a Transfer in the database.
+func (q TransferQ) Update(t *data.Transfer) error {
+	return q.UpdateCtx(context.Background(), t)
+}
+
+// UpsertCtx performs an upsert for Transfer.
+func (q TransferQ) UpsertCtx(ctx context.Context, t *data.Transfer) error {
+	// upsert
+	sqlstr := `INSERT INTO public.transfers (` +
+		`id, index, status, created_at, updated_at, creator, rarimo_tx, rarimo_tx_timestamp, origin, tx, event_id, from_chain, to_chain, receiver, amount, bundle_data, bundle_salt, item_index` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18` +
+		`)` +
+		` ON CONFLICT (id) DO ` +
+		`UPDATE SET ` +
+		`index = EXCLUDED.index, status = EXCLUDED.status, updated_at = EXCLUDED.updated_at, creator = EXCLUDED.creator, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, origin = EXCLUDED.origin, tx = EXCLUDED.tx, event_id = EXCLUDED.event_id, from_chain = EXCLUDED.from_chain, to_chain = EXCLUDED.to_chain, receiver = EXCLUDED.receiver, amount = EXCLUDED.amount, bundle_data = EXCLUDED.bundle_data, bundle_salt = EXCLUDED.bundle_salt, item_index = EXCLUDED.item_index `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, t.ID, t.Index, t.Status, t.CreatedAt, t.UpdatedAt, t.Creator, t.RarimoTx, t.RarimoTxTimestamp, t.Origin, t.Tx, t.EventID, t.FromChain, t.ToChain, t.Receiver, t.Amount, t.BundleData, t.BundleSalt, t.ItemIndex); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for Transfer.
+func (q TransferQ) Upsert(t *data.Transfer) error {
+	return q.UpsertCtx(context.Background(), t)
+}
+
+// DeleteCtx deletes the Transfer from the database.
+func (q TransferQ) DeleteCtx(ctx context.Context, t *data.Transfer) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.transfers ` +
+		`WHERE id = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, t.ID); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the Transfer from the database.
+func (q TransferQ) Delete(t *data.Transfer) error {
+	return q.DeleteCtx(context.Background(), t)
+} // TransferStatusChangeQ represents helper struct to access row of 'transfer_status_changes'.
+type TransferStatusChangeQ struct {
+	db *pgdb.DB
+}
+
+// NewTransferStatusChangeQ  - creates new instance
+func NewTransferStatusChangeQ(db *pgdb.DB) TransferStatusChangeQ {
+	return TransferStatusChangeQ{
+		db,
+	}
+}
+
+// TransferStatusChangeQ  - creates new instance of TransferStatusChangeQ
+func (s Storage) TransferStatusChangeQ() data.TransferStatusChangeQ {
+	return NewTransferStatusChangeQ(s.DB())
+}
+
+var colsTransferStatusChange = `id, transfer_index, status, rarimo_transaction, rarimo_tx_timestamp, created_at`
+
+// InsertCtx inserts a TransferStatusChange to the database.
+func (q TransferStatusChangeQ) InsertCtx(ctx context.Context, tsc *data.TransferStatusChange) error {
+	// insert (primary key generated and returned by database)
+	sqlstr := `INSERT INTO public.transfer_status_changes (` +
+		`transfer_index, status, rarimo_transaction, rarimo_tx_timestamp, created_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5` +
+		`) RETURNING id`
+		// run
+
+	err := q.db.GetRawContext(ctx, &tsc.ID, sqlstr, tsc.TransferIndex, tsc.Status, tsc.RarimoTransaction, tsc.RarimoTxTimestamp, tsc.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute insert")
+	}
+
+	return nil
+}
+
+// Insert insert a TransferStatusChange to the database.
+func (q TransferStatusChangeQ) Insert(tsc *data.TransferStatusChange) error {
+	return q.InsertCtx(context.Background(), tsc)
+}
+
+// UpdateCtx updates a TransferStatusChange in the database.
+func (q TransferStatusChangeQ) UpdateCtx(ctx context.Context, tsc *data.TransferStatusChange) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.transfer_status_changes SET ` +
+		`transfer_index = $1, status = $2, rarimo_transaction = $3, rarimo_tx_timestamp = $4 ` +
+		`WHERE id = $5`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, tsc.TransferIndex, tsc.Status, tsc.RarimoTransaction, tsc.RarimoTxTimestamp, tsc.ID)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a TransferStatusChange in the database.
+func (q TransferStatusChangeQ) Update(tsc *data.TransferStatusChange) error {
+	return q.UpdateCtx(context.Background(), tsc)
+}
+
+// UpsertCtx performs an upsert for TransferStatusChange.
+func (q TransferStatusChangeQ) UpsertCtx(ctx context.Context, tsc *data.TransferStatusChange) error {
+	// upsert
+	sqlstr := `INSERT INTO public.transfer_status_changes (` +
+		`id, transfer_index, status, rarimo_transaction, rarimo_tx_timestamp, created_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6` +
+		`)` +
+		` ON CONFLICT (id) DO ` +
+		`UPDATE SET ` +
+		`transfer_index = EXCLUDED.transfer_index, status = EXCLUDED.status, rarimo_transaction = EXCLUDED.rarimo_transaction, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, tsc.ID, tsc.TransferIndex, tsc.Status, tsc.RarimoTransaction, tsc.RarimoTxTimestamp, tsc.CreatedAt); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for TransferStatusChange.
+func (q TransferStatusChangeQ) Upsert(tsc *data.TransferStatusChange) error {
+	return q.UpsertCtx(context.Background(), tsc)
+}
+
+// DeleteCtx deletes the TransferStatusChange from the database.
+func (q TransferStatusChangeQ) DeleteCtx(ctx context.Context, tsc *data.TransferStatusChange) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.transfer_status_changes ` +
+		`WHERE id = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, tsc.ID); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the TransferStatusChange from the database.
+func (q TransferStatusChangeQ) Delete(tsc *data.TransferStatusChange) error {
+	return q.DeleteCtx(context.Background(), tsc)
+} // VoteQ represents helper struct to access row of 'votes'.
+type VoteQ struct {
+	db *pgdb.DB
+}
+
+// NewVoteQ  - creates new instance
+func NewVoteQ(db *pgdb.DB) VoteQ {
+	return VoteQ{
+		db,
+	}
+}
+
+// VoteQ  - creates new instance of VoteQ
+func (s Storage) VoteQ() data.VoteQ {
+	return NewVoteQ(s.DB())
+}
+
+var colsVote = `id, transfer_index, choice, rarimo_transaction, created_at`
+
+// InsertCtx inserts a Vote to the database.
+func (q VoteQ) InsertCtx(ctx context.Context, v *data.Vote) error {
+	// insert (primary key generated and returned by database)
+	sqlstr := `INSERT INTO public.votes (` +
+		`transfer_index, choice, rarimo_transaction, created_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4` +
+		`) RETURNING id`
+		// run
+
+	err := q.db.GetRawContext(ctx, &v.ID, sqlstr, v.TransferIndex, v.Choice, v.RarimoTransaction, v.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute insert")
+	}
+
+	return nil
+}
+
+// Insert insert a Vote to the database.
+func (q VoteQ) Insert(v *data.Vote) error {
+	return q.InsertCtx(context.Background(), v)
+}
+
+// UpdateCtx updates a Vote in the database.
+func (q VoteQ) UpdateCtx(ctx context.Context, v *data.Vote) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.votes SET ` +
+		`transfer_index = $1, choice = $2, rarimo_transaction = $3 ` +
+		`WHERE id = $4`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, v.TransferIndex, v.Choice, v.RarimoTransaction, v.ID)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a Vote in the database.
+func (q VoteQ) Update(v *data.Vote) error {
+	return q.UpdateCtx(context.Background(), v)
+}
+
+// UpsertCtx performs an upsert for Vote.
+func (q VoteQ) UpsertCtx(ctx context.Context, v *data.Vote) error {
+	// upsert
+	sqlstr := `INSERT INTO public.votes (` +
+		`id, transfer_index, choice, rarimo_transaction, created_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5` +
+		`)` +
+		` ON CONFLICT (id) DO ` +
+		`UPDATE SET ` +
+		`transfer_index = EXCLUDED.transfer_index, choice = EXCLUDED.choice, rarimo_transaction = EXCLUDED.rarimo_transaction `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, v.ID, v.TransferIndex, v.Choice, v.RarimoTransaction, v.CreatedAt); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for Vote.
+func (q VoteQ) Upsert(v *data.Vote) error {
+	return q.UpsertCtx(context.Background(), v)
+}
+
+// DeleteCtx deletes the Vote from the database.
+func (q VoteQ) DeleteCtx(ctx context.Context, v *data.Vote) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.votes ` +
+		`WHERE id = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, v.ID); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the Vote from the database.
+func (q VoteQ) Delete(v *data.Vote) error {
+	return q.DeleteCtx(context.Background(), v)
+} // WithdrawalQ represents helper struct to access row of 'withdrawals'.
+type WithdrawalQ struct {
+	db *pgdb.DB
+}
+
+// NewWithdrawalQ  - creates new instance
+func NewWithdrawalQ(db *pgdb.DB) WithdrawalQ {
+	return WithdrawalQ{
+		db,
+	}
+}
+
+// WithdrawalQ  - creates new instance of WithdrawalQ
+func (s Storage) WithdrawalQ() data.WithdrawalQ {
+	return NewWithdrawalQ(s.DB())
+}
+
+var colsWithdrawal = `origin, hash, success, created_at`
+
+// InsertCtx inserts a Withdrawal to the database.
+func (q WithdrawalQ) InsertCtx(ctx context.Context, w *data.Withdrawal) error {
+	// sql insert query, primary key must be provided
+	sqlstr := `INSERT INTO public.withdrawals (` +
+		`origin, hash, success, created_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4` +
+		`)`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, w.Origin, w.Hash, w.Success, w.CreatedAt)
+	return errors.Wrap(err, "failed to execute insert query")
+}
+
+// Insert insert a Withdrawal to the database.
+func (q WithdrawalQ) Insert(w *data.Withdrawal) error {
+	return q.InsertCtx(context.Background(), w)
+}
+
+// UpdateCtx updates a Withdrawal in the database.
+func (q WithdrawalQ) UpdateCtx(ctx context.Context, w *data.Withdrawal) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.withdrawals SET ` +
+		`hash = $1, success = $2 ` +
+		`WHERE origin = $3`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, w.Hash, w.Success, w.Origin)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a Withdrawal in the database.
+func (q WithdrawalQ) Update(w *data.Withdrawal) error {
+	return q.UpdateCtx(context.Background(), w)
+}
+
+// UpsertCtx performs an upsert for Withdrawal.
+func (q WithdrawalQ) UpsertCtx(ctx context.Context, w *data.Withdrawal) error {
+	// upsert
+	sqlstr := `INSERT INTO public.withdrawals (` +
+		`origin, hash, success, created_at` +
+		`) VALUES (` +
+		`$1, $2, $3, $4` +
+		`)` +
+		` ON CONFLICT (origin) DO ` +
+		`UPDATE SET ` +
+		`hash = EXCLUDED.hash, success = EXCLUDED.success `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, w.Origin, w.Hash, w.Success, w.CreatedAt); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for Withdrawal.
+func (q WithdrawalQ) Upsert(w *data.Withdrawal) error {
+	return q.UpsertCtx(context.Background(), w)
+}
+
+// DeleteCtx deletes the Withdrawal from the database.
+func (q WithdrawalQ) DeleteCtx(ctx context.Context, w *data.Withdrawal) error {
+	// delete with single primary key
+	sqlstr := `DELETE FROM public.withdrawals ` +
+		`WHERE origin = $1`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, w.Origin); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the Withdrawal from the database.
+func (q WithdrawalQ) Delete(w *data.Withdrawal) error {
+	return q.DeleteCtx(context.Background(), w)
+}
+
+// ApprovalByIDCtx retrieves a row from 'public.approvals' as a Approval.
+//
+// Generated from index 'approvals_pkey'.
+func (q ApprovalQ) ApprovalByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Approval, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.approvals ` +
+		`WHERE id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Approval
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// ApprovalByID retrieves a row from 'public.approvals' as a Approval.
+//
+// Generated from index 'approvals_pkey'.
+func (q ApprovalQ) ApprovalByID(id int64, isForUpdate bool) (*data.Approval, error) {
+	return q.ApprovalByIDCtx(context.Background(), id, isForUpdate)
+}
+
+// ApprovalsByTransferIndexCtx retrieves a row from 'public.approvals' as a Approval.
+//
+// Generated from index 'approvals_transfer_index'.
+func (q ApprovalQ) ApprovalsByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) ([]data.Approval, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.approvals ` +
+		`WHERE transfer_index = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.Approval
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, transferIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// ApprovalsByTransferIndex retrieves a row from 'public.approvals' as a Approval.
+//
+// Generated from index 'approvals_transfer_index'.
+func (q ApprovalQ) ApprovalsByTransferIndex(transferIndex []byte, isForUpdate bool) ([]data.Approval, error) {
+	return q.ApprovalsByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+}
+
+// ApprovalByTransferIndexRarimoTransactionCtx retrieves a row from 'public.approvals' as a Approval.
+//
+// Generated from index 'approvals_transfer_index_rarimo_transaction_key'.
+func (q ApprovalQ) ApprovalByTransferIndexRarimoTransactionCtx(ctx context.Context, transferIndex []byte, rarimoTransaction []byte, isForUpdate bool) (*data.Approval, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.approvals ` +
+		`WHERE transfer_index = $1 AND rarimo_transaction = $2`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Approval
+	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex, rarimoTransaction)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// ApprovalByTransferIndexRarimoTransaction retrieves a row from 'public.approvals' as a Approval.
+//
+// Generated from index 'approvals_transfer_index_rarimo_transaction_key'.
+func (q ApprovalQ) ApprovalByTransferIndexRarimoTransaction(transferIndex []byte, rarimoTransaction []byte, isForUpdate bool) (*data.Approval, error) {
+	return q.ApprovalByTransferIndexRarimoTransactionCtx(context.Background(), transferIndex, rarimoTransaction, isForUpdate)
+}
+
+// CollectionByIndexCtx retrieves a row from 'public.collections' as a Collection.
+//
+// Generated from index 'collections_index_key'.
+func (q CollectionQ) CollectionByIndexCtx(ctx context.Context, index []byte, isForUpdate bool) (*data.Collection, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, index, metadata, created_at, updated_at, deleted_at ` +
+		`FROM public.collections ` +
+		`WHERE index = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Collection
+	err := q.db.GetRawContext(ctx, &res, sqlstr, index)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// CollectionByIndex retrieves a row from 'public.collections' as a Collection.
+//
+// Generated from index 'collections_index_key'.
+func (q CollectionQ) CollectionByIndex(index []byte, isForUpdate bool) (*data.Collection, error) {
+	return q.CollectionByIndexCtx(context.Background(), index, isForUpdate)
+}
+
+// CollectionByIDCtx retrieves a row from 'public.collections' as a Collection.
+//
+// Generated from index 'collections_pkey'.
+func (q CollectionQ) CollectionByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Collection, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, index, metadata, created_at, updated_at, deleted_at ` +
+		`FROM public.collections ` +
+		`WHERE id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Collection
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// CollectionByID retrieves a row from 'public.collections' as a Collection.
+//
+// Generated from index 'collections_pkey'.
+func (q CollectionQ) CollectionByID(id int64, isForUpdate bool) (*data.Collection, error) {
+	return q.CollectionByIDCtx(context.Background(), id, isForUpdate)
+}
+
+// CollectionChainMappingsByCollectionCtx retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+//
+// Generated from index 'collection_chain_mappings_collection'.
+func (q CollectionChainMappingQ) CollectionChainMappingsByCollectionCtx(ctx context.Context, collection int64, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`collection, network, address, token_type, wrapped, decimals, created_at, updated_at ` +
+		`FROM public.collection_chain_mappings ` +
+		`WHERE collection = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.CollectionChainMapping
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, collection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// CollectionChainMappingsByCollection retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+//
+// Generated from index 'collection_chain_mappings_collection'.
+func (q CollectionChainMappingQ) CollectionChainMappingsByCollection(collection int64, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+	return q.CollectionChainMappingsByCollectionCtx(context.Background(), collection, isForUpdate)
+}
+
+// CollectionChainMappingsByNetworkCtx retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+//
+// Generated from index 'collection_chain_mappings_network'.
+func (q CollectionChainMappingQ) CollectionChainMappingsByNetworkCtx(ctx context.Context, network int, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`collection, network, address, token_type, wrapped, decimals, created_at, updated_at ` +
+		`FROM public.collection_chain_mappings ` +
+		`WHERE network = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.CollectionChainMapping
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, network)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// CollectionChainMappingsByNetwork retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+//
+// Generated from index 'collection_chain_mappings_network'.
+func (q CollectionChainMappingQ) CollectionChainMappingsByNetwork(network int, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+	return q.CollectionChainMappingsByNetworkCtx(context.Background(), network, isForUpdate)
+}
+
+// CollectionChainMappingByCollectionNetworkCtx retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+//
+// Generated from index 'collection_chain_mappings_pkey'.
+func (q CollectionChainMappingQ) CollectionChainMappingByCollectionNetworkCtx(ctx context.Context, collection int64, network int, isForUpdate bool) (*data.CollectionChainMapping, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`collection, network, address, token_type, wrapped, decimals, created_at, updated_at ` +
+		`FROM public.collection_chain_mappings ` +
+		`WHERE collection = $1 AND network = $2`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.CollectionChainMapping
+	err := q.db.GetRawContext(ctx, &res, sqlstr, collection, network)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// CollectionChainMappingByCollectionNetwork retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+//
+// Generated from index 'collection_chain_mappings_pkey'.
+func (q CollectionChainMappingQ) CollectionChainMappingByCollectionNetwork(collection int64, network int, isForUpdate bool) (*data.CollectionChainMapping, error) {
+	return q.CollectionChainMappingByCollectionNetworkCtx(context.Background(), collection, network, isForUpdate)
+}
+
+// ConfirmationByIDCtx retrieves a row from 'public.confirmations' as a Confirmation.
+//
+// Generated from index 'confirmations_pkey'.
+func (q ConfirmationQ) ConfirmationByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Confirmation, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.confirmations ` +
+		`WHERE id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Confirmation
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// ConfirmationByID retrieves a row from 'public.confirmations' as a Confirmation.
+//
+// Generated from index 'confirmations_pkey'.
+func (q ConfirmationQ) ConfirmationByID(id int64, isForUpdate bool) (*data.Confirmation, error) {
+	return q.ConfirmationByIDCtx(context.Background(), id, isForUpdate)
+}
+
+// ConfirmationsByTransferIndexCtx retrieves a row from 'public.confirmations' as a Confirmation.
+//
+// Generated from index 'confirmations_transfer_index'.
+func (q ConfirmationQ) ConfirmationsByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) ([]data.Confirmation, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.confirmations ` +
+		`WHERE transfer_index = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.Confirmation
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, transferIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// ConfirmationsByTransferIndex retrieves a row from 'public.confirmations' as a Confirmation.
+//
+// Generated from index 'confirmations_transfer_index'.
+func (q ConfirmationQ) ConfirmationsByTransferIndex(transferIndex []byte, isForUpdate bool) ([]data.Confirmation, error) {
+	return q.ConfirmationsByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+}
+
+// ConfirmationByTransferIndexCtx retrieves a row from 'public.confirmations' as a Confirmation.
+//
+// Generated from index 'confirmations_transfer_index_key'.
+func (q ConfirmationQ) ConfirmationByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) (*data.Confirmation, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.confirmations ` +
+		`WHERE transfer_index = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Confirmation
+	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// ConfirmationByTransferIndex retrieves a row from 'public.confirmations' as a Confirmation.
+//
+// Generated from index 'confirmations_transfer_index_key'.
+func (q ConfirmationQ) ConfirmationByTransferIndex(transferIndex []byte, isForUpdate bool) (*data.Confirmation, error) {
+	return q.ConfirmationByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+}
+
+// GorpMigrationByIDCtx retrieves a row from 'public.gorp_migrations' as a GorpMigration.
+//
+// Generated from index 'gorp_migrations_pkey'.
+func (q GorpMigrationQ) GorpMigrationByIDCtx(ctx context.Context, id string, isForUpdate bool) (*data.GorpMigration, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, applied_at ` +
+		`FROM public.gorp_migrations ` +
+		`WHERE id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.GorpMigration
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// GorpMigrationByID retrieves a row from 'public.gorp_migrations' as a GorpMigration.
+//
+// Generated from index 'gorp_migrations_pkey'.
+func (q GorpMigrationQ) GorpMigrationByID(id string, isForUpdate bool) (*data.GorpMigration, error) {
+	return q.GorpMigrationByIDCtx(context.Background(), id, isForUpdate)
+}
+
+// IdentityTransfersByChainStatusRarimoTxTimestampCtx retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_chain_status'.
+func (q IdentityTransferQ) IdentityTransfersByChainStatusRarimoTxTimestampCtx(ctx context.Context, chain string, status int, rarimoTxTimestamp time.Time, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at ` +
+		`FROM public.identity_transfers ` +
+		`WHERE chain = $1 AND status = $2 AND rarimo_tx_timestamp = $3`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.IdentityTransfer
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, chain, status, rarimoTxTimestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// IdentityTransfersByChainStatusRarimoTxTimestamp retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_chain_status'.
+func (q IdentityTransferQ) IdentityTransfersByChainStatusRarimoTxTimestamp(chain string, status int, rarimoTxTimestamp time.Time, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	return q.IdentityTransfersByChainStatusRarimoTxTimestampCtx(context.Background(), chain, status, rarimoTxTimestamp, isForUpdate)
+}
+
+// IdentityTransfersByGistHashCtx retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_gist_hash'.
+func (q IdentityTransferQ) IdentityTransfersByGistHashCtx(ctx context.Context, gistHash sql.NullString, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at ` +
+		`FROM public.identity_transfers ` +
+		`WHERE gist_hash = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.IdentityTransfer
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, gistHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// IdentityTransfersByGistHash retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_gist_hash'.
+func (q IdentityTransferQ) IdentityTransfersByGistHash(gistHash sql.NullString, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	return q.IdentityTransfersByGistHashCtx(context.Background(), gistHash, isForUpdate)
+}
+
+// IdentityTransfersByIdentityIDCtx retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_identity_id'.
+func (q IdentityTransferQ) IdentityTransfersByIdentityIDCtx(ctx context.Context, identityID sql.NullString, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at ` +
+		`FROM public.identity_transfers ` +
+		`WHERE identity_id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.IdentityTransfer
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, identityID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// IdentityTransfersByIdentityID retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_identity_id'.
+func (q IdentityTransferQ) IdentityTransfersByIdentityID(identityID sql.NullString, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	return q.IdentityTransfersByIdentityIDCtx(context.Background(), identityID, isForUpdate)
+}
+
+// IdentityTransferByOperationIndexCtx retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_operation_index_key'.
+func (q IdentityTransferQ) IdentityTransferByOperationIndexCtx(ctx context.Context, operationIndex []byte, isForUpdate bool) (*data.IdentityTransfer, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at ` +
+		`FROM public.identity_transfers ` +
+		`WHERE operation_index = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.IdentityTransfer
+	err := q.db.GetRawContext(ctx, &res, sqlstr, operationIndex)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// IdentityTransferByOperationIndex retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_operation_index_key'.
+func (q IdentityTransferQ) IdentityTransferByOperationIndex(operationIndex []byte, isForUpdate bool) (*data.IdentityTransfer, error) {
+	return q.IdentityTransferByOperationIndexCtx(context.Background(), operationIndex, isForUpdate)
+}
+
+// IdentityTransferByIDCtx retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_pkey'.
+func (q IdentityTransferQ) IdentityTransferByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.IdentityTransfer, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at ` +
+		`FROM public.identity_transfers ` +
+		`WHERE id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.IdentityTransfer
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// IdentityTransferByID retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_pkey'.
+func (q IdentityTransferQ) IdentityTransferByID(id int64, isForUpdate bool) (*data.IdentityTransfer, error) {
+	return q.IdentityTransferByIDCtx(context.Background(), id, isForUpdate)
+}
+
+// IdentityTransfersByStateHashCtx retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_state_hash'.
+func (q IdentityTransferQ) IdentityTransfersByStateHashCtx(ctx context.Context, stateHash sql.NullString, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, operation_index, type, status, chain, contract, identity_id, state_hash, state_created_at, state_created_at_block, replaced_state_hash, gist_hash, gist_created_at, gist_created_at_block, replaced_gist_hash, state_root_hash, rarimo_tx, rarimo_tx_timestamp, created_at, updated_at ` +
+		`FROM public.identity_transfers ` +
+		`WHERE state_hash = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.IdentityTransfer
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, stateHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// IdentityTransfersByStateHash retrieves a row from 'public.identity_transfers' as a IdentityTransfer.
+//
+// Generated from index 'identity_transfers_state_hash'.
+func (q IdentityTransferQ) IdentityTransfersByStateHash(stateHash sql.NullString, isForUpdate bool) ([]data.IdentityTransfer, error) {
+	return q.IdentityTransfersByStateHashCtx(context.Background(), stateHash, isForUpdate)
+}
+
+// ItemsByCollectionCtx retrieves a row from 'public.items' as a Item.
+//
+// Generated from index 'item_collection'.
+func (q ItemQ) ItemsByCollectionCtx(ctx context.Context, collection sql.NullInt64, isForUpdate bool) ([]data.Item, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, index, collection, metadata, created_at, updated_at, deleted_at ` +
+		`FROM public.items ` +
+		`WHERE collection = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.Item
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, collection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// ItemsByCollection retrieves a row from 'public.items' as a Item.
+//
+// Generated from index 'item_collection'.
+func (q ItemQ) ItemsByCollection(collection sql.NullInt64, isForUpdate bool) ([]data.Item, error) {
+	return q.ItemsByCollectionCtx(context.Background(), collection, isForUpdate)
 }
 
-// DeleteCtx deletes the Withdrawal from the database.
-func (q WithdrawalQ) DeleteCtx(ctx context.Context, w *data.Withdrawal) error {
-	// delete with single primary key
-	sqlstr := `DELETE FROM public.withdrawals ` +
-		`WHERE origin = $1`
+// ItemByIndexCtx retrieves a row from 'public.items' as a Item.
+//
+// Generated from index 'items_index_key'.
+func (q ItemQ) ItemByIndexCtx(ctx context.Context, index []byte, isForUpdate bool) (*data.Item, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, index, collection, metadata, created_at, updated_at, deleted_at ` +
+		`FROM public.items ` +
+		`WHERE index = $1`
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, w.Origin); err != nil {
-		return errors.Wrap(err, "failed to exec delete stmt")
+	if isForUpdate {
+		sqlstr += " for update"
 	}
-	return nil
+	var res data.Item
+	err := q.db.GetRawContext(ctx, &res, sqlstr, index)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
 }
 
-// Delete deletes the Withdrawal from the database.
-func (q WithdrawalQ) Delete(w *data.Withdrawal) error {
-	return q.DeleteCtx(context.Background(), w)
+// ItemByIndex retrieves a row from 'public.items' as a Item.
+//
+// Generated from index 'items_index_key'.
+func (q ItemQ) ItemByIndex(index []byte, isForUpdate bool) (*data.Item, error) {
+	return q.ItemByIndexCtx(context.Background(), index, isForUpdate)
 }
 
-// ApprovalByIDCtx retrieves a row from 'public.approvals' as a Approval.
+// ItemByIDCtx retrieves a row from 'public.items' as a Item.
 //
-// Generated from index 'approvals_pkey'.
-func (q ApprovalQ) ApprovalByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Approval, error) {
+// Generated from index 'items_pkey'.
+func (q ItemQ) ItemByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Item, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.approvals ` +
+		`id, index, collection, metadata, created_at, updated_at, deleted_at ` +
+		`FROM public.items ` +
 		`WHERE id = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Approval
+	var res data.Item
 	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
@@ -1192,28 +2479,28 @@ func (q ApprovalQ) ApprovalByIDCtx(ctx context.Context, id int64, isForUpdate bo
 	return &res, nil
 }
 
-// ApprovalByID retrieves a row from 'public.approvals' as a Approval.
+// ItemByID retrieves a row from 'public.items' as a Item.
 //
-// Generated from index 'approvals_pkey'.
-func (q ApprovalQ) ApprovalByID(id int64, isForUpdate bool) (*data.Approval, error) {
-	return q.ApprovalByIDCtx(context.Background(), id, isForUpdate)
+// Generated from index 'items_pkey'.
+func (q ItemQ) ItemByID(id int64, isForUpdate bool) (*data.Item, error) {
+	return q.ItemByIDCtx(context.Background(), id, isForUpdate)
 }
 
-// ApprovalsByTransferIndexCtx retrieves a row from 'public.approvals' as a Approval.
+// ItemChainMappingsByAddressCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'approvals_transfer_index'.
-func (q ApprovalQ) ApprovalsByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) ([]data.Approval, error) {
+// Generated from index 'item_chain_mapping_address'.
+func (q ItemChainMappingQ) ItemChainMappingsByAddressCtx(ctx context.Context, address []byte, isForUpdate bool) ([]data.ItemChainMapping, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.approvals ` +
-		`WHERE transfer_index = $1`
+		`item, network, address, token_id, created_at, updated_at ` +
+		`FROM public.item_chain_mappings ` +
+		`WHERE address = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.Approval
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, transferIndex)
+	var res []data.ItemChainMapping
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, address)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
@@ -1221,152 +2508,152 @@ func (q ApprovalQ) ApprovalsByTransferIndexCtx(ctx context.Context, transferInde
 	return res, nil
 }
 
-// ApprovalsByTransferIndex retrieves a row from 'public.approvals' as a Approval.
+// ItemChainMappingsByAddress retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'approvals_transfer_index'.
-func (q ApprovalQ) ApprovalsByTransferIndex(transferIndex []byte, isForUpdate bool) ([]data.Approval, error) {
-	return q.ApprovalsByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+// Generated from index 'item_chain_mapping_address'.
+func (q ItemChainMappingQ) ItemChainMappingsByAddress(address []byte, isForUpdate bool) ([]data.ItemChainMapping, error) {
+	return q.ItemChainMappingsByAddressCtx(context.Background(), address, isForUpdate)
 }
 
-// CollectionByIndexCtx retrieves a row from 'public.collections' as a Collection.
+// ItemChainMappingsByItemCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'collections_index_key'.
-func (q CollectionQ) CollectionByIndexCtx(ctx context.Context, index []byte, isForUpdate bool) (*data.Collection, error) {
+// Generated from index 'item_chain_mapping_item'.
+func (q ItemChainMappingQ) ItemChainMappingsByItemCtx(ctx context.Context, item int64, isForUpdate bool) ([]data.ItemChainMapping, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, index, metadata, created_at, updated_at ` +
-		`FROM public.collections ` +
-		`WHERE index = $1`
+		`item, network, address, token_id, created_at, updated_at ` +
+		`FROM public.item_chain_mappings ` +
+		`WHERE item = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Collection
-	err := q.db.GetRawContext(ctx, &res, sqlstr, index)
+	var res []data.ItemChainMapping
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, item)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
-			return nil, nil
-		}
-
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return &res, nil
+	return res, nil
 }
 
-// CollectionByIndex retrieves a row from 'public.collections' as a Collection.
+// ItemChainMappingsByItem retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'collections_index_key'.
-func (q CollectionQ) CollectionByIndex(index []byte, isForUpdate bool) (*data.Collection, error) {
-	return q.CollectionByIndexCtx(context.Background(), index, isForUpdate)
+// Generated from index 'item_chain_mapping_item'.
+func (q ItemChainMappingQ) ItemChainMappingsByItem(item int64, isForUpdate bool) ([]data.ItemChainMapping, error) {
+	return q.ItemChainMappingsByItemCtx(context.Background(), item, isForUpdate)
 }
 
-// CollectionByIDCtx retrieves a row from 'public.collections' as a Collection.
+// ItemChainMappingsByNetworkCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'collections_pkey'.
-func (q CollectionQ) CollectionByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Collection, error) {
+// Generated from index 'item_chain_mapping_network'.
+func (q ItemChainMappingQ) ItemChainMappingsByNetworkCtx(ctx context.Context, network int, isForUpdate bool) ([]data.ItemChainMapping, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, index, metadata, created_at, updated_at ` +
-		`FROM public.collections ` +
-		`WHERE id = $1`
+		`item, network, address, token_id, created_at, updated_at ` +
+		`FROM public.item_chain_mappings ` +
+		`WHERE network = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Collection
-	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	var res []data.ItemChainMapping
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, network)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
-			return nil, nil
-		}
-
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return &res, nil
+	return res, nil
 }
 
-// CollectionByID retrieves a row from 'public.collections' as a Collection.
+// ItemChainMappingsByNetwork retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'collections_pkey'.
-func (q CollectionQ) CollectionByID(id int64, isForUpdate bool) (*data.Collection, error) {
-	return q.CollectionByIDCtx(context.Background(), id, isForUpdate)
+// Generated from index 'item_chain_mapping_network'.
+func (q ItemChainMappingQ) ItemChainMappingsByNetwork(network int, isForUpdate bool) ([]data.ItemChainMapping, error) {
+	return q.ItemChainMappingsByNetworkCtx(context.Background(), network, isForUpdate)
 }
 
-// CollectionChainMappingsByCollectionCtx retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+// ItemChainMappingByItemNetworkCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'collection_chain_mappings_collection'.
-func (q CollectionChainMappingQ) CollectionChainMappingsByCollectionCtx(ctx context.Context, collection int64, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+// Generated from index 'item_chain_mappings_pkey'.
+func (q ItemChainMappingQ) ItemChainMappingByItemNetworkCtx(ctx context.Context, item int64, network int, isForUpdate bool) (*data.ItemChainMapping, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`collection, network, address, token_type, wrapped, decimals, created_at, updated_at ` +
-		`FROM public.collection_chain_mappings ` +
-		`WHERE collection = $1`
+		`item, network, address, token_id, created_at, updated_at ` +
+		`FROM public.item_chain_mappings ` +
+		`WHERE item = $1 AND network = $2`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.CollectionChainMapping
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, collection)
+	var res data.ItemChainMapping
+	err := q.db.GetRawContext(ctx, &res, sqlstr, item, network)
 	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return res, nil
+	return &res, nil
 }
 
-// CollectionChainMappingsByCollection retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+// ItemChainMappingByItemNetwork retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
 //
-// Generated from index 'collection_chain_mappings_collection'.
-func (q CollectionChainMappingQ) CollectionChainMappingsByCollection(collection int64, isForUpdate bool) ([]data.CollectionChainMapping, error) {
-	return q.CollectionChainMappingsByCollectionCtx(context.Background(), collection, isForUpdate)
+// Generated from index 'item_chain_mappings_pkey'.
+func (q ItemChainMappingQ) ItemChainMappingByItemNetwork(item int64, network int, isForUpdate bool) (*data.ItemChainMapping, error) {
+	return q.ItemChainMappingByItemNetworkCtx(context.Background(), item, network, isForUpdate)
 }
 
-// CollectionChainMappingsByNetworkCtx retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+// ItemMetadatumByItemNetworkCtx retrieves a row from 'public.item_metadata' as a ItemMetadatum.
 //
-// Generated from index 'collection_chain_mappings_network'.
-func (q CollectionChainMappingQ) CollectionChainMappingsByNetworkCtx(ctx context.Context, network int, isForUpdate bool) ([]data.CollectionChainMapping, error) {
+// Generated from index 'item_metadata_pkey'.
+func (q ItemMetadatumQ) ItemMetadatumByItemNetworkCtx(ctx context.Context, item int64, network int, isForUpdate bool) (*data.ItemMetadatum, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`collection, network, address, token_type, wrapped, decimals, created_at, updated_at ` +
-		`FROM public.collection_chain_mappings ` +
-		`WHERE network = $1`
+		`item, network, metadata, fetched_at, failures, last_error, next_fetch_at, created_at, updated_at ` +
+		`FROM public.item_metadata ` +
+		`WHERE item = $1 AND network = $2`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.CollectionChainMapping
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, network)
+	var res data.ItemMetadatum
+	err := q.db.GetRawContext(ctx, &res, sqlstr, item, network)
 	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return res, nil
+	return &res, nil
 }
 
-// CollectionChainMappingsByNetwork retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+// ItemMetadatumByItemNetwork retrieves a row from 'public.item_metadata' as a ItemMetadatum.
 //
-// Generated from index 'collection_chain_mappings_network'.
-func (q CollectionChainMappingQ) CollectionChainMappingsByNetwork(network int, isForUpdate bool) ([]data.CollectionChainMapping, error) {
-	return q.CollectionChainMappingsByNetworkCtx(context.Background(), network, isForUpdate)
+// Generated from index 'item_metadata_pkey'.
+func (q ItemMetadatumQ) ItemMetadatumByItemNetwork(item int64, network int, isForUpdate bool) (*data.ItemMetadatum, error) {
+	return q.ItemMetadatumByItemNetworkCtx(context.Background(), item, network, isForUpdate)
 }
 
-// CollectionChainMappingByCollectionNetworkCtx retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+// OperationByIndexCtx retrieves a row from 'public.operations' as a Operation.
 //
-// Generated from index 'collection_chain_mappings_pkey'.
-func (q CollectionChainMappingQ) CollectionChainMappingByCollectionNetworkCtx(ctx context.Context, collection int64, network int, isForUpdate bool) (*data.CollectionChainMapping, error) {
+// Generated from index 'operations_index_key'.
+func (q OperationQ) OperationByIndexCtx(ctx context.Context, index []byte, isForUpdate bool) (*data.Operation, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`collection, network, address, token_type, wrapped, decimals, created_at, updated_at ` +
-		`FROM public.collection_chain_mappings ` +
-		`WHERE collection = $1 AND network = $2`
+		`id, index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at ` +
+		`FROM public.operations ` +
+		`WHERE index = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.CollectionChainMapping
-	err := q.db.GetRawContext(ctx, &res, sqlstr, collection, network)
+	var res data.Operation
+	err := q.db.GetRawContext(ctx, &res, sqlstr, index)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, nil
@@ -1378,27 +2665,27 @@ func (q CollectionChainMappingQ) CollectionChainMappingByCollectionNetworkCtx(ct
 	return &res, nil
 }
 
-// CollectionChainMappingByCollectionNetwork retrieves a row from 'public.collection_chain_mappings' as a CollectionChainMapping.
+// OperationByIndex retrieves a row from 'public.operations' as a Operation.
 //
-// Generated from index 'collection_chain_mappings_pkey'.
-func (q CollectionChainMappingQ) CollectionChainMappingByCollectionNetwork(collection int64, network int, isForUpdate bool) (*data.CollectionChainMapping, error) {
-	return q.CollectionChainMappingByCollectionNetworkCtx(context.Background(), collection, network, isForUpdate)
+// Generated from index 'operations_index_key'.
+func (q OperationQ) OperationByIndex(index []byte, isForUpdate bool) (*data.Operation, error) {
+	return q.OperationByIndexCtx(context.Background(), index, isForUpdate)
 }
 
-// ConfirmationByIDCtx retrieves a row from 'public.confirmations' as a Confirmation.
+// OperationByIDCtx retrieves a row from 'public.operations' as a Operation.
 //
-// Generated from index 'confirmations_pkey'.
-func (q ConfirmationQ) ConfirmationByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Confirmation, error) {
+// Generated from index 'operations_pkey'.
+func (q OperationQ) OperationByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Operation, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.confirmations ` +
+		`id, index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at ` +
+		`FROM public.operations ` +
 		`WHERE id = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Confirmation
+	var res data.Operation
 	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
@@ -1411,90 +2698,148 @@ func (q ConfirmationQ) ConfirmationByIDCtx(ctx context.Context, id int64, isForU
 	return &res, nil
 }
 
-// ConfirmationByID retrieves a row from 'public.confirmations' as a Confirmation.
+// OperationByID retrieves a row from 'public.operations' as a Operation.
 //
-// Generated from index 'confirmations_pkey'.
-func (q ConfirmationQ) ConfirmationByID(id int64, isForUpdate bool) (*data.Confirmation, error) {
-	return q.ConfirmationByIDCtx(context.Background(), id, isForUpdate)
+// Generated from index 'operations_pkey'.
+func (q OperationQ) OperationByID(id int64, isForUpdate bool) (*data.Operation, error) {
+	return q.OperationByIDCtx(context.Background(), id, isForUpdate)
 }
 
-// ConfirmationsByTransferIndexCtx retrieves a row from 'public.confirmations' as a Confirmation.
+// OperationsByStatusCtx retrieves a row from 'public.operations' as a Operation.
 //
-// Generated from index 'confirmations_transfer_index'.
-func (q ConfirmationQ) ConfirmationsByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) ([]data.Confirmation, error) {
+// Generated from index 'operations_status'.
+func (q OperationQ) OperationsByStatusCtx(ctx context.Context, status int, isForUpdate bool) ([]data.Operation, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at ` +
+		`FROM public.operations ` +
+		`WHERE status = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.Operation
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, status)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// OperationsByStatus retrieves a row from 'public.operations' as a Operation.
+//
+// Generated from index 'operations_status'.
+func (q OperationQ) OperationsByStatus(status int, isForUpdate bool) ([]data.Operation, error) {
+	return q.OperationsByStatusCtx(context.Background(), status, isForUpdate)
+}
+
+// OperationsByTypeCtx retrieves a row from 'public.operations' as a Operation.
+//
+// Generated from index 'operations_type'.
+func (q OperationQ) OperationsByTypeCtx(ctx context.Context, typ int, isForUpdate bool) ([]data.Operation, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, index, type, status, creator, rarimo_tx, rarimo_tx_timestamp, details, created_at, updated_at ` +
+		`FROM public.operations ` +
+		`WHERE type = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res []data.Operation
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, typ)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return res, nil
+}
+
+// OperationsByType retrieves a row from 'public.operations' as a Operation.
+//
+// Generated from index 'operations_type'.
+func (q OperationQ) OperationsByType(typ int, isForUpdate bool) ([]data.Operation, error) {
+	return q.OperationsByTypeCtx(context.Background(), typ, isForUpdate)
+}
+
+// RejectionByIDCtx retrieves a row from 'public.rejections' as a Rejection.
+//
+// Generated from index 'rejections_pkey'.
+func (q RejectionQ) RejectionByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Rejection, error) {
 	// query
 	sqlstr := `SELECT ` +
 		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.confirmations ` +
-		`WHERE transfer_index = $1`
+		`FROM public.rejections ` +
+		`WHERE id = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.Confirmation
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, transferIndex)
+	var res data.Rejection
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
 	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return res, nil
+	return &res, nil
 }
 
-// ConfirmationsByTransferIndex retrieves a row from 'public.confirmations' as a Confirmation.
+// RejectionByID retrieves a row from 'public.rejections' as a Rejection.
 //
-// Generated from index 'confirmations_transfer_index'.
-func (q ConfirmationQ) ConfirmationsByTransferIndex(transferIndex []byte, isForUpdate bool) ([]data.Confirmation, error) {
-	return q.ConfirmationsByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+// Generated from index 'rejections_pkey'.
+func (q RejectionQ) RejectionByID(id int64, isForUpdate bool) (*data.Rejection, error) {
+	return q.RejectionByIDCtx(context.Background(), id, isForUpdate)
 }
 
-// ConfirmationByTransferIndexCtx retrieves a row from 'public.confirmations' as a Confirmation.
+// RejectionsByTransferIndexCtx retrieves a row from 'public.rejections' as a Rejection.
 //
-// Generated from index 'confirmations_transfer_index_key'.
-func (q ConfirmationQ) ConfirmationByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) (*data.Confirmation, error) {
+// Generated from index 'rejections_transfer_index'.
+func (q RejectionQ) RejectionsByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) ([]data.Rejection, error) {
 	// query
 	sqlstr := `SELECT ` +
 		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.confirmations ` +
+		`FROM public.rejections ` +
 		`WHERE transfer_index = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Confirmation
-	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex)
+	var res []data.Rejection
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, transferIndex)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
-			return nil, nil
-		}
-
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return &res, nil
+	return res, nil
 }
 
-// ConfirmationByTransferIndex retrieves a row from 'public.confirmations' as a Confirmation.
+// RejectionsByTransferIndex retrieves a row from 'public.rejections' as a Rejection.
 //
-// Generated from index 'confirmations_transfer_index_key'.
-func (q ConfirmationQ) ConfirmationByTransferIndex(transferIndex []byte, isForUpdate bool) (*data.Confirmation, error) {
-	return q.ConfirmationByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+// Generated from index 'rejections_transfer_index'.
+func (q RejectionQ) RejectionsByTransferIndex(transferIndex []byte, isForUpdate bool) ([]data.Rejection, error) {
+	return q.RejectionsByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
 }
 
-// GorpMigrationByIDCtx retrieves a row from 'public.gorp_migrations' as a GorpMigration.
+// RejectionByTransferIndexRarimoTransactionCtx retrieves a row from 'public.rejections' as a Rejection.
 //
-// Generated from index 'gorp_migrations_pkey'.
-func (q GorpMigrationQ) GorpMigrationByIDCtx(ctx context.Context, id string, isForUpdate bool) (*data.GorpMigration, error) {
+// Generated from index 'rejections_transfer_index_rarimo_transaction_key'.
+func (q RejectionQ) RejectionByTransferIndexRarimoTransactionCtx(ctx context.Context, transferIndex []byte, rarimoTransaction []byte, isForUpdate bool) (*data.Rejection, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, applied_at ` +
-		`FROM public.gorp_migrations ` +
-		`WHERE id = $1`
+		`id, transfer_index, rarimo_transaction, created_at ` +
+		`FROM public.rejections ` +
+		`WHERE transfer_index = $1 AND rarimo_transaction = $2`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.GorpMigration
-	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	var res data.Rejection
+	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex, rarimoTransaction)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, nil
@@ -1506,28 +2851,28 @@ func (q GorpMigrationQ) GorpMigrationByIDCtx(ctx context.Context, id string, isF
 	return &res, nil
 }
 
-// GorpMigrationByID retrieves a row from 'public.gorp_migrations' as a GorpMigration.
+// RejectionByTransferIndexRarimoTransaction retrieves a row from 'public.rejections' as a Rejection.
 //
-// Generated from index 'gorp_migrations_pkey'.
-func (q GorpMigrationQ) GorpMigrationByID(id string, isForUpdate bool) (*data.GorpMigration, error) {
-	return q.GorpMigrationByIDCtx(context.Background(), id, isForUpdate)
+// Generated from index 'rejections_transfer_index_rarimo_transaction_key'.
+func (q RejectionQ) RejectionByTransferIndexRarimoTransaction(transferIndex []byte, rarimoTransaction []byte, isForUpdate bool) (*data.Rejection, error) {
+	return q.RejectionByTransferIndexRarimoTransactionCtx(context.Background(), transferIndex, rarimoTransaction, isForUpdate)
 }
 
-// ItemsByCollectionCtx retrieves a row from 'public.items' as a Item.
+// SeedsByItemCtx retrieves a row from 'public.seeds' as a Seed.
 //
-// Generated from index 'item_collection'.
-func (q ItemQ) ItemsByCollectionCtx(ctx context.Context, collection sql.NullInt64, isForUpdate bool) ([]data.Item, error) {
+// Generated from index 'seeds_item'.
+func (q SeedQ) SeedsByItemCtx(ctx context.Context, item int64, isForUpdate bool) ([]data.Seed, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, index, collection, metadata, created_at, updated_at ` +
-		`FROM public.items ` +
-		`WHERE collection = $1`
+		`seed, item, created_tx, created_at ` +
+		`FROM public.seeds ` +
+		`WHERE item = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.Item
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, collection)
+	var res []data.Seed
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, item)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
@@ -1535,28 +2880,28 @@ func (q ItemQ) ItemsByCollectionCtx(ctx context.Context, collection sql.NullInt6
 	return res, nil
 }
 
-// ItemsByCollection retrieves a row from 'public.items' as a Item.
+// SeedsByItem retrieves a row from 'public.seeds' as a Seed.
 //
-// Generated from index 'item_collection'.
-func (q ItemQ) ItemsByCollection(collection sql.NullInt64, isForUpdate bool) ([]data.Item, error) {
-	return q.ItemsByCollectionCtx(context.Background(), collection, isForUpdate)
+// Generated from index 'seeds_item'.
+func (q SeedQ) SeedsByItem(item int64, isForUpdate bool) ([]data.Seed, error) {
+	return q.SeedsByItemCtx(context.Background(), item, isForUpdate)
 }
 
-// ItemByIndexCtx retrieves a row from 'public.items' as a Item.
+// SeedBySeedCtx retrieves a row from 'public.seeds' as a Seed.
 //
-// Generated from index 'items_index_key'.
-func (q ItemQ) ItemByIndexCtx(ctx context.Context, index []byte, isForUpdate bool) (*data.Item, error) {
+// Generated from index 'seeds_pkey'.
+func (q SeedQ) SeedBySeedCtx(ctx context.Context, seed string, isForUpdate bool) (*data.Seed, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, index, collection, metadata, created_at, updated_at ` +
-		`FROM public.items ` +
-		`WHERE index = $1`
+		`seed, item, created_tx, created_at ` +
+		`FROM public.seeds ` +
+		`WHERE seed = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Item
-	err := q.db.GetRawContext(ctx, &res, sqlstr, index)
+	var res data.Seed
+	err := q.db.GetRawContext(ctx, &res, sqlstr, seed)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, nil
@@ -1568,27 +2913,27 @@ func (q ItemQ) ItemByIndexCtx(ctx context.Context, index []byte, isForUpdate boo
 	return &res, nil
 }
 
-// ItemByIndex retrieves a row from 'public.items' as a Item.
+// SeedBySeed retrieves a row from 'public.seeds' as a Seed.
 //
-// Generated from index 'items_index_key'.
-func (q ItemQ) ItemByIndex(index []byte, isForUpdate bool) (*data.Item, error) {
-	return q.ItemByIndexCtx(context.Background(), index, isForUpdate)
+// Generated from index 'seeds_pkey'.
+func (q SeedQ) SeedBySeed(seed string, isForUpdate bool) (*data.Seed, error) {
+	return q.SeedBySeedCtx(context.Background(), seed, isForUpdate)
 }
 
-// ItemByIDCtx retrieves a row from 'public.items' as a Item.
+// StuckTransferAlertByIDCtx retrieves a row from 'public.stuck_transfer_alerts' as a StuckTransferAlert.
 //
-// Generated from index 'items_pkey'.
-func (q ItemQ) ItemByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Item, error) {
+// Generated from index 'stuck_transfer_alerts_pkey'.
+func (q StuckTransferAlertQ) StuckTransferAlertByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.StuckTransferAlert, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, index, collection, metadata, created_at, updated_at ` +
-		`FROM public.items ` +
+		`id, transfer_index, kind, origin, chain, status, since, detected_at, notified_at, resolved_at ` +
+		`FROM public.stuck_transfer_alerts ` +
 		`WHERE id = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Item
+	var res data.StuckTransferAlert
 	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
@@ -1601,57 +2946,61 @@ func (q ItemQ) ItemByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*da
 	return &res, nil
 }
 
-// ItemByID retrieves a row from 'public.items' as a Item.
+// StuckTransferAlertByID retrieves a row from 'public.stuck_transfer_alerts' as a StuckTransferAlert.
 //
-// Generated from index 'items_pkey'.
-func (q ItemQ) ItemByID(id int64, isForUpdate bool) (*data.Item, error) {
-	return q.ItemByIDCtx(context.Background(), id, isForUpdate)
+// Generated from index 'stuck_transfer_alerts_pkey'.
+func (q StuckTransferAlertQ) StuckTransferAlertByID(id int64, isForUpdate bool) (*data.StuckTransferAlert, error) {
+	return q.StuckTransferAlertByIDCtx(context.Background(), id, isForUpdate)
 }
 
-// ItemChainMappingsByAddressCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// StuckTransferAlertByTransferIndexKindCtx retrieves a row from 'public.stuck_transfer_alerts' as a StuckTransferAlert.
 //
-// Generated from index 'item_chain_mapping_address'.
-func (q ItemChainMappingQ) ItemChainMappingsByAddressCtx(ctx context.Context, address []byte, isForUpdate bool) ([]data.ItemChainMapping, error) {
+// Generated from index 'stuck_transfer_alerts_transfer_index_kind_key'.
+func (q StuckTransferAlertQ) StuckTransferAlertByTransferIndexKindCtx(ctx context.Context, transferIndex []byte, kind string, isForUpdate bool) (*data.StuckTransferAlert, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`item, network, address, token_id, created_at, updated_at ` +
-		`FROM public.item_chain_mappings ` +
-		`WHERE address = $1`
+		`id, transfer_index, kind, origin, chain, status, since, detected_at, notified_at, resolved_at ` +
+		`FROM public.stuck_transfer_alerts ` +
+		`WHERE transfer_index = $1 AND kind = $2`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.ItemChainMapping
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, address)
+	var res data.StuckTransferAlert
+	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex, kind)
 	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return res, nil
+	return &res, nil
 }
 
-// ItemChainMappingsByAddress retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// StuckTransferAlertByTransferIndexKind retrieves a row from 'public.stuck_transfer_alerts' as a StuckTransferAlert.
 //
-// Generated from index 'item_chain_mapping_address'.
-func (q ItemChainMappingQ) ItemChainMappingsByAddress(address []byte, isForUpdate bool) ([]data.ItemChainMapping, error) {
-	return q.ItemChainMappingsByAddressCtx(context.Background(), address, isForUpdate)
+// Generated from index 'stuck_transfer_alerts_transfer_index_kind_key'.
+func (q StuckTransferAlertQ) StuckTransferAlertByTransferIndexKind(transferIndex []byte, kind string, isForUpdate bool) (*data.StuckTransferAlert, error) {
+	return q.StuckTransferAlertByTransferIndexKindCtx(context.Background(), transferIndex, kind, isForUpdate)
 }
 
-// ItemChainMappingsByItemCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// TokenmanagerEventsByCollectionIndexCtx retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'item_chain_mapping_item'.
-func (q ItemChainMappingQ) ItemChainMappingsByItemCtx(ctx context.Context, item int64, isForUpdate bool) ([]data.ItemChainMapping, error) {
+// Generated from index 'tokenmanager_events_collection_index'.
+func (q TokenmanagerEventQ) TokenmanagerEventsByCollectionIndexCtx(ctx context.Context, collectionIndex sql.NullString, isForUpdate bool) ([]data.TokenmanagerEvent, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`item, network, address, token_id, created_at, updated_at ` +
-		`FROM public.item_chain_mappings ` +
-		`WHERE item = $1`
+		`id, type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at ` +
+		`FROM public.tokenmanager_events ` +
+		`WHERE collection_index = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.ItemChainMapping
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, item)
+	var res []data.TokenmanagerEvent
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, collectionIndex)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
@@ -1659,89 +3008,89 @@ func (q ItemChainMappingQ) ItemChainMappingsByItemCtx(ctx context.Context, item
 	return res, nil
 }
 
-// ItemChainMappingsByItem retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// TokenmanagerEventsByCollectionIndex retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'item_chain_mapping_item'.
-func (q ItemChainMappingQ) ItemChainMappingsByItem(item int64, isForUpdate bool) ([]data.ItemChainMapping, error) {
-	return q.ItemChainMappingsByItemCtx(context.Background(), item, isForUpdate)
+// Generated from index 'tokenmanager_events_collection_index'.
+func (q TokenmanagerEventQ) TokenmanagerEventsByCollectionIndex(collectionIndex sql.NullString, isForUpdate bool) ([]data.TokenmanagerEvent, error) {
+	return q.TokenmanagerEventsByCollectionIndexCtx(context.Background(), collectionIndex, isForUpdate)
 }
 
-// ItemChainMappingsByNetworkCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// TokenmanagerEventByEventKeyCtx retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'item_chain_mapping_network'.
-func (q ItemChainMappingQ) ItemChainMappingsByNetworkCtx(ctx context.Context, network int, isForUpdate bool) ([]data.ItemChainMapping, error) {
+// Generated from index 'tokenmanager_events_event_key_key'.
+func (q TokenmanagerEventQ) TokenmanagerEventByEventKeyCtx(ctx context.Context, eventKey sql.NullString, isForUpdate bool) (*data.TokenmanagerEvent, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`item, network, address, token_id, created_at, updated_at ` +
-		`FROM public.item_chain_mappings ` +
-		`WHERE network = $1`
+		`id, type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at ` +
+		`FROM public.tokenmanager_events ` +
+		`WHERE event_key = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.ItemChainMapping
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, network)
+	var res data.TokenmanagerEvent
+	err := q.db.GetRawContext(ctx, &res, sqlstr, eventKey)
 	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return res, nil
+	return &res, nil
 }
 
-// ItemChainMappingsByNetwork retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// TokenmanagerEventByEventKey retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'item_chain_mapping_network'.
-func (q ItemChainMappingQ) ItemChainMappingsByNetwork(network int, isForUpdate bool) ([]data.ItemChainMapping, error) {
-	return q.ItemChainMappingsByNetworkCtx(context.Background(), network, isForUpdate)
+// Generated from index 'tokenmanager_events_event_key_key'.
+func (q TokenmanagerEventQ) TokenmanagerEventByEventKey(eventKey sql.NullString, isForUpdate bool) (*data.TokenmanagerEvent, error) {
+	return q.TokenmanagerEventByEventKeyCtx(context.Background(), eventKey, isForUpdate)
 }
 
-// ItemChainMappingByItemNetworkCtx retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// TokenmanagerEventsByItemIndexCtx retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'item_chain_mappings_pkey'.
-func (q ItemChainMappingQ) ItemChainMappingByItemNetworkCtx(ctx context.Context, item int64, network int, isForUpdate bool) (*data.ItemChainMapping, error) {
+// Generated from index 'tokenmanager_events_item_index'.
+func (q TokenmanagerEventQ) TokenmanagerEventsByItemIndexCtx(ctx context.Context, itemIndex sql.NullString, isForUpdate bool) ([]data.TokenmanagerEvent, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`item, network, address, token_id, created_at, updated_at ` +
-		`FROM public.item_chain_mappings ` +
-		`WHERE item = $1 AND network = $2`
+		`id, type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at ` +
+		`FROM public.tokenmanager_events ` +
+		`WHERE item_index = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.ItemChainMapping
-	err := q.db.GetRawContext(ctx, &res, sqlstr, item, network)
+	var res []data.TokenmanagerEvent
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, itemIndex)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
-			return nil, nil
-		}
-
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
 
-	return &res, nil
+	return res, nil
 }
 
-// ItemChainMappingByItemNetwork retrieves a row from 'public.item_chain_mappings' as a ItemChainMapping.
+// TokenmanagerEventsByItemIndex retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'item_chain_mappings_pkey'.
-func (q ItemChainMappingQ) ItemChainMappingByItemNetwork(item int64, network int, isForUpdate bool) (*data.ItemChainMapping, error) {
-	return q.ItemChainMappingByItemNetworkCtx(context.Background(), item, network, isForUpdate)
+// Generated from index 'tokenmanager_events_item_index'.
+func (q TokenmanagerEventQ) TokenmanagerEventsByItemIndex(itemIndex sql.NullString, isForUpdate bool) ([]data.TokenmanagerEvent, error) {
+	return q.TokenmanagerEventsByItemIndexCtx(context.Background(), itemIndex, isForUpdate)
 }
 
-// RejectionByIDCtx retrieves a row from 'public.rejections' as a Rejection.
+// TokenmanagerEventByIDCtx retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'rejections_pkey'.
-func (q RejectionQ) RejectionByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.Rejection, error) {
+// Generated from index 'tokenmanager_events_pkey'.
+func (q TokenmanagerEventQ) TokenmanagerEventByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.TokenmanagerEvent, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.rejections ` +
+		`id, type, collection_index, item_index, chain, details, rarimo_tx, height, event_key, created_at ` +
+		`FROM public.tokenmanager_events ` +
 		`WHERE id = $1`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res data.Rejection
+	var res data.TokenmanagerEvent
 	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
@@ -1754,28 +3103,28 @@ func (q RejectionQ) RejectionByIDCtx(ctx context.Context, id int64, isForUpdate
 	return &res, nil
 }
 
-// RejectionByID retrieves a row from 'public.rejections' as a Rejection.
+// TokenmanagerEventByID retrieves a row from 'public.tokenmanager_events' as a TokenmanagerEvent.
 //
-// Generated from index 'rejections_pkey'.
-func (q RejectionQ) RejectionByID(id int64, isForUpdate bool) (*data.Rejection, error) {
-	return q.RejectionByIDCtx(context.Background(), id, isForUpdate)
+// Generated from index 'tokenmanager_events_pkey'.
+func (q TokenmanagerEventQ) TokenmanagerEventByID(id int64, isForUpdate bool) (*data.TokenmanagerEvent, error) {
+	return q.TokenmanagerEventByIDCtx(context.Background(), id, isForUpdate)
 }
 
-// RejectionsByTransferIndexCtx retrieves a row from 'public.rejections' as a Rejection.
+// TransactionsByBlockHeightIndexCtx retrieves a row from 'public.transactions' as a Transaction.
 //
-// Generated from index 'rejections_transfer_index'.
-func (q RejectionQ) RejectionsByTransferIndexCtx(ctx context.Context, transferIndex []byte, isForUpdate bool) ([]data.Rejection, error) {
+// Generated from index 'transactions_block_height_index'.
+func (q TransactionQ) TransactionsByBlockHeightIndexCtx(ctx context.Context, blockHeight sql.NullInt64, index sql.NullInt64, isForUpdate bool) ([]data.Transaction, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, transfer_index, rarimo_transaction, created_at ` +
-		`FROM public.rejections ` +
-		`WHERE transfer_index = $1`
+		`hash, block_height, index, raw_tx, tx_result, tx_timestamp, created_at ` +
+		`FROM public.transactions ` +
+		`WHERE block_height = $1 AND index = $2`
 	// run
 	if isForUpdate {
 		sqlstr += " for update"
 	}
-	var res []data.Rejection
-	err := q.db.SelectRawContext(ctx, &res, sqlstr, transferIndex)
+	var res []data.Transaction
+	err := q.db.SelectRawContext(ctx, &res, sqlstr, blockHeight, index)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to exec select")
 	}
@@ -1783,11 +3132,11 @@ func (q RejectionQ) RejectionsByTransferIndexCtx(ctx context.Context, transferIn
 	return res, nil
 }
 
-// RejectionsByTransferIndex retrieves a row from 'public.rejections' as a Rejection.
+// TransactionsByBlockHeightIndex retrieves a row from 'public.transactions' as a Transaction.
 //
-// Generated from index 'rejections_transfer_index'.
-func (q RejectionQ) RejectionsByTransferIndex(transferIndex []byte, isForUpdate bool) ([]data.Rejection, error) {
-	return q.RejectionsByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
+// Generated from index 'transactions_block_height_index'.
+func (q TransactionQ) TransactionsByBlockHeightIndex(blockHeight sql.NullInt64, index sql.NullInt64, isForUpdate bool) ([]data.Transaction, error) {
+	return q.TransactionsByBlockHeightIndexCtx(context.Background(), blockHeight, index, isForUpdate)
 }
 
 // TransactionByHashCtx retrieves a row from 'public.transactions' as a Transaction.
@@ -1889,6 +3238,72 @@ func (q TransferQ) TransferByID(id int64, isForUpdate bool) (*data.Transfer, err
 	return q.TransferByIDCtx(context.Background(), id, isForUpdate)
 }
 
+// TransferStatusChangeByIDCtx retrieves a row from 'public.transfer_status_changes' as a TransferStatusChange.
+//
+// Generated from index 'transfer_status_changes_pkey'.
+func (q TransferStatusChangeQ) TransferStatusChangeByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.TransferStatusChange, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, status, rarimo_transaction, rarimo_tx_timestamp, created_at ` +
+		`FROM public.transfer_status_changes ` +
+		`WHERE id = $1`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.TransferStatusChange
+	err := q.db.GetRawContext(ctx, &res, sqlstr, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// TransferStatusChangeByID retrieves a row from 'public.transfer_status_changes' as a TransferStatusChange.
+//
+// Generated from index 'transfer_status_changes_pkey'.
+func (q TransferStatusChangeQ) TransferStatusChangeByID(id int64, isForUpdate bool) (*data.TransferStatusChange, error) {
+	return q.TransferStatusChangeByIDCtx(context.Background(), id, isForUpdate)
+}
+
+// TransferStatusChangeByTransferIndexStatusCtx retrieves a row from 'public.transfer_status_changes' as a TransferStatusChange.
+//
+// Generated from index 'transfer_status_changes_transfer_index_status_key'.
+func (q TransferStatusChangeQ) TransferStatusChangeByTransferIndexStatusCtx(ctx context.Context, transferIndex []byte, status int, isForUpdate bool) (*data.TransferStatusChange, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, status, rarimo_transaction, rarimo_tx_timestamp, created_at ` +
+		`FROM public.transfer_status_changes ` +
+		`WHERE transfer_index = $1 AND status = $2`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.TransferStatusChange
+	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex, status)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// TransferStatusChangeByTransferIndexStatus retrieves a row from 'public.transfer_status_changes' as a TransferStatusChange.
+//
+// Generated from index 'transfer_status_changes_transfer_index_status_key'.
+func (q TransferStatusChangeQ) TransferStatusChangeByTransferIndexStatus(transferIndex []byte, status int, isForUpdate bool) (*data.TransferStatusChange, error) {
+	return q.TransferStatusChangeByTransferIndexStatusCtx(context.Background(), transferIndex, status, isForUpdate)
+}
+
 // VoteByIDCtx retrieves a row from 'public.votes' as a Vote.
 //
 // Generated from index 'votes_pkey'.
@@ -1951,6 +3366,39 @@ func (q VoteQ) VotesByTransferIndex(transferIndex []byte, isForUpdate bool) ([]d
 	return q.VotesByTransferIndexCtx(context.Background(), transferIndex, isForUpdate)
 }
 
+// VoteByTransferIndexRarimoTransactionCtx retrieves a row from 'public.votes' as a Vote.
+//
+// Generated from index 'votes_transfer_index_rarimo_transaction_key'.
+func (q VoteQ) VoteByTransferIndexRarimoTransactionCtx(ctx context.Context, transferIndex []byte, rarimoTransaction []byte, isForUpdate bool) (*data.Vote, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`id, transfer_index, choice, rarimo_transaction, created_at ` +
+		`FROM public.votes ` +
+		`WHERE transfer_index = $1 AND rarimo_transaction = $2`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.Vote
+	err := q.db.GetRawContext(ctx, &res, sqlstr, transferIndex, rarimoTransaction)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// VoteByTransferIndexRarimoTransaction retrieves a row from 'public.votes' as a Vote.
+//
+// Generated from index 'votes_transfer_index_rarimo_transaction_key'.
+func (q VoteQ) VoteByTransferIndexRarimoTransaction(transferIndex []byte, rarimoTransaction []byte, isForUpdate bool) (*data.Vote, error) {
+	return q.VoteByTransferIndexRarimoTransactionCtx(context.Background(), transferIndex, rarimoTransaction, isForUpdate)
+}
+
 // WithdrawalByOriginCtx retrieves a row from 'public.withdrawals' as a Withdrawal.
 //
 // Generated from index 'withdrawals_pkey'.
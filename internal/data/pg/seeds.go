@@ -0,0 +1,26 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+)
+
+func (q SeedQ) RelinkCtx(ctx context.Context, s *data.Seed) error {
+	stmt := squirrel.Insert("public.seeds").
+		Columns("seed", "item", "created_tx", "created_at").
+		Values(s.Seed, s.Item, s.CreatedTx, s.CreatedAt).
+		// creating transaction is known from the seed created event only, so it is not reset by the item savers
+		Suffix("ON CONFLICT (seed) DO UPDATE SET item = EXCLUDED.item, " +
+			"created_tx = COALESCE(EXCLUDED.created_tx, seeds.created_tx)")
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+func (q SeedQ) DeleteByItemCtx(ctx context.Context, item int64) error {
+	return q.db.ExecContext(ctx,
+		squirrel.
+			Delete("public.seeds").
+			Where(squirrel.Eq{"item": item}))
+}
@@ -0,0 +1,175 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// withdrawalsJoin joins withdrawals by the origin, which is stored as the hex string in the transfers
+const withdrawalsJoin = "public.withdrawals w ON w.origin = decode(substring(t.origin from 3), 'hex')"
+
+func (q StuckTransferAlertQ) SelectCandidatesCtx(ctx context.Context, selector data.StuckTransferCandidatesSelector) ([]data.StuckTransferAlert, error) {
+	var since string
+	stmt := squirrel.Select().From("public.transfers t")
+
+	switch selector.Kind {
+	case data.StuckTransferInitialized:
+		since = "t.rarimo_tx_timestamp"
+		stmt = stmt.Where(squirrel.Eq{"t.status": int(rarimocore.OpStatus_INITIALIZED)})
+	case data.StuckTransferNotWithdrawn:
+		// transfers indexed before the status changes were recorded have no signing time, so the operation
+		// time is used for them
+		since = "COALESCE(sc.rarimo_tx_timestamp, sc.created_at, t.rarimo_tx_timestamp)"
+		stmt = stmt.
+			LeftJoin(withdrawalsJoin).
+			LeftJoin(fmt.Sprintf("public.transfer_status_changes sc ON sc.transfer_index = t.index AND sc.status = %d",
+				rarimocore.OpStatus_SIGNED)).
+			Where(squirrel.Eq{"t.status": int(rarimocore.OpStatus_SIGNED)}).
+			Where("w.origin IS NULL")
+	case data.StuckTransferWithdrawalFailed:
+		since = "w.created_at"
+		stmt = stmt.
+			Join(withdrawalsJoin).
+			Where(squirrel.Eq{"w.success": false})
+	default:
+		return nil, errors.From(errors.New("unknown stuck transfer kind"), logan.F{
+			"kind": selector.Kind,
+		})
+	}
+
+	stmt = stmt.
+		Columns(
+			"t.index AS transfer_index",
+			"t.origin",
+			"t.to_chain AS chain",
+			"t.status",
+			since+" AS since",
+		).
+		Where(squirrel.Lt{since: selector.Before})
+
+	var alerts []data.StuckTransferAlert
+
+	if err := q.db.SelectContext(ctx, &alerts, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select stuck transfers", logan.F{
+			"kind": selector.Kind,
+		})
+	}
+
+	for i := range alerts {
+		alerts[i].Kind = selector.Kind
+	}
+
+	return alerts, nil
+}
+
+func (q StuckTransferAlertQ) UpsertBatchCtx(ctx context.Context, alerts ...data.StuckTransferAlert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	stmt := squirrel.Insert("public.stuck_transfer_alerts").
+		Columns("transfer_index", "kind", "origin", "chain", "status", "since", "detected_at")
+
+	for _, alert := range alerts {
+		stmt = stmt.Values(alert.TransferIndex, alert.Kind, alert.Origin, alert.Chain, alert.Status, alert.Since, alert.DetectedAt)
+	}
+
+	// reopened alerts are notified again, while the open ones keep the time they were detected at
+	stmt = stmt.Suffix("ON CONFLICT (transfer_index, kind) DO UPDATE SET " +
+		"status = EXCLUDED.status, since = EXCLUDED.since, detected_at = EXCLUDED.detected_at, notified_at = NULL, resolved_at = NULL " +
+		"WHERE stuck_transfer_alerts.resolved_at IS NOT NULL")
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+func (q StuckTransferAlertQ) ResolveCtx(ctx context.Context, kind string, except ...[]byte) error {
+	stmt := squirrel.Update("public.stuck_transfer_alerts").
+		Set("resolved_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"kind": kind}).
+		Where("resolved_at IS NULL")
+
+	if len(except) != 0 {
+		stmt = stmt.Where(squirrel.NotEq{"transfer_index": except})
+	}
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+func (q StuckTransferAlertQ) SetNotifiedCtx(ctx context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := squirrel.Update("public.stuck_transfer_alerts").
+		Set("notified_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": ids})
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+func (q StuckTransferAlertQ) SelectCtx(ctx context.Context, selector data.StuckTransferAlertSelector) ([]data.StuckTransferAlert, error) {
+	stmt := squirrel.Select("*").From("public.stuck_transfer_alerts")
+
+	if selector.Kind != nil {
+		stmt = stmt.Where(squirrel.Eq{"kind": selector.Kind})
+	}
+
+	if selector.Chain != nil {
+		stmt = stmt.Where(squirrel.Eq{"chain": selector.Chain})
+	}
+
+	if selector.Resolved != nil {
+		stmt = stmt.Where(nullFilter("resolved_at", *selector.Resolved))
+	}
+
+	if selector.Notified != nil {
+		stmt = stmt.Where(nullFilter("notified_at", *selector.Notified))
+	}
+
+	if selector.PageSize != 0 {
+		stmt = stmt.Limit(selector.PageSize)
+	}
+
+	sorts := selector.Sort
+	if len(sorts) == 0 {
+		sorts = pgdb.Sorts{"-id"}
+	}
+
+	stmt = sorts.ApplyTo(stmt, map[string]string{
+		"id": "id",
+	})
+
+	if selector.PageCursor != 0 {
+		comp := ">"
+		if strings.HasPrefix(string(sorts[0]), "-") {
+			comp = "<"
+		}
+
+		stmt = stmt.Where(fmt.Sprintf("id %s ?", comp), selector.PageCursor)
+	}
+
+	var alerts []data.StuckTransferAlert
+
+	if err := q.db.SelectContext(ctx, &alerts, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select stuck transfer alerts")
+	}
+
+	return alerts, nil
+}
+
+// nullFilter matches rows with the column set if isSet is true and with the null column otherwise
+func nullFilter(column string, isSet bool) squirrel.Sqlizer {
+	if isSet {
+		return squirrel.NotEq{column: nil}
+	}
+
+	return squirrel.Eq{column: nil}
+}
@@ -0,0 +1,64 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func (q TokenmanagerEventQ) AppendCtx(ctx context.Context, e *data.TokenmanagerEvent) error {
+	stmt := squirrel.Insert("public.tokenmanager_events").
+		Columns("type", "collection_index", "item_index", "chain", "details", "rarimo_tx", "height", "event_key", "created_at").
+		Values(e.Type, e.CollectionIndex, e.ItemIndex, e.Chain, e.Details, e.RarimoTx, e.Height, e.EventKey, e.CreatedAt).
+		Suffix("ON CONFLICT (event_key) DO NOTHING")
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+func (q TokenmanagerEventQ) SelectCtx(ctx context.Context, selector data.TokenmanagerEventSelector) ([]data.TokenmanagerEvent, error) {
+	stmt := squirrel.Select("*").From("public.tokenmanager_events")
+
+	if selector.CollectionIndex != nil {
+		stmt = stmt.Where(squirrel.Eq{"collection_index": selector.CollectionIndex})
+	}
+
+	if selector.ItemIndex != nil {
+		stmt = stmt.Where(squirrel.Eq{"item_index": selector.ItemIndex})
+	}
+
+	if selector.PageSize != 0 {
+		stmt = stmt.Limit(selector.PageSize)
+	}
+
+	sorts := selector.Sort
+	if len(sorts) == 0 {
+		sorts = pgdb.Sorts{"-id"}
+	}
+
+	// events are appended in the order they are indexed, so the id is their order in the history
+	stmt = sorts.ApplyTo(stmt, map[string]string{
+		"id": "id",
+	})
+
+	if selector.PageCursor != 0 {
+		comp := ">"
+		if strings.HasPrefix(string(sorts[0]), "-") {
+			comp = "<"
+		}
+
+		stmt = stmt.Where(fmt.Sprintf("id %s ?", comp), selector.PageCursor)
+	}
+
+	var events []data.TokenmanagerEvent
+
+	if err := q.db.SelectContext(ctx, &events, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select tokenmanager events")
+	}
+
+	return events, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Masterminds/squirrel"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 
 	"github.com/rarimo/horizon-svc/internal/data"
 )
@@ -27,3 +28,34 @@ func (q TransactionQ) InsertBatchCtx(ctx context.Context, transfers ...data.Tran
 
 	return q.db.ExecContext(ctx, stmt)
 }
+
+func (q TransactionQ) SelectCtx(ctx context.Context, selector data.TransactionSelector) ([]data.Transaction, error) {
+	stmt := squirrel.Select("*").
+		From("public.transactions").
+		Where(squirrel.NotEq{"block_height": nil}).
+		OrderBy("block_height", "index")
+
+	if selector.FromHeight != nil {
+		stmt = stmt.Where(squirrel.GtOrEq{"block_height": *selector.FromHeight})
+	}
+
+	if selector.ToHeight != nil {
+		stmt = stmt.Where(squirrel.LtOrEq{"block_height": *selector.ToHeight})
+	}
+
+	if selector.AfterHeight != nil && selector.AfterIndex != nil {
+		stmt = stmt.Where("(block_height, index) > (?, ?)", *selector.AfterHeight, *selector.AfterIndex)
+	}
+
+	if selector.PageSize != 0 {
+		stmt = stmt.Limit(selector.PageSize)
+	}
+
+	var transactions []data.Transaction
+
+	if err := q.db.SelectContext(ctx, &transactions, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select transactions")
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,47 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func (q TransferStatusChangeQ) InsertBatchCtx(ctx context.Context, changes ...data.TransferStatusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	stmt := squirrel.Insert("public.transfer_status_changes").
+		Columns("transfer_index", "status", "rarimo_transaction", "rarimo_tx_timestamp", "created_at")
+
+	for _, change := range changes {
+		stmt = stmt.
+			Values(change.TransferIndex, change.Status, change.RarimoTransaction, change.RarimoTxTimestamp, change.CreatedAt)
+	}
+
+	// redelivered messages must not duplicate rows, the first transaction reached the status is kept
+	stmt = stmt.Suffix("ON CONFLICT(transfer_index, status) DO NOTHING")
+
+	return q.db.ExecContext(ctx, stmt)
+}
+
+func (q TransferStatusChangeQ) SelectByTransferIndexesCtx(ctx context.Context, indexes ...[]byte) ([]data.TransferStatusChange, error) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	stmt := squirrel.Select("*").
+		From("public.transfer_status_changes").
+		Where(squirrel.Eq{"transfer_index": indexes}).
+		OrderBy("transfer_index", "rarimo_tx_timestamp", "created_at")
+
+	var changes []data.TransferStatusChange
+
+	if err := q.db.SelectContext(ctx, &changes, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to select transfer status changes")
+	}
+
+	return changes, nil
+}
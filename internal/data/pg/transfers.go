@@ -17,6 +17,11 @@ import (
 )
 
 func (q TransferQ) UpsertBatchCtx(ctx context.Context, transfers ...data.Transfer) error {
+	transfers = uniqueByKey(transfers, func(row data.Transfer) []byte { return row.Index })
+	if len(transfers) == 0 {
+		return nil
+	}
+
 	stmt := squirrel.Insert("public.transfers").
 		Columns("index", "status",
 			"created_at", "updated_at", "creator",
@@ -39,18 +44,32 @@ func (q TransferQ) UpsertBatchCtx(ctx context.Context, transfers ...data.Transfe
 	stmt = stmt.Suffix(
 		`ON CONFLICT(index) DO ` +
 			`UPDATE SET ` +
-			`status = EXCLUDED.status, updated_at = EXCLUDED.updated_at, creator = EXCLUDED.creator, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, origin = EXCLUDED.origin, tx = EXCLUDED.tx, event_id = EXCLUDED.event_id, from_chain = EXCLUDED.from_chain, to_chain = EXCLUDED.to_chain, receiver = EXCLUDED.receiver, amount = EXCLUDED.amount, bundle_data = EXCLUDED.bundle_data, bundle_salt = EXCLUDED.bundle_salt, item_index = EXCLUDED.item_index `)
+			greatestStatus("transfers") + `, updated_at = EXCLUDED.updated_at, creator = EXCLUDED.creator, rarimo_tx = EXCLUDED.rarimo_tx, rarimo_tx_timestamp = EXCLUDED.rarimo_tx_timestamp, origin = EXCLUDED.origin, tx = EXCLUDED.tx, event_id = EXCLUDED.event_id, from_chain = EXCLUDED.from_chain, to_chain = EXCLUDED.to_chain, receiver = EXCLUDED.receiver, amount = EXCLUDED.amount, bundle_data = EXCLUDED.bundle_data, bundle_salt = EXCLUDED.bundle_salt, item_index = EXCLUDED.item_index `)
 
 	return q.db.ExecContext(ctx, stmt)
 }
 
-func (q TransferQ) SetStatusByIndexCtx(ctx context.Context, status int, indexes ...string) error {
+// SetStatusByIndexCtx only moves status forward, so redelivered approvals or rejections can't
+// override status of already signed transfers. Returns indexes of the transfers which status was moved.
+func (q TransferQ) SetStatusByIndexCtx(ctx context.Context, status int, indexes ...string) ([]string, error) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
 	stmt := squirrel.
 		Update("public.transfers").
 		Set("status", status).
-		Where(squirrel.Eq{"index": indexes})
+		Where(squirrel.Eq{"index": indexes}).
+		Where(squirrel.Lt{"status": status}).
+		Suffix("RETURNING index")
 
-	return q.db.ExecContext(ctx, stmt)
+	var moved []string
+
+	if err := q.db.SelectContext(ctx, &moved, stmt); err != nil {
+		return nil, errors.Wrap(err, "failed to set transfers status")
+	}
+
+	return moved, nil
 }
 
 func (q TransferQ) SelectCtx(ctx context.Context, selector data.TransferSelector) ([]data.Transfer, error) {
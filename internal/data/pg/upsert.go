@@ -0,0 +1,26 @@
+package pg
+
+// uniqueByKey leaves only the last row for every key in the place of the first one, as postgres does not allow
+// to update the same row twice within one upsert statement
+func uniqueByKey[T any](rows []T, key func(row T) []byte) []T {
+	positions := make(map[string]int, len(rows))
+	result := make([]T, 0, len(rows))
+
+	for _, row := range rows {
+		if i, ok := positions[string(key(row))]; ok {
+			result[i] = row
+			continue
+		}
+
+		positions[string(key(row))] = len(result)
+		result = append(result, row)
+	}
+
+	return result
+}
+
+// greatestStatus is the upsert assignment of the status of the operations stored in the table, it never moves the
+// status back, so the redelivered messages can't reset approved or signed operations
+func greatestStatus(table string) string {
+	return "status = GREATEST(" + table + ".status, EXCLUDED.status)"
+}
@@ -9,6 +9,10 @@ import (
 )
 
 func (q VoteQ) InsertBatchCtx(ctx context.Context, votes ...data.Vote) error {
+	if len(votes) == 0 {
+		return nil
+	}
+
 	stmt := squirrel.Insert("public.votes").
 		Columns("transfer_index", "choice", "rarimo_transaction", "created_at")
 
@@ -18,5 +22,8 @@ func (q VoteQ) InsertBatchCtx(ctx context.Context, votes ...data.Vote) error {
 			vote.RarimoTransaction, vote.CreatedAt)
 	}
 
+	// redelivered messages must not duplicate rows
+	stmt = stmt.Suffix("ON CONFLICT(transfer_index, rarimo_transaction) DO NOTHING")
+
 	return q.db.ExecContext(ctx, stmt)
 }
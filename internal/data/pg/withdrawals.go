@@ -9,6 +9,10 @@ import (
 )
 
 func (q WithdrawalQ) InsertBatchCtx(ctx context.Context, withdrawals ...data.Withdrawal) error {
+	if len(withdrawals) == 0 {
+		return nil
+	}
+
 	stmt := squirrel.Insert("public.withdrawals").Columns(colsWithdrawal)
 
 	for _, withdrawal := range withdrawals {
@@ -24,3 +28,10 @@ func (q WithdrawalQ) InsertBatchCtx(ctx context.Context, withdrawals ...data.Wit
 
 	return q.db.ExecContext(ctx, stmt)
 }
+
+func (q WithdrawalQ) RetractCtx(ctx context.Context, origin []byte, hash string) error {
+	stmt := squirrel.Delete("public.withdrawals").
+		Where(squirrel.Eq{"origin": origin, "hash": hash})
+
+	return q.db.ExecContext(ctx, stmt)
+}
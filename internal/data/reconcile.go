@@ -0,0 +1,58 @@
+package data
+
+import "time"
+
+// ReconcileReportKey is the key value storage key of the latest report of the reconciler
+const ReconcileReportKey = "reconcile_report"
+
+// Kinds of the differences between the horizon database and the rarimo core state
+const (
+	ReconcileCollectionMissing     = "collection_missing"
+	ReconcileCollectionExtra       = "collection_extra"
+	ReconcileCollectionMetadata    = "collection_metadata"
+	ReconcileCollectionDataMissing = "collection_data_missing"
+	ReconcileCollectionDataExtra   = "collection_data_extra"
+	ReconcileCollectionDataChanged = "collection_data_changed"
+	ReconcileItemMissing           = "item_missing"
+	ReconcileItemExtra             = "item_extra"
+	ReconcileItemChanged           = "item_changed"
+	ReconcileItemOnChainMissing    = "item_on_chain_missing"
+	ReconcileItemOnChainExtra      = "item_on_chain_extra"
+	ReconcileItemOnChainChanged    = "item_on_chain_changed"
+)
+
+var ReconcileDifferenceKinds = []string{
+	ReconcileCollectionMissing,
+	ReconcileCollectionExtra,
+	ReconcileCollectionMetadata,
+	ReconcileCollectionDataMissing,
+	ReconcileCollectionDataExtra,
+	ReconcileCollectionDataChanged,
+	ReconcileItemMissing,
+	ReconcileItemExtra,
+	ReconcileItemChanged,
+	ReconcileItemOnChainMissing,
+	ReconcileItemOnChainExtra,
+	ReconcileItemOnChainChanged,
+}
+
+type ReconcileDifference struct {
+	Kind       string `json:"kind"`
+	Collection string `json:"collection,omitempty"`
+	Item       string `json:"item,omitempty"`
+	Chain      string `json:"chain,omitempty"`
+	Repaired   bool   `json:"repaired"`
+}
+
+// ReconcileReport is the result of the comparison of the tokenmanager tables with the rarimo core state
+type ReconcileReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Repair     bool      `json:"repair"`
+	// Counts are the numbers of the differences by their kinds
+	Counts   map[string]int64 `json:"counts"`
+	Repaired int64            `json:"repaired"`
+	// Differences are truncated to the configured limit, while Counts are not
+	Differences []ReconcileDifference `json:"differences"`
+	Truncated   bool                  `json:"truncated"`
+}
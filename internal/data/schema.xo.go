@@ -73,11 +73,12 @@ type Approval struct {
 
 // Collection represents a row from 'public.collections'.
 type Collection struct {
-	ID        int64     `db:"id" json:"id" structs:"-"`                          // id
-	Index     []byte    `db:"index" json:"index" structs:"index"`                // index
-	Metadata  xo.Jsonb  `db:"metadata" json:"metadata" structs:"metadata"`       // metadata
-	CreatedAt time.Time `db:"created_at" json:"created_at" structs:"created_at"` // created_at
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at" structs:"updated_at"` // updated_at
+	ID        int64        `db:"id" json:"id" structs:"-"`                          // id
+	Index     []byte       `db:"index" json:"index" structs:"index"`                // index
+	Metadata  xo.Jsonb     `db:"metadata" json:"metadata" structs:"metadata"`       // metadata
+	CreatedAt time.Time    `db:"created_at" json:"created_at" structs:"created_at"` // created_at
+	UpdatedAt time.Time    `db:"updated_at" json:"updated_at" structs:"updated_at"` // updated_at
+	DeletedAt sql.NullTime `db:"deleted_at" json:"deleted_at" structs:"deleted_at"` // deleted_at
 
 }
 
@@ -110,6 +111,31 @@ type GorpMigration struct {
 
 }
 
+// IdentityTransfer represents a row from 'public.identity_transfers'.
+type IdentityTransfer struct {
+	ID                  int64          `db:"id" json:"id" structs:"-"`                                                              // id
+	OperationIndex      []byte         `db:"operation_index" json:"operation_index" structs:"operation_index"`                      // operation_index
+	Type                int            `db:"type" json:"type" structs:"type"`                                                       // type
+	Status              int            `db:"status" json:"status" structs:"status"`                                                 // status
+	Chain               string         `db:"chain" json:"chain" structs:"chain"`                                                    // chain
+	Contract            string         `db:"contract" json:"contract" structs:"contract"`                                           // contract
+	IdentityID          sql.NullString `db:"identity_id" json:"identity_id" structs:"identity_id"`                                  // identity_id
+	StateHash           sql.NullString `db:"state_hash" json:"state_hash" structs:"state_hash"`                                     // state_hash
+	StateCreatedAt      sql.NullTime   `db:"state_created_at" json:"state_created_at" structs:"state_created_at"`                   // state_created_at
+	StateCreatedAtBlock sql.NullInt64  `db:"state_created_at_block" json:"state_created_at_block" structs:"state_created_at_block"` // state_created_at_block
+	ReplacedStateHash   sql.NullString `db:"replaced_state_hash" json:"replaced_state_hash" structs:"replaced_state_hash"`          // replaced_state_hash
+	GistHash            sql.NullString `db:"gist_hash" json:"gist_hash" structs:"gist_hash"`                                        // gist_hash
+	GistCreatedAt       sql.NullTime   `db:"gist_created_at" json:"gist_created_at" structs:"gist_created_at"`                      // gist_created_at
+	GistCreatedAtBlock  sql.NullInt64  `db:"gist_created_at_block" json:"gist_created_at_block" structs:"gist_created_at_block"`    // gist_created_at_block
+	ReplacedGistHash    sql.NullString `db:"replaced_gist_hash" json:"replaced_gist_hash" structs:"replaced_gist_hash"`             // replaced_gist_hash
+	StateRootHash       sql.NullString `db:"state_root_hash" json:"state_root_hash" structs:"state_root_hash"`                      // state_root_hash
+	RarimoTx            []byte         `db:"rarimo_tx" json:"rarimo_tx" structs:"rarimo_tx"`                                        // rarimo_tx
+	RarimoTxTimestamp   time.Time      `db:"rarimo_tx_timestamp" json:"rarimo_tx_timestamp" structs:"rarimo_tx_timestamp"`          // rarimo_tx_timestamp
+	CreatedAt           time.Time      `db:"created_at" json:"created_at" structs:"created_at"`                                     // created_at
+	UpdatedAt           time.Time      `db:"updated_at" json:"updated_at" structs:"updated_at"`                                     // updated_at
+
+}
+
 // Item represents a row from 'public.items'.
 type Item struct {
 	ID         int64         `db:"id" json:"id" structs:"-"`                          // id
@@ -118,6 +144,7 @@ type Item struct {
 	Metadata   xo.Jsonb      `db:"metadata" json:"metadata" structs:"metadata"`       // metadata
 	CreatedAt  time.Time     `db:"created_at" json:"created_at" structs:"created_at"` // created_at
 	UpdatedAt  time.Time     `db:"updated_at" json:"updated_at" structs:"updated_at"` // updated_at
+	DeletedAt  sql.NullTime  `db:"deleted_at" json:"deleted_at" structs:"deleted_at"` // deleted_at
 
 }
 
@@ -132,6 +159,35 @@ type ItemChainMapping struct {
 
 }
 
+// ItemMetadatum represents a row from 'public.item_metadata'.
+type ItemMetadatum struct {
+	Item        int64          `db:"item" json:"item" structs:"-"`                               // item
+	Network     int            `db:"network" json:"network" structs:"-"`                         // network
+	Metadata    xo.NullJsonb   `db:"metadata" json:"metadata" structs:"metadata"`                // metadata
+	FetchedAt   sql.NullTime   `db:"fetched_at" json:"fetched_at" structs:"fetched_at"`          // fetched_at
+	Failures    int            `db:"failures" json:"failures" structs:"failures"`                // failures
+	LastError   sql.NullString `db:"last_error" json:"last_error" structs:"last_error"`          // last_error
+	NextFetchAt time.Time      `db:"next_fetch_at" json:"next_fetch_at" structs:"next_fetch_at"` // next_fetch_at
+	CreatedAt   time.Time      `db:"created_at" json:"created_at" structs:"created_at"`          // created_at
+	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at" structs:"updated_at"`          // updated_at
+
+}
+
+// Operation represents a row from 'public.operations'.
+type Operation struct {
+	ID                int64          `db:"id" json:"id" structs:"-"`                                                     // id
+	Index             []byte         `db:"index" json:"index" structs:"index"`                                           // index
+	Type              int            `db:"type" json:"type" structs:"type"`                                              // type
+	Status            int            `db:"status" json:"status" structs:"status"`                                        // status
+	Creator           sql.NullString `db:"creator" json:"creator" structs:"creator"`                                     // creator
+	RarimoTx          []byte         `db:"rarimo_tx" json:"rarimo_tx" structs:"rarimo_tx"`                               // rarimo_tx
+	RarimoTxTimestamp time.Time      `db:"rarimo_tx_timestamp" json:"rarimo_tx_timestamp" structs:"rarimo_tx_timestamp"` // rarimo_tx_timestamp
+	Details           xo.Jsonb       `db:"details" json:"details" structs:"details"`                                     // details
+	CreatedAt         time.Time      `db:"created_at" json:"created_at" structs:"created_at"`                            // created_at
+	UpdatedAt         time.Time      `db:"updated_at" json:"updated_at" structs:"updated_at"`                            // updated_at
+
+}
+
 // Rejection represents a row from 'public.rejections'.
 type Rejection struct {
 	ID                int64     `db:"id" json:"id" structs:"-"`                                                  // id
@@ -141,6 +197,45 @@ type Rejection struct {
 
 }
 
+// Seed represents a row from 'public.seeds'.
+type Seed struct {
+	Seed      string         `db:"seed" json:"seed" structs:"-"`                      // seed
+	Item      int64          `db:"item" json:"item" structs:"item"`                   // item
+	CreatedTx sql.NullString `db:"created_tx" json:"created_tx" structs:"created_tx"` // created_tx
+	CreatedAt time.Time      `db:"created_at" json:"created_at" structs:"created_at"` // created_at
+
+}
+
+// StuckTransferAlert represents a row from 'public.stuck_transfer_alerts'.
+type StuckTransferAlert struct {
+	ID            int64        `db:"id" json:"id" structs:"-"`                                      // id
+	TransferIndex []byte       `db:"transfer_index" json:"transfer_index" structs:"transfer_index"` // transfer_index
+	Kind          string       `db:"kind" json:"kind" structs:"kind"`                               // kind
+	Origin        string       `db:"origin" json:"origin" structs:"origin"`                         // origin
+	Chain         string       `db:"chain" json:"chain" structs:"chain"`                            // chain
+	Status        int          `db:"status" json:"status" structs:"status"`                         // status
+	Since         time.Time    `db:"since" json:"since" structs:"since"`                            // since
+	DetectedAt    time.Time    `db:"detected_at" json:"detected_at" structs:"detected_at"`          // detected_at
+	NotifiedAt    sql.NullTime `db:"notified_at" json:"notified_at" structs:"notified_at"`          // notified_at
+	ResolvedAt    sql.NullTime `db:"resolved_at" json:"resolved_at" structs:"resolved_at"`          // resolved_at
+
+}
+
+// TokenmanagerEvent represents a row from 'public.tokenmanager_events'.
+type TokenmanagerEvent struct {
+	ID              int64          `db:"id" json:"id" structs:"-"`                                            // id
+	Type            string         `db:"type" json:"type" structs:"type"`                                     // type
+	CollectionIndex sql.NullString `db:"collection_index" json:"collection_index" structs:"collection_index"` // collection_index
+	ItemIndex       sql.NullString `db:"item_index" json:"item_index" structs:"item_index"`                   // item_index
+	Chain           sql.NullString `db:"chain" json:"chain" structs:"chain"`                                  // chain
+	Details         xo.Jsonb       `db:"details" json:"details" structs:"details"`                            // details
+	RarimoTx        sql.NullString `db:"rarimo_tx" json:"rarimo_tx" structs:"rarimo_tx"`                      // rarimo_tx
+	Height          sql.NullInt64  `db:"height" json:"height" structs:"height"`                               // height
+	EventKey        sql.NullString `db:"event_key" json:"event_key" structs:"event_key"`                      // event_key
+	CreatedAt       time.Time      `db:"created_at" json:"created_at" structs:"created_at"`                   // created_at
+
+}
+
 // Transaction represents a row from 'public.transactions'.
 type Transaction struct {
 	Hash        []byte        `db:"hash" json:"hash" structs:"-"`                            // hash
@@ -176,6 +271,17 @@ type Transfer struct {
 
 }
 
+// TransferStatusChange represents a row from 'public.transfer_status_changes'.
+type TransferStatusChange struct {
+	ID                int64        `db:"id" json:"id" structs:"-"`                                                     // id
+	TransferIndex     []byte       `db:"transfer_index" json:"transfer_index" structs:"transfer_index"`                // transfer_index
+	Status            int          `db:"status" json:"status" structs:"status"`                                        // status
+	RarimoTransaction []byte       `db:"rarimo_transaction" json:"rarimo_transaction" structs:"rarimo_transaction"`    // rarimo_transaction
+	RarimoTxTimestamp sql.NullTime `db:"rarimo_tx_timestamp" json:"rarimo_tx_timestamp" structs:"rarimo_tx_timestamp"` // rarimo_tx_timestamp
+	CreatedAt         time.Time    `db:"created_at" json:"created_at" structs:"created_at"`                            // created_at
+
+}
+
 // Vote represents a row from 'public.votes'.
 type Vote struct {
 	ID                int64     `db:"id" json:"id" structs:"-"`                                                  // id
@@ -0,0 +1,34 @@
+package data
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/kit/pgdb"
+)
+
+const (
+	// StuckTransferInitialized is the kind of the alerts for the transfers not approved or rejected in time
+	StuckTransferInitialized = "initialized"
+	// StuckTransferNotWithdrawn is the kind of the alerts for the signed transfers not withdrawn in time
+	StuckTransferNotWithdrawn = "not_withdrawn"
+	// StuckTransferWithdrawalFailed is the kind of the alerts for the transfers with failed withdrawals
+	StuckTransferWithdrawalFailed = "withdrawal_failed"
+)
+
+type StuckTransferAlertSelector struct {
+	Kind     *string
+	Chain    *string
+	Resolved *bool
+	Notified *bool
+
+	PageCursor uint64
+	PageSize   uint64
+	Sort       pgdb.Sorts
+}
+
+// StuckTransferCandidatesSelector selects transfers stuck in the state of the alert kind since before the
+// provided time, no matter whether the alerts of them are already stored
+type StuckTransferCandidatesSelector struct {
+	Kind   string
+	Before time.Time
+}
@@ -0,0 +1,12 @@
+package data
+
+import "gitlab.com/distributed_lab/kit/pgdb"
+
+type TokenmanagerEventSelector struct {
+	CollectionIndex *string
+	ItemIndex       *string
+
+	PageCursor uint64
+	PageSize   uint64
+	Sort       pgdb.Sorts
+}
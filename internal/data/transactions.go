@@ -8,7 +8,23 @@ import (
 	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
+// TransactionSelector selects transactions ordered by the block height and the index in block,
+// paginated by the position of the last transaction on the previous page
+type TransactionSelector struct {
+	FromHeight *int64
+	ToHeight   *int64
+
+	AfterHeight *int64
+	AfterIndex  *int64
+	PageSize    uint64
+}
+
 func MustDBHash(in string) []byte {
+	// unknown hash is stored as NULL, as the empty one would violate the foreign key to the transactions
+	if in == "" {
+		return nil
+	}
+
 	hashBB, err := hex.DecodeString(strings.ToLower(in)) // bytes.HexBytes.String() does strings.ToUpper(hex.EncodeToString)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to decode hex hash", logan.F{
@@ -155,7 +155,7 @@ func ChainList(w http.ResponseWriter, r *http.Request) {
 }
 
 func itemResourceP(item data.Item) *resources.Item {
-	return &resources.Item{
+	resource := resources.Item{
 		Key: resources.Key{
 			ID:   strconv.FormatInt(item.ID, 10),
 			Type: resources.ITEMS,
@@ -173,4 +173,10 @@ func itemResourceP(item data.Item) *resources.Item {
 			},
 		},
 	}
+
+	if item.DeletedAt.Valid {
+		resource.Attributes.DeletedAt = &item.DeletedAt.Time
+	}
+
+	return &resource
 }
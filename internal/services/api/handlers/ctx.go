@@ -4,9 +4,12 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/rarimo/horizon-svc/internal/config"
 	"github.com/rarimo/horizon-svc/internal/core"
 	"github.com/rarimo/horizon-svc/internal/data"
 	"github.com/rarimo/horizon-svc/internal/proxy"
+	"github.com/rarimo/horizon-svc/internal/services/supervisor"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
@@ -20,6 +23,10 @@ const (
 	coreCtxKey
 	proxyRepoCtxKey
 	chainsQCtxKey
+	deadLettersCtxKey
+	keyValueCtxKey
+	serviceStatesCtxKey
+	nftMetadataIndexerCtxKey
 )
 
 func CtxLog(entry *logan.Entry) func(context.Context) context.Context {
@@ -91,3 +98,43 @@ func CtxCachedStorage(s data.Storage) func(context.Context) context.Context {
 func CachedStorage(r *http.Request) data.Storage {
 	return r.Context().Value(cachedStorageCtxKey).(data.Storage)
 }
+
+func CtxDeadLetters(q *msgs.DeadLetterQueues) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, deadLettersCtxKey, q)
+	}
+}
+
+func DeadLetters(r *http.Request) *msgs.DeadLetterQueues {
+	return r.Context().Value(deadLettersCtxKey).(*msgs.DeadLetterQueues)
+}
+
+func CtxKeyValue(q data.KeyValueQ) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, keyValueCtxKey, q)
+	}
+}
+
+func KeyValue(r *http.Request) data.KeyValueQ {
+	return r.Context().Value(keyValueCtxKey).(data.KeyValueQ)
+}
+
+func CtxServiceStates(p supervisor.StatesProvider) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, serviceStatesCtxKey, p)
+	}
+}
+
+func ServiceStates(r *http.Request) supervisor.StatesProvider {
+	return r.Context().Value(serviceStatesCtxKey).(supervisor.StatesProvider)
+}
+
+func CtxNftMetadataIndexer(cfg *config.NftMetadataIndexerConfig) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, nftMetadataIndexerCtxKey, cfg)
+	}
+}
+
+func NftMetadataIndexer(r *http.Request) *config.NftMetadataIndexerConfig {
+	return r.Context().Value(nftMetadataIndexerCtxKey).(*config.NftMetadataIndexerConfig)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+type deadLetterRequest struct {
+	Queue string
+	ID    string
+}
+
+func newDeadLetterRequest(r *http.Request, withID bool) (*deadLetterRequest, error) {
+	request := deadLetterRequest{
+		Queue: chi.URLParam(r, "queue"),
+		ID:    chi.URLParam(r, "id"),
+	}
+
+	errs := validation.Errors{
+		"queue": validation.Validate(request.Queue, validation.Required),
+	}
+	if withID {
+		errs["id"] = validation.Validate(request.ID, validation.Required)
+	}
+
+	return &request, errs.Filter()
+}
+
+func DeadLetterList(w http.ResponseWriter, r *http.Request) {
+	request, err := newDeadLetterRequest(r, false)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	letters, err := DeadLetters(r).Get(request.Queue).List(r.Context())
+	if err != nil {
+		Log(r).WithError(err).WithField("queue", request.Queue).Error("failed to list dead letters")
+		ape.RenderErr(w, problems.InternalError())
+		return
+	}
+
+	response := resources.DeadLetterListResponse{
+		Data:     make([]resources.DeadLetter, len(letters)),
+		Included: resources.Included{},
+	}
+
+	for i, letter := range letters {
+		response.Data[i] = toDeadLetterResource(letter)
+	}
+
+	ape.Render(w, response)
+}
+
+func DeadLetterByID(w http.ResponseWriter, r *http.Request) {
+	request, err := newDeadLetterRequest(r, true)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	letter, err := DeadLetters(r).Get(request.Queue).Get(r.Context(), request.ID)
+	if err != nil {
+		Log(r).WithError(err).WithFields(logan.F{
+			"queue": request.Queue,
+			"id":    request.ID,
+		}).Error("failed to get dead letter")
+		ape.RenderErr(w, problems.InternalError())
+		return
+	}
+
+	if letter == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	ape.Render(w, resources.DeadLetterResponse{
+		Data:     toDeadLetterResource(*letter),
+		Included: resources.Included{},
+	})
+}
+
+func ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	request, err := newDeadLetterRequest(r, true)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	replayed, err := DeadLetters(r).Get(request.Queue).Replay(r.Context(), request.ID)
+	if err != nil {
+		Log(r).WithError(err).WithFields(logan.F{
+			"queue": request.Queue,
+			"id":    request.ID,
+		}).Error("failed to replay dead letter")
+		ape.RenderErr(w, problems.InternalError())
+		return
+	}
+
+	if !replayed {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func DiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	request, err := newDeadLetterRequest(r, true)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	discarded, err := DeadLetters(r).Get(request.Queue).Discard(r.Context(), request.ID)
+	if err != nil {
+		Log(r).WithError(err).WithFields(logan.F{
+			"queue": request.Queue,
+			"id":    request.ID,
+		}).Error("failed to discard dead letter")
+		ape.RenderErr(w, problems.InternalError())
+		return
+	}
+
+	if !discarded {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toDeadLetterResource(letter msgs.DeadLetter) resources.DeadLetter {
+	return resources.DeadLetter{
+		Key: resources.Key{
+			ID:   letter.ID,
+			Type: resources.DEAD_LETTERS,
+		},
+		Attributes: resources.DeadLetterAttributes{
+			Attempts:       letter.Attempts,
+			Consumer:       letter.Consumer,
+			Error:          letter.Error,
+			FailedAt:       letter.FailedAt,
+			FirstAttemptAt: letter.FirstAttemptAt,
+			Messages:       letter.Messages,
+			Queue:          letter.Queue,
+		},
+	}
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/urlval"
+)
+
+type identityTransferListRequest struct {
+	Type       *resources.OperationType `filter:"type"`
+	Status     *resources.TransferState `filter:"status"`
+	Chain      *string                  `filter:"chain"`
+	IdentityID *string                  `filter:"identity_id"`
+	StateHash  *string                  `filter:"state_hash"`
+	GISTHash   *string                  `filter:"gist_hash"`
+
+	PageCursor uint64     `page:"cursor"`
+	PageLimit  uint64     `page:"limit" default:"15"`
+	Sorts      pgdb.Sorts `url:"sort" default:"-time"`
+}
+
+func IdentityTransferList(w http.ResponseWriter, r *http.Request) {
+	var request identityTransferListRequest
+	if err := urlval.Decode(r.URL.Query(), &request); err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	selector := data.IdentityTransferSelector{
+		Chain:      request.Chain,
+		IdentityID: request.IdentityID,
+		StateHash:  request.StateHash,
+		GISTHash:   request.GISTHash,
+		PageCursor: request.PageCursor,
+		PageSize:   request.PageLimit,
+		Sort:       request.Sorts,
+	}
+
+	if request.Type != nil {
+		selector.Type = request.Type.Intp()
+	}
+
+	if request.Status != nil {
+		selector.Status = request.Status.Intp()
+	}
+
+	transfers, err := Storage(r).IdentityTransferQ().SelectCtx(r.Context(), selector)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to select identity transfers"))
+	}
+
+	response := resources.IdentityTransferListResponse{
+		Data:     make([]resources.IdentityTransfer, 0, len(transfers)),
+		Included: resources.Included{},
+		Links: &resources.Links{
+			Self: fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request)),
+		},
+	}
+
+	if len(transfers) == 0 {
+		ape.Render(w, response)
+		return
+	}
+
+	request.PageCursor = uint64(transfers[len(transfers)-1].ID)
+	response.Links.Next = fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request))
+
+	_ = response.PutMeta(map[string]interface{}{
+		"next_cursor": transfers[len(transfers)-1].ID,
+	})
+
+	for _, transfer := range transfers {
+		response.Data = append(response.Data, mustToIdentityTransferResource(transfer))
+	}
+
+	ape.Render(w, response)
+}
+
+// IdentityTransferByIndex renders the identity transfer with the merkle proof of the operation if it was signed
+func IdentityTransferByIndex(w http.ResponseWriter, r *http.Request) {
+	index, err := hexutil.Decode(chi.URLParam(r, "index"))
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(validation.Errors{
+			"index": err,
+		})...)
+		return
+	}
+
+	transfer, err := Storage(r).IdentityTransferQ().IdentityTransferByOperationIndexCtx(r.Context(), []byte(hexutil.Encode(index)), false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get identity transfer", logan.F{
+			"index": hexutil.Encode(index),
+		}))
+	}
+
+	if transfer == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	renderIdentityTransferWithProof(w, r, *transfer)
+}
+
+// LatestIdentityTransfer renders the latest signed identity transfer to the destination chain,
+// so relayers know which state to submit there
+func LatestIdentityTransfer(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Type *resources.OperationType `filter:"type"`
+	}
+
+	if err := urlval.Decode(r.URL.Query(), &request); err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	chain := chi.URLParam(r, "chain")
+	selector := data.IdentityTransferSelector{
+		Chain:    &chain,
+		Status:   resources.TransferStateSigned.Intp(),
+		PageSize: 1,
+		Sort:     pgdb.Sorts{"-time"},
+	}
+
+	if request.Type != nil {
+		selector.Type = request.Type.Intp()
+	}
+
+	transfers, err := Storage(r).IdentityTransferQ().SelectCtx(r.Context(), selector)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to select latest identity transfer", logan.F{
+			"chain": chain,
+		}))
+	}
+
+	if len(transfers) == 0 {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	renderIdentityTransferWithProof(w, r, transfers[0])
+}
+
+func renderIdentityTransferWithProof(w http.ResponseWriter, r *http.Request, transfer data.IdentityTransfer) {
+	resource := mustToIdentityTransferResource(transfer)
+
+	if transfer.Status == int(rarimocore.OpStatus_SIGNED) {
+		proof, err := Core(r).Rarimocore().GetOperationProof(r.Context(), string(transfer.OperationIndex))
+		if err != nil {
+			panic(errors.Wrap(err, "failed to get operation proof", logan.F{
+				"index": string(transfer.OperationIndex),
+			}))
+		}
+
+		if proof != nil {
+			resource.Attributes.Proof = &resources.OperationProof{
+				Path:      proof.Path,
+				Signature: proof.Signature,
+			}
+		}
+	}
+
+	ape.Render(w, resources.IdentityTransferResponse{
+		Data:     resource,
+		Included: resources.Included{},
+	})
+}
+
+func mustToIdentityTransferResource(transfer data.IdentityTransfer) resources.IdentityTransfer {
+	state, ok := resources.TransferStateFromInt(transfer.Status)
+	if !ok {
+		panic(errors.From(errors.New("invalid identity transfer state"), logan.F{
+			"status": transfer.Status,
+		}))
+	}
+
+	resource := resources.IdentityTransfer{
+		Key: resources.Key{
+			ID:   strconv.FormatInt(transfer.ID, 10),
+			Type: resources.IDENTITY_TRANSFERS,
+		},
+		Attributes: resources.IdentityTransferAttributes{
+			Chain:             transfer.Chain,
+			Contract:          transfer.Contract,
+			CreatedAt:         transfer.RarimoTxTimestamp,
+			GistHash:          nullStringPtr(transfer.GistHash),
+			IdentityId:        nullStringPtr(transfer.IdentityID),
+			OperationIndex:    string(transfer.OperationIndex),
+			ReplacedGistHash:  nullStringPtr(transfer.ReplacedGistHash),
+			ReplacedStateHash: nullStringPtr(transfer.ReplacedStateHash),
+			StateHash:         nullStringPtr(transfer.StateHash),
+			StateRootHash:     nullStringPtr(transfer.StateRootHash),
+			Status:            state,
+			Type:              resources.OperationType(transfer.Type),
+		},
+		Relationships: resources.IdentityTransferRelationships{
+			Tx: &resources.Relation{
+				Data: &resources.Key{
+					ID:   transfer.RarimoTxHash(),
+					Type: resources.TRANSACTIONS,
+				},
+			},
+		},
+	}
+
+	if transfer.StateCreatedAt.Valid {
+		resource.Attributes.StateCreatedAt = &transfer.StateCreatedAt.Time
+	}
+
+	if transfer.StateCreatedAtBlock.Valid {
+		resource.Attributes.StateCreatedAtBlock = &transfer.StateCreatedAtBlock.Int64
+	}
+
+	if transfer.GistCreatedAt.Valid {
+		resource.Attributes.GistCreatedAt = &transfer.GistCreatedAt.Time
+	}
+
+	if transfer.GistCreatedAtBlock.Valid {
+		resource.Attributes.GistCreatedAtBlock = &transfer.GistCreatedAtBlock.Int64
+	}
+
+	return resource
+}
+
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+
+	return &s.String
+}
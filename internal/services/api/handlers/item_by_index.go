@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// ItemByIndex renders the item saved on core with its seeds included
+func ItemByIndex(w http.ResponseWriter, r *http.Request) {
+	index := chi.URLParam(r, "index")
+
+	item, err := CachedStorage(r).ItemQ().ItemByIndexCtx(r.Context(), []byte(index), false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get item", logan.F{
+			"index": index,
+		}))
+	}
+
+	if item == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	seeds, err := Storage(r).SeedQ().SeedsByItemCtx(r.Context(), item.ID, false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get item seeds", logan.F{
+			"index": index,
+		}))
+	}
+
+	response := resources.ItemResponse{
+		Data:     *itemResourceP(*item),
+		Included: resources.Included{},
+	}
+
+	response.Data.Relationships.Seeds = &resources.RelationCollection{
+		Data: make([]resources.Key, 0, len(seeds)),
+	}
+
+	for _, seed := range seeds {
+		resource := toSeedResource(seed)
+		response.Data.Relationships.Seeds.Data = append(response.Data.Relationships.Seeds.Data, resource.Key)
+		response.Included.Add(&resource)
+	}
+
+	ape.Render(w, response)
+}
+
+func toSeedResource(seed data.Seed) resources.Seed {
+	resource := resources.Seed{
+		Key: resources.Key{
+			ID:   seed.Seed,
+			Type: resources.SEEDS,
+		},
+		Attributes: resources.SeedAttributes{
+			CreatedAt: seed.CreatedAt,
+		},
+		Relationships: resources.SeedRelationships{
+			Item: resources.Relation{
+				Data: &resources.Key{
+					ID:   strconv.FormatInt(seed.Item, 10),
+					Type: resources.ITEMS,
+				},
+			},
+		},
+	}
+
+	if seed.CreatedTx.Valid {
+		resource.Attributes.CreatedTx = &seed.CreatedTx.String
+	}
+
+	return resource
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/urlval"
+)
+
+type itemHistoryRequest struct {
+	PageCursor uint64     `page:"cursor"`
+	PageLimit  uint64     `page:"limit" default:"15"`
+	Sorts      pgdb.Sorts `url:"sort" default:"-id"`
+}
+
+func newItemHistoryRequest(r *http.Request) (*itemHistoryRequest, error) {
+	var result itemHistoryRequest
+
+	err := urlval.Decode(r.URL.Query(), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ItemHistory renders the tokenmanager events changed the item, its on-chain data and seeds,
+// they are available even after the item was removed
+func ItemHistory(w http.ResponseWriter, r *http.Request) {
+	request, err := newItemHistoryRequest(r)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	index := chi.URLParam(r, "index")
+
+	events, err := Storage(r).TokenmanagerEventQ().SelectCtx(r.Context(), data.TokenmanagerEventSelector{
+		ItemIndex:  &index,
+		PageCursor: request.PageCursor,
+		PageSize:   request.PageLimit,
+		Sort:       request.Sorts,
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "failed to select item events", logan.F{
+			"index": index,
+		}))
+	}
+
+	response := resources.TokenmanagerEventListResponse{
+		Data:     make([]resources.TokenmanagerEvent, 0, len(events)),
+		Included: resources.Included{},
+		Links: &resources.Links{
+			Self: fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request)),
+		},
+	}
+
+	if len(events) == 0 {
+		ape.Render(w, response)
+		return
+	}
+
+	request.PageCursor = uint64(events[len(events)-1].ID)
+	response.Links.Next = fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request))
+
+	_ = response.PutMeta(map[string]interface{}{
+		"next_cursor": events[len(events)-1].ID,
+	})
+
+	for _, event := range events {
+		response.Data = append(response.Data, toTokenmanagerEventResource(event))
+	}
+
+	ape.Render(w, response)
+}
+
+func toTokenmanagerEventResource(event data.TokenmanagerEvent) resources.TokenmanagerEvent {
+	resource := resources.TokenmanagerEvent{
+		Key: resources.Key{
+			ID:   strconv.FormatInt(event.ID, 10),
+			Type: resources.TOKENMANAGER_EVENTS,
+		},
+		Attributes: resources.TokenmanagerEventAttributes{
+			Chain:           nullStringPtr(event.Chain),
+			CollectionIndex: nullStringPtr(event.CollectionIndex),
+			CreatedAt:       event.CreatedAt,
+			Details:         []byte(event.Details),
+			ItemIndex:       nullStringPtr(event.ItemIndex),
+			RarimoTx:        nullStringPtr(event.RarimoTx),
+			Type:            event.Type,
+		},
+	}
+
+	if event.Height.Valid {
+		resource.Attributes.Height = &event.Height.Int64
+	}
+
+	return resource
+}
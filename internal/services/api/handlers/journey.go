@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// Journey renders all the stages of the cross-chain transfer found by its origin or the source chain deposit
+// transaction hash. If the deposit transaction emitted a few transfers, the first indexed one is rendered.
+func Journey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	transfer, err := journeyTransfer(r, id)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get transfer", logan.F{
+			"id": id,
+		}))
+	}
+
+	if transfer == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	origin, err := hexutil.Decode(transfer.Origin)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode transfer origin", logan.F{
+			"origin": transfer.Origin,
+		}))
+	}
+
+	withdrawal, err := Storage(r).WithdrawalQ().WithdrawalByOriginCtx(r.Context(), origin, false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get withdrawal", logan.F{
+			"origin": transfer.Origin,
+		}))
+	}
+
+	changes, err := Storage(r).TransferStatusChangeQ().SelectByTransferIndexesCtx(r.Context(), transfer.Index)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to select transfer status changes", logan.F{
+			"origin": transfer.Origin,
+		}))
+	}
+
+	stages := journeyStages(ChainsQ(r), *transfer, changes, withdrawal)
+
+	transferResource := mustToTransferResource(*transfer)
+
+	response := resources.JourneyResponse{
+		Data: resources.Journey{
+			Key: resources.Key{
+				ID:   transfer.Origin,
+				Type: resources.JOURNEYS,
+			},
+			Attributes: resources.JourneyAttributes{
+				Origin: transfer.Origin,
+				Stages: stages,
+				State:  journeyState(stages),
+			},
+			Relationships: resources.JourneyRelationships{
+				Transfer: resources.Relation{
+					Data: &transferResource.Key,
+				},
+			},
+		},
+		Included: resources.Included{},
+	}
+
+	response.Included.Add(&transferResource)
+
+	ape.Render(w, response)
+}
+
+// journeyTransfer finds the transfer by the origin first and by the source chain transaction hash otherwise
+func journeyTransfer(r *http.Request, id string) (*data.Transfer, error) {
+	if origin, err := hexutil.Decode(id); err == nil {
+		encoded := hexutil.Encode(origin)
+
+		transfers, err := Storage(r).TransferQ().SelectCtx(r.Context(), data.TransferSelector{
+			Origin:   &encoded,
+			PageSize: 1,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to select transfers by origin")
+		}
+
+		if len(transfers) > 0 {
+			return &transfers[0], nil
+		}
+	}
+
+	transfers, err := Storage(r).TransferQ().SelectCtx(r.Context(), data.TransferSelector{
+		ChainTx:  &id,
+		PageSize: 1,
+		Sort:     pgdb.Sorts{"id"},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select transfers by chain tx")
+	}
+
+	if len(transfers) == 0 {
+		return nil, nil
+	}
+
+	return &transfers[0], nil
+}
+
+func journeyStages(
+	chains data.ChainsQ,
+	transfer data.Transfer,
+	changes []data.TransferStatusChange,
+	withdrawal *data.Withdrawal,
+) []resources.JourneyStage {
+	deposit := newJourneyStage(chains.Get(transfer.FromChain), transfer.FromChain, resources.JourneyStageDeposit)
+	deposit.setTx(string(transfer.Tx), nil)
+	deposit.Status = resources.JourneyStageStatusDone
+
+	rarimo := rarimoChain(chains)
+	rarimoName := "Rarimo"
+	if rarimo != nil {
+		rarimoName = rarimo.Name
+	}
+
+	operation := newJourneyStage(rarimo, rarimoName, resources.JourneyStageOperation)
+	if len(transfer.RarimoTx) > 0 {
+		timestamp := transfer.RarimoTxTimestamp
+		operation.setTx(transfer.RarimoTxHash(), &timestamp)
+		operation.Status = resources.JourneyStageStatusDone
+	}
+
+	approval := newJourneyStage(rarimo, rarimoName, resources.JourneyStageApproval)
+	signing := newJourneyStage(rarimo, rarimoName, resources.JourneyStageSigning)
+
+	for _, change := range changes {
+		var timestamp *time.Time
+		if change.RarimoTxTimestamp.Valid {
+			timestamp = &change.RarimoTxTimestamp.Time
+		}
+
+		switch rarimocore.OpStatus(change.Status) {
+		case rarimocore.OpStatus_APPROVED, rarimocore.OpStatus_NOT_APPROVED:
+			approval.setTx(bytes.HexBytes(change.RarimoTransaction).String(), timestamp)
+		case rarimocore.OpStatus_SIGNED:
+			signing.setTx(bytes.HexBytes(change.RarimoTransaction).String(), timestamp)
+		}
+	}
+
+	// the status is taken from the transfer, as the status changes of the transfers indexed before they were
+	// recorded are missing
+	switch rarimocore.OpStatus(transfer.Status) {
+	case rarimocore.OpStatus_APPROVED:
+		approval.Status = resources.JourneyStageStatusDone
+	case rarimocore.OpStatus_NOT_APPROVED:
+		approval.Status = resources.JourneyStageStatusFailed
+		signing.Status = resources.JourneyStageStatusSkipped
+	case rarimocore.OpStatus_SIGNED:
+		approval.Status = resources.JourneyStageStatusDone
+		signing.Status = resources.JourneyStageStatusDone
+	}
+
+	withdraw := newJourneyStage(chains.Get(transfer.ToChain), transfer.ToChain, resources.JourneyStageWithdrawal)
+	switch {
+	case signing.Status == resources.JourneyStageStatusSkipped:
+		withdraw.Status = resources.JourneyStageStatusSkipped
+	case withdrawal != nil:
+		timestamp := withdrawal.CreatedAt
+		withdraw.setTx(withdrawal.Hash.String, &timestamp)
+		withdraw.Status = resources.JourneyStageStatusDone
+		if withdrawal.Success.Valid && !withdrawal.Success.Bool {
+			withdraw.Status = resources.JourneyStageStatusFailed
+		}
+	}
+
+	return []resources.JourneyStage{
+		deposit.JourneyStage,
+		operation.JourneyStage,
+		approval.JourneyStage,
+		signing.JourneyStage,
+		withdraw.JourneyStage,
+	}
+}
+
+// journeyState computes the overall state of the transfer as the state of the last finished stage
+func journeyState(stages []resources.JourneyStage) resources.JourneyState {
+	state := resources.JourneyStateDeposited
+
+	for _, stage := range stages {
+		switch stage.Status {
+		case resources.JourneyStageStatusFailed:
+			return resources.JourneyStateFailed
+		case resources.JourneyStageStatusDone:
+			state = journeyStageStates[stage.Name]
+		}
+	}
+
+	return state
+}
+
+var journeyStageStates = map[resources.JourneyStageName]resources.JourneyState{
+	resources.JourneyStageDeposit:    resources.JourneyStateDeposited,
+	resources.JourneyStageOperation:  resources.JourneyStateOnRarimo,
+	resources.JourneyStageApproval:   resources.JourneyStateApproved,
+	resources.JourneyStageSigning:    resources.JourneyStateSigned,
+	resources.JourneyStageWithdrawal: resources.JourneyStateWithdrawn,
+}
+
+type journeyStage struct {
+	resources.JourneyStage
+	chain *data.Chain
+}
+
+func newJourneyStage(chain *data.Chain, chainName string, name resources.JourneyStageName) *journeyStage {
+	return &journeyStage{
+		JourneyStage: resources.JourneyStage{
+			Chain:  chainName,
+			Name:   name,
+			Status: resources.JourneyStageStatusPending,
+		},
+		chain: chain,
+	}
+}
+
+func (s *journeyStage) setTx(hash string, timestamp *time.Time) {
+	if hash == "" {
+		return
+	}
+
+	s.Hash = &hash
+	s.Timestamp = timestamp
+
+	if s.chain != nil {
+		s.ExplorerUrl = s.chain.ExplorerTxURL(hash)
+	}
+}
+
+// rarimoChain returns the rarimo chain from the configured ones, if any
+func rarimoChain(chains data.ChainsQ) *data.Chain {
+	for _, chain := range chains.List() {
+		if chain.Type == tokenmanager.NetworkType_Rarimo || chain.Type == tokenmanager.NetworkType_Other {
+			chain := chain
+			return &chain
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/mem"
+	"github.com/rarimo/horizon-svc/resources"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJourneyStages(t *testing.T) {
+	chains := mem.NewChainsQ([]data.Chain{
+		{
+			ID:          1,
+			Name:        "Goerli",
+			Type:        tokenmanager.NetworkType_EVM,
+			ChainParams: json.RawMessage(`{"explorer_url":"https://goerli.etherscan.io"}`),
+		},
+		{ID: 2, Name: "Rarimo", Type: tokenmanager.NetworkType_Rarimo},
+	})
+
+	signedAt := time.Unix(1700000000, 0).UTC()
+	changes := []data.TransferStatusChange{
+		{Status: int(rarimocore.OpStatus_APPROVED), RarimoTransaction: []byte{0xaa}},
+		{
+			Status:            int(rarimocore.OpStatus_SIGNED),
+			RarimoTransaction: []byte{0xbb},
+			RarimoTxTimestamp: sql.NullTime{Time: signedAt, Valid: true},
+		},
+	}
+
+	const (
+		pending = resources.JourneyStageStatusPending
+		done    = resources.JourneyStageStatusDone
+		failed  = resources.JourneyStageStatusFailed
+		skipped = resources.JourneyStageStatusSkipped
+	)
+
+	cases := []struct {
+		name       string
+		status     rarimocore.OpStatus
+		rarimoTx   []byte
+		withdrawal *data.Withdrawal
+		expected   []resources.JourneyStageStatus
+	}{
+		{
+			name:     "deposited",
+			status:   rarimocore.OpStatus_INITIALIZED,
+			expected: []resources.JourneyStageStatus{done, pending, pending, pending, pending},
+		},
+		{
+			name:     "on rarimo",
+			status:   rarimocore.OpStatus_INITIALIZED,
+			rarimoTx: []byte{0x01},
+			expected: []resources.JourneyStageStatus{done, done, pending, pending, pending},
+		},
+		{
+			name:     "approved",
+			status:   rarimocore.OpStatus_APPROVED,
+			rarimoTx: []byte{0x01},
+			expected: []resources.JourneyStageStatus{done, done, done, pending, pending},
+		},
+		{
+			name:     "not approved",
+			status:   rarimocore.OpStatus_NOT_APPROVED,
+			rarimoTx: []byte{0x01},
+			expected: []resources.JourneyStageStatus{done, done, failed, skipped, skipped},
+		},
+		{
+			name:     "signed",
+			status:   rarimocore.OpStatus_SIGNED,
+			rarimoTx: []byte{0x01},
+			expected: []resources.JourneyStageStatus{done, done, done, done, pending},
+		},
+		{
+			name:     "withdrawn",
+			status:   rarimocore.OpStatus_SIGNED,
+			rarimoTx: []byte{0x01},
+			withdrawal: &data.Withdrawal{
+				Hash:    sql.NullString{String: "0xwithdrawal", Valid: true},
+				Success: sql.NullBool{Bool: true, Valid: true},
+			},
+			expected: []resources.JourneyStageStatus{done, done, done, done, done},
+		},
+		{
+			name:     "withdrawal failed",
+			status:   rarimocore.OpStatus_SIGNED,
+			rarimoTx: []byte{0x01},
+			withdrawal: &data.Withdrawal{
+				Hash:    sql.NullString{String: "0xwithdrawal", Valid: true},
+				Success: sql.NullBool{Bool: false, Valid: true},
+			},
+			expected: []resources.JourneyStageStatus{done, done, done, done, failed},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stages := journeyStages(chains, data.Transfer{
+				Tx:        []byte("0xdeposit"),
+				FromChain: "Goerli",
+				ToChain:   "Goerli",
+				Status:    int(c.status),
+				RarimoTx:  c.rarimoTx,
+			}, changes, c.withdrawal)
+
+			statuses := make([]resources.JourneyStageStatus, len(stages))
+			for i, stage := range stages {
+				statuses[i] = stage.Status
+			}
+
+			assert.Equal(t, c.expected, statuses)
+		})
+	}
+
+	stages := journeyStages(chains, data.Transfer{
+		Tx:        []byte("0xdeposit"),
+		FromChain: "Goerli",
+		ToChain:   "Goerli",
+		Status:    int(rarimocore.OpStatus_SIGNED),
+	}, changes, nil)
+
+	if assert.NotNil(t, stages[0].ExplorerUrl) {
+		assert.Equal(t, "https://goerli.etherscan.io/tx/0xdeposit", *stages[0].ExplorerUrl)
+	}
+
+	assert.Equal(t, "Rarimo", stages[3].Chain)
+	if assert.NotNil(t, stages[3].Hash) && assert.NotNil(t, stages[3].Timestamp) {
+		assert.Equal(t, "BB", *stages[3].Hash)
+		assert.Equal(t, signedAt, *stages[3].Timestamp)
+	}
+}
+
+func TestJourneyState(t *testing.T) {
+	stages := func(statuses ...resources.JourneyStageStatus) []resources.JourneyStage {
+		names := []resources.JourneyStageName{
+			resources.JourneyStageDeposit,
+			resources.JourneyStageOperation,
+			resources.JourneyStageApproval,
+			resources.JourneyStageSigning,
+			resources.JourneyStageWithdrawal,
+		}
+
+		result := make([]resources.JourneyStage, len(statuses))
+		for i, status := range statuses {
+			result[i] = resources.JourneyStage{Name: names[i], Status: status}
+		}
+
+		return result
+	}
+
+	const (
+		pending = resources.JourneyStageStatusPending
+		done    = resources.JourneyStageStatusDone
+		failed  = resources.JourneyStageStatusFailed
+		skipped = resources.JourneyStageStatusSkipped
+	)
+
+	cases := []struct {
+		name     string
+		stages   []resources.JourneyStage
+		expected resources.JourneyState
+	}{
+		{"deposited", stages(done, pending, pending, pending, pending), resources.JourneyStateDeposited},
+		{"on rarimo", stages(done, done, pending, pending, pending), resources.JourneyStateOnRarimo},
+		{"approved", stages(done, done, done, pending, pending), resources.JourneyStateApproved},
+		{"signed", stages(done, done, done, done, pending), resources.JourneyStateSigned},
+		{"withdrawn", stages(done, done, done, done, done), resources.JourneyStateWithdrawn},
+		{"not approved", stages(done, done, failed, skipped, skipped), resources.JourneyStateFailed},
+		{"withdrawal failed", stages(done, done, done, done, failed), resources.JourneyStateFailed},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, journeyState(c.stages))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -9,7 +11,6 @@ import (
 	"github.com/rarimo/horizon-svc/internal/data"
 	"github.com/rarimo/horizon-svc/internal/proxy/types"
 	"github.com/rarimo/horizon-svc/resources"
-	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
 	"gitlab.com/distributed_lab/ape"
 	"gitlab.com/distributed_lab/ape/problems"
 	"gitlab.com/distributed_lab/logan/v3"
@@ -19,19 +20,29 @@ type nftMetadataRequest struct {
 	Chain     string `url:"chain"`
 	ItemIndex string `url:"index"`
 	TokenID   string `url:"token_id"`
+	// Refresh fetches the metadata from the chain instead of serving the stored one
+	Refresh bool `url:"refresh"`
 }
 
 func newNftMetadataRequest(r *http.Request) (*nftMetadataRequest, error) {
-	var request nftMetadataRequest
+	var (
+		request    nftMetadataRequest
+		refreshErr error
+	)
 
 	request.Chain = chi.URLParam(r, "chain")
 	request.ItemIndex = chi.URLParam(r, "index")
 	request.TokenID = chi.URLParam(r, "token_id")
 
+	if raw := r.URL.Query().Get("refresh"); raw != "" {
+		request.Refresh, refreshErr = strconv.ParseBool(raw)
+	}
+
 	return &request, validation.Errors{
 		"chain":    validation.Validate(request.Chain, validation.Required),
 		"index":    validation.Validate(request.ItemIndex, validation.Required),
 		"token_id": validation.Validate(request.TokenID, validation.Required),
+		"refresh":  refreshErr,
 	}.Filter()
 }
 
@@ -43,6 +54,25 @@ func NftMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	stored, err := storedNftMetadata(r, req)
+	if err != nil {
+		Log(r).WithError(err).Error("failed to get stored metadata")
+		ape.RenderErr(w, problems.InternalError())
+		return
+	}
+
+	if !req.Refresh && stored != nil && stored.Metadata.Valid {
+		metadata, err := stored.NftMetadata()
+		if err != nil {
+			Log(r).WithError(err).Error("failed to decode stored metadata")
+			ape.RenderErr(w, problems.InternalError())
+			return
+		}
+
+		ape.Render(w, newNftMetadataResponse(req.TokenID, metadata, &stored.FetchedAt.Time))
+		return
+	}
+
 	item, err := Core(r).Tokenmanager().GetItem(r.Context(), req.ItemIndex)
 	if err != nil {
 		Log(r).WithError(err).Error("failed to get item from token manager")
@@ -82,7 +112,7 @@ func NftMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !isNftTokenType(collectionData.TokenType) {
+	if !data.IsNftTokenType(collectionData.TokenType) {
 		ape.RenderErr(w, problems.BadRequest(validation.Errors{
 			"index": errors.Wrap(err, "item is not of NFT type"),
 		})...)
@@ -112,16 +142,69 @@ func NftMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ape.Render(w, newNftMetadataResponse(req.TokenID, metadata))
+	// fetched metadata replaces the stored one, so the indexer refreshes it only after the refresh period
+	if stored != nil {
+		if err := stored.SetFetched(*metadata, NftMetadataIndexer(r).RefreshPeriod); err != nil {
+			Log(r).WithError(err).Error("failed to set fetched metadata")
+		} else if err := Storage(r).ItemMetadatumQ().UpsertCtx(r.Context(), stored); err != nil {
+			Log(r).WithError(err).Error("failed to store fetched metadata")
+		}
+	}
+
+	fetchedAt := time.Now().UTC()
+	ape.Render(w, newNftMetadataResponse(req.TokenID, metadata, &fetchedAt))
 }
 
-func newNftMetadataModel(tokenId string, value *data.NftMetadata) *resources.NftMetadata {
+// storedNftMetadata returns the stored metadata entry of the item on the chain, the new one if the metadata of the
+// item is not stored yet and nil if the item or chain is unknown or the token id is not the one of the item there
+func storedNftMetadata(r *http.Request, req *nftMetadataRequest) (*data.ItemMetadatum, error) {
+	chain := ChainsQ(r).Get(req.Chain)
+	if chain == nil {
+		return nil, nil
+	}
+
+	item, err := CachedStorage(r).ItemQ().ItemByIndexCtx(r.Context(), []byte(req.ItemIndex), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item")
+	}
+
+	if item == nil {
+		return nil, nil
+	}
+
+	mapping, err := CachedStorage(r).ItemChainMappingQ().ItemChainMappingByItemNetworkCtx(r.Context(), item.ID, chain.ID, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item chain mapping")
+	}
+
+	// metadata is stored for the token of the item on the chain, the other ones are fetched live
+	if mapping == nil || string(mapping.TokenID) != req.TokenID {
+		return nil, nil
+	}
+
+	stored, err := Storage(r).ItemMetadatumQ().ItemMetadatumByItemNetworkCtx(r.Context(), item.ID, chain.ID, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item metadata")
+	}
+
+	if stored == nil {
+		stored = &data.ItemMetadatum{
+			Item:    item.ID,
+			Network: chain.ID,
+		}
+	}
+
+	return stored, nil
+}
+
+func newNftMetadataModel(tokenId string, value *data.NftMetadata, fetchedAt *time.Time) *resources.NftMetadata {
 	result := resources.NftMetadata{
 		Key: resources.NewStringKey(tokenId, resources.NFTS_METADATA),
 		Attributes: resources.NftMetadataAttributes{
 			Name:       value.Name,
 			ImageUrl:   value.ImageURL,
 			Attributes: make([]resources.NftMetadataAttribute, 0, len(value.Attributes)),
+			FetchedAt:  fetchedAt,
 		},
 	}
 
@@ -143,20 +226,8 @@ func newNftMetadataModel(tokenId string, value *data.NftMetadata) *resources.Nft
 	return &result
 }
 
-func newNftMetadataResponse(tokenId string, model *data.NftMetadata) resources.NftMetadataResponse {
+func newNftMetadataResponse(tokenId string, model *data.NftMetadata, fetchedAt *time.Time) resources.NftMetadataResponse {
 	return resources.NftMetadataResponse{
-		Data: *newNftMetadataModel(tokenId, model),
+		Data: *newNftMetadataModel(tokenId, model, fetchedAt),
 	}
 }
-
-var nftTypes = map[tokenmanager.Type]interface{}{
-	tokenmanager.Type_ERC721:       nil,
-	tokenmanager.Type_ERC1155:      nil,
-	tokenmanager.Type_NEAR_NFT:     nil,
-	tokenmanager.Type_METAPLEX_NFT: nil,
-}
-
-func isNftTokenType(tokenType tokenmanager.Type) bool {
-	_, ok := nftTypes[tokenType]
-	return ok
-}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+type operationByIndexRequest struct {
+	Index string
+}
+
+func newOperationByIndexRequest(r *http.Request) (*operationByIndexRequest, error) {
+	index, err := hexutil.Decode(chi.URLParam(r, "index"))
+	if err != nil {
+		return nil, validation.Errors{
+			"index": err,
+		}
+	}
+
+	return &operationByIndexRequest{
+		Index: hexutil.Encode(index),
+	}, nil
+}
+
+// OperationByIndex renders the operation with its votes included and the confirmation transaction,
+// if the operation was already signed
+func OperationByIndex(w http.ResponseWriter, r *http.Request) {
+	request, err := newOperationByIndexRequest(r)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	operation, err := Storage(r).OperationQ().OperationByIndexCtx(r.Context(), []byte(request.Index), false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get operation", logan.F{
+			"index": request.Index,
+		}))
+	}
+
+	if operation == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	votes, err := CachedStorage(r).VoteQ().VotesByTransferIndexCtx(r.Context(), operation.Index, false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get votes", logan.F{
+			"index": request.Index,
+		}))
+	}
+
+	confirmations, err := CachedStorage(r).ConfirmationQ().ConfirmationsByTransferIndexCtx(r.Context(), operation.Index, false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get confirmations", logan.F{
+			"index": request.Index,
+		}))
+	}
+
+	response := resources.OperationResponse{
+		Data:     mustToOperationResource(*operation),
+		Included: resources.Included{},
+	}
+
+	response.Data.Relationships.Votes = &resources.RelationCollection{
+		Data: make([]resources.Key, 0, len(votes)),
+	}
+
+	for _, vote := range votes {
+		resource := toVoteResource(vote)
+		response.Data.Relationships.Votes.Data = append(response.Data.Relationships.Votes.Data, resource.Key)
+		response.Included.Add(&resource)
+	}
+
+	if len(confirmations) != 0 {
+		response.Data.Relationships.Confirmation = &resources.Relation{
+			Data: &resources.Key{
+				ID:   bytes.HexBytes(confirmations[0].RarimoTransaction).String(),
+				Type: resources.TRANSACTIONS,
+			},
+		}
+	}
+
+	ape.Render(w, response)
+}
+
+func mustToOperationResource(operation data.Operation) resources.Operation {
+	state, ok := resources.TransferStateFromInt(operation.Status)
+	if !ok {
+		panic(errors.From(errors.New("invalid operation state"), logan.F{
+			"status": operation.Status,
+		}))
+	}
+
+	resource := resources.Operation{
+		Key: resources.Key{
+			ID:   strconv.FormatInt(operation.ID, 10),
+			Type: resources.OPERATIONS,
+		},
+		Attributes: resources.OperationAttributes{
+			CreatedAt: operation.RarimoTxTimestamp,
+			Details:   []byte(operation.Details),
+			Index:     string(operation.Index),
+			Status:    state,
+			Type:      resources.OperationType(operation.Type),
+		},
+		Relationships: resources.OperationRelationships{
+			Tx: &resources.Relation{
+				Data: &resources.Key{
+					ID:   operation.RarimoTxHash(),
+					Type: resources.TRANSACTIONS,
+				},
+			},
+		},
+	}
+
+	if operation.Creator.Valid {
+		resource.Relationships.Creator = &resources.Relation{
+			Data: &resources.Key{
+				ID:   operation.Creator.String,
+				Type: resources.ACCOUNTS,
+			},
+		}
+	}
+
+	return resource
+}
+
+func toVoteResource(vote data.Vote) resources.Vote {
+	return resources.Vote{
+		Key: resources.Key{
+			ID:   strconv.FormatInt(vote.ID, 10),
+			Type: resources.VOTES,
+		},
+		Attributes: resources.VoteAttributes{
+			Choice:    rarimocore.VoteType(vote.Choice).String(),
+			CreatedAt: vote.CreatedAt,
+		},
+		Relationships: resources.VoteRelationships{
+			Tx: &resources.Relation{
+				Data: &resources.Key{
+					ID:   bytes.HexBytes(vote.RarimoTransaction).String(),
+					Type: resources.TRANSACTIONS,
+				},
+			},
+		},
+	}
+}
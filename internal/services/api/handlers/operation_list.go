@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/urlval"
+)
+
+type operationListRequest struct {
+	Type    *resources.OperationType `filter:"type"`
+	Status  *resources.TransferState `filter:"status"`
+	Creator *string                  `filter:"creator"`
+	Before  *int64                   `filter:"created_before"`
+	After   *int64                   `filter:"created_after"`
+
+	PageCursor uint64     `page:"cursor"`
+	PageLimit  uint64     `page:"limit" default:"15"`
+	Sorts      pgdb.Sorts `url:"sort" default:"-time"`
+}
+
+func newOperationListRequest(r *http.Request) (*operationListRequest, error) {
+	var result operationListRequest
+
+	err := urlval.Decode(r.URL.Query(), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func OperationList(w http.ResponseWriter, r *http.Request) {
+	request, err := newOperationListRequest(r)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	operations, err := Storage(r).OperationQ().SelectCtx(r.Context(), createOperationSelector(*request))
+	if err != nil {
+		panic(errors.Wrap(err, "failed to select operations"))
+	}
+
+	response := resources.OperationListResponse{
+		Data:     make([]resources.Operation, 0, len(operations)),
+		Included: resources.Included{},
+		Links: &resources.Links{
+			Self: fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request)),
+		},
+	}
+
+	if len(operations) == 0 {
+		ape.Render(w, response)
+		return
+	}
+
+	request.PageCursor = uint64(operations[len(operations)-1].ID)
+	response.Links.Next = fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request))
+
+	_ = response.PutMeta(map[string]interface{}{
+		"next_cursor": operations[len(operations)-1].ID,
+	})
+
+	for _, operation := range operations {
+		response.Data = append(response.Data, mustToOperationResource(operation))
+	}
+
+	ape.Render(w, response)
+}
+
+func createOperationSelector(request operationListRequest) data.OperationSelector {
+	sel := data.OperationSelector{
+		Creator:    request.Creator,
+		PageCursor: request.PageCursor,
+		PageSize:   request.PageLimit,
+		Sort:       request.Sorts,
+	}
+
+	if request.Type != nil {
+		sel.Type = request.Type.Intp()
+	}
+
+	if request.Status != nil {
+		sel.Status = request.Status.Intp()
+	}
+
+	if request.Before != nil {
+		before := time.Unix(*request.Before, 0)
+		sel.Before = &before
+	}
+
+	if request.After != nil {
+		after := time.Unix(*request.After, 0)
+		sel.After = &after
+	}
+
+	return sel
+}
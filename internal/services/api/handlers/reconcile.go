@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// ReconcileReport renders the latest report of the reconciler
+func ReconcileReport(w http.ResponseWriter, r *http.Request) {
+	report := mustReconcileReport(r)
+	if report == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	ape.Render(w, resources.ReconcileReportResponse{
+		Data:     toReconcileReportResource(*report),
+		Included: resources.Included{},
+	})
+}
+
+// ReconcileMetrics exposes the latest report of the reconciler in the prometheus format, the metrics are absent
+// until the first reconciliation finishes
+func ReconcileMetrics(w http.ResponseWriter, r *http.Request) {
+	registry := prometheus.NewRegistry()
+
+	if report := mustReconcileReport(r); report != nil {
+		differences := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "horizon_reconcile_differences",
+			Help: "Number of the differences with the core state found by the last reconciliation",
+		}, []string{"kind"})
+		repaired := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "horizon_reconcile_repaired",
+			Help: "Number of the differences repaired by the last reconciliation",
+		})
+		finished := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "horizon_reconcile_last_finished_timestamp_seconds",
+			Help: "Unix time the last reconciliation finished at",
+		})
+		duration := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "horizon_reconcile_last_duration_seconds",
+			Help: "Duration of the last reconciliation",
+		})
+
+		registry.MustRegister(differences, repaired, finished, duration)
+
+		for _, kind := range data.ReconcileDifferenceKinds {
+			differences.WithLabelValues(kind).Set(float64(report.Counts[kind]))
+		}
+
+		repaired.Set(float64(report.Repaired))
+		finished.Set(float64(report.FinishedAt.Unix()))
+		duration.Set(report.FinishedAt.Sub(report.StartedAt).Seconds())
+	}
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+func mustReconcileReport(r *http.Request) *data.ReconcileReport {
+	kv, err := KeyValue(r).Get(r.Context(), data.ReconcileReportKey)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get reconcile report"))
+	}
+
+	if kv == nil {
+		return nil
+	}
+
+	var report data.ReconcileReport
+	if err := json.Unmarshal([]byte(kv.Value), &report); err != nil {
+		panic(errors.Wrap(err, "failed to unmarshal reconcile report"))
+	}
+
+	return &report
+}
+
+func toReconcileReportResource(report data.ReconcileReport) resources.ReconcileReport {
+	resource := resources.ReconcileReport{
+		Key: resources.Key{
+			ID:   strconv.FormatInt(report.FinishedAt.Unix(), 10),
+			Type: resources.RECONCILE_REPORTS,
+		},
+		Attributes: resources.ReconcileReportAttributes{
+			Counts:      report.Counts,
+			Differences: make([]resources.ReconcileDifference, len(report.Differences)),
+			FinishedAt:  report.FinishedAt,
+			Repair:      report.Repair,
+			Repaired:    report.Repaired,
+			StartedAt:   report.StartedAt,
+			Truncated:   report.Truncated,
+		},
+	}
+
+	for i, diff := range report.Differences {
+		resource.Attributes.Differences[i] = resources.ReconcileDifference{
+			Chain:      optionalString(diff.Chain),
+			Collection: optionalString(diff.Collection),
+			Item:       optionalString(diff.Item),
+			Kind:       diff.Kind,
+			Repaired:   diff.Repaired,
+		}
+	}
+
+	return resource
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
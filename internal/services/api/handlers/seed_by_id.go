@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// SeedByID renders the seed with the item it is linked to included, so the item can be found by the seed
+// (e.g. derived from the Solana PDA) without requesting core
+func SeedByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "seed")
+
+	seed, err := Storage(r).SeedQ().SeedBySeedCtx(r.Context(), id, false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get seed", logan.F{
+			"seed": id,
+		}))
+	}
+
+	if seed == nil {
+		ape.RenderErr(w, problems.NotFound())
+		return
+	}
+
+	item, err := CachedStorage(r).ItemQ().ItemByIDCtx(r.Context(), seed.Item, false)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get item", logan.F{
+			"seed": id,
+			"item": seed.Item,
+		}))
+	}
+
+	response := resources.SeedResponse{
+		Data:     toSeedResource(*seed),
+		Included: resources.Included{},
+	}
+
+	if item != nil {
+		response.Included.Add(itemResourceP(*item))
+	}
+
+	ape.Render(w, response)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+)
+
+// ServiceStateList renders the states of the services run by the supervisor in the same process as the API
+func ServiceStateList(w http.ResponseWriter, r *http.Request) {
+	states := ServiceStates(r).States()
+
+	response := resources.ServiceStateListResponse{
+		Data:     make([]resources.ServiceState, len(states)),
+		Included: resources.Included{},
+		Links: &resources.Links{
+			Self: r.URL.Path,
+		},
+	}
+
+	for i, state := range states {
+		response.Data[i] = resources.ServiceState{
+			Key: resources.Key{
+				ID:   state.Name,
+				Type: resources.SERVICE_STATES,
+			},
+			Attributes: resources.ServiceStateAttributes{
+				CrashedAt: state.CrashedAt,
+				LastError: optionalString(state.LastError),
+				Restarts:  state.Restarts,
+				StartedAt: state.StartedAt,
+				Status:    string(state.Status),
+			},
+		}
+	}
+
+	ape.Render(w, response)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/resources"
+	"gitlab.com/distributed_lab/ape"
+	"gitlab.com/distributed_lab/ape/problems"
+	"gitlab.com/distributed_lab/kit/pgdb"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/urlval"
+)
+
+type stuckTransferAlertListRequest struct {
+	Kind     *string `filter:"kind"`
+	Chain    *string `filter:"chain"`
+	Resolved *bool   `filter:"resolved"`
+
+	PageCursor uint64     `page:"cursor"`
+	PageLimit  uint64     `page:"limit" default:"15"`
+	Sorts      pgdb.Sorts `url:"sort" default:"-id"`
+}
+
+func newStuckTransferAlertListRequest(r *http.Request) (*stuckTransferAlertListRequest, error) {
+	var result stuckTransferAlertListRequest
+
+	err := urlval.Decode(r.URL.Query(), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, validation.Errors{
+		"filter[kind]": validation.Validate(result.Kind, validation.In(
+			data.StuckTransferInitialized,
+			data.StuckTransferNotWithdrawn,
+			data.StuckTransferWithdrawalFailed,
+		)),
+	}.Filter()
+}
+
+// StuckTransferAlertList renders the findings of the stuck transfers detector
+func StuckTransferAlertList(w http.ResponseWriter, r *http.Request) {
+	request, err := newStuckTransferAlertListRequest(r)
+	if err != nil {
+		ape.RenderErr(w, problems.BadRequest(err)...)
+		return
+	}
+
+	alerts, err := Storage(r).StuckTransferAlertQ().SelectCtx(r.Context(), data.StuckTransferAlertSelector{
+		Kind:       request.Kind,
+		Chain:      request.Chain,
+		Resolved:   request.Resolved,
+		PageCursor: request.PageCursor,
+		PageSize:   request.PageLimit,
+		Sort:       request.Sorts,
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "failed to select stuck transfer alerts"))
+	}
+
+	response := resources.StuckTransferAlertListResponse{
+		Data:     make([]resources.StuckTransferAlert, 0, len(alerts)),
+		Included: resources.Included{},
+		Links: &resources.Links{
+			Self: fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request)),
+		},
+	}
+
+	if len(alerts) == 0 {
+		ape.Render(w, response)
+		return
+	}
+
+	request.PageCursor = uint64(alerts[len(alerts)-1].ID)
+	response.Links.Next = fmt.Sprintf("%s?%s", r.URL.Path, urlval.MustEncode(request))
+
+	_ = response.PutMeta(map[string]interface{}{
+		"next_cursor": alerts[len(alerts)-1].ID,
+	})
+
+	for _, alert := range alerts {
+		response.Data = append(response.Data, toStuckTransferAlertResource(alert))
+	}
+
+	ape.Render(w, response)
+}
+
+func toStuckTransferAlertResource(alert data.StuckTransferAlert) resources.StuckTransferAlert {
+	resource := resources.StuckTransferAlert{
+		Key: resources.Key{
+			ID:   strconv.FormatInt(alert.ID, 10),
+			Type: resources.STUCK_TRANSFER_ALERTS,
+		},
+		Attributes: resources.StuckTransferAlertAttributes{
+			Chain:         alert.Chain,
+			DetectedAt:    alert.DetectedAt,
+			Kind:          alert.Kind,
+			Origin:        alert.Origin,
+			Since:         alert.Since,
+			Status:        resources.TransferState(alert.Status),
+			TransferIndex: string(alert.TransferIndex),
+		},
+	}
+
+	if alert.ResolvedAt.Valid {
+		resource.Attributes.ResolvedAt = &alert.ResolvedAt.Time
+	}
+
+	return resource
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-chi/chi"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/rarimo/horizon-svc/resources"
+	"github.com/tendermint/tendermint/libs/bytes"
 	"gitlab.com/distributed_lab/ape"
 	"gitlab.com/distributed_lab/ape/problems"
 	"gitlab.com/distributed_lab/logan/v3/errors"
@@ -49,12 +50,49 @@ func TransferByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	timelines, err := transferTimelines(r, *transfer)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get transfer timeline"))
+	}
+
+	resource := mustToTransferResource(*transfer)
+	resource.Attributes.Timeline = timelines[string(transfer.Index)]
+
 	ape.Render(w, resources.TransferResponse{
-		Data:     mustToTransferResource(*transfer),
+		Data:     resource,
 		Included: resources.Included{},
 	})
 }
 
+// transferTimelines returns the status changes of the transfers by their indexes
+func transferTimelines(r *http.Request, transfers ...data.Transfer) (map[string][]resources.TransferStatusChange, error) {
+	indexes := make([][]byte, len(transfers))
+	for i, transfer := range transfers {
+		indexes[i] = transfer.Index
+	}
+
+	changes, err := Storage(r).TransferStatusChangeQ().SelectByTransferIndexesCtx(r.Context(), indexes...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select transfer status changes")
+	}
+
+	timelines := make(map[string][]resources.TransferStatusChange, len(transfers))
+	for _, change := range changes {
+		entry := resources.TransferStatusChange{
+			RarimoTx: bytes.HexBytes(change.RarimoTransaction).String(),
+			Status:   resources.TransferState(change.Status),
+		}
+
+		if change.RarimoTxTimestamp.Valid {
+			entry.Timestamp = &change.RarimoTxTimestamp.Time
+		}
+
+		timelines[string(change.TransferIndex)] = append(timelines[string(change.TransferIndex)], entry)
+	}
+
+	return timelines, nil
+}
+
 func mustToTransferResource(transfer data.Transfer) resources.Transfer {
 	amount := transfer.Amount.String()
 	bundleData := string(transfer.BundleData)
@@ -76,8 +76,15 @@ func TransferList(w http.ResponseWriter, r *http.Request) {
 		"next_cursor": transfers[len(transfers)-1].ID,
 	})
 
+	timelines, err := transferTimelines(r, transfers...)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get transfers timelines"))
+	}
+
 	for _, transfer := range transfers {
-		response.Data = append(response.Data, mustToTransferResource(transfer))
+		resource := mustToTransferResource(transfer)
+		resource.Attributes.Timeline = timelines[string(transfer.Index)]
+		response.Data = append(response.Data, resource)
 	}
 
 	ape.Render(w, response)
@@ -4,14 +4,17 @@ import (
 	"context"
 	"time"
 
+	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"github.com/rarimo/horizon-svc/pkg/txbuild"
 
 	"github.com/rarimo/horizon-svc/internal/data/cachedpg"
+	"github.com/rarimo/horizon-svc/internal/data/redis"
 
 	"github.com/go-chi/chi"
 	"github.com/rarimo/horizon-svc/internal/config"
 	"github.com/rarimo/horizon-svc/internal/proxy"
 	"github.com/rarimo/horizon-svc/internal/services/api/handlers"
+	"github.com/rarimo/horizon-svc/internal/services/supervisor"
 	"gitlab.com/distributed_lab/ape"
 	"gitlab.com/distributed_lab/logan/v3"
 )
@@ -23,6 +26,8 @@ func Run(ctx context.Context, cfg config.Config) {
 	ape.DefaultMiddlewares(r, cfg.Log(), slowRequestDurationThreshold)
 
 	storage := cfg.NewStorage()
+	// states of the services are known only if the API is run by the supervisor
+	states := supervisor.StatesFromContext(ctx)
 
 	r.Use(
 		ape.CtxMiddleware(
@@ -32,6 +37,10 @@ func Run(ctx context.Context, cfg config.Config) {
 			handlers.CtxCachedStorage(cachedpg.NewStorage(cfg.Log(), storage, cfg.RedisClient())),
 			handlers.CtxBuilder(txbuild.NewMultiBuilder(cfg)),
 			handlers.CtxCore(cfg.Core()),
+			handlers.CtxDeadLetters(msgs.NewDeadLetterQueues(cfg.Log(), cfg.Queues())),
+			handlers.CtxKeyValue(redis.NewKeyValueProvider(cfg)),
+			handlers.CtxServiceStates(states),
+			handlers.CtxNftMetadataIndexer(cfg.NftMetadataIndexer()),
 			handlers.CtxProxyRepo(
 				proxy.New(
 					cfg.ChainsQ(),
@@ -52,6 +61,8 @@ func Run(ctx context.Context, cfg config.Config) {
 
 		r.Route("/items", func(r chi.Router) {
 			r.Route("/{index}", func(r chi.Router) {
+				r.Get("/", handlers.ItemByIndex)
+				r.Get("/history", handlers.ItemHistory)
 				r.Route("/chains", func(r chi.Router) {
 					r.Route("/{chain}", func(r chi.Router) {
 						r.Get("/balance/{account_address}", handlers.Balance)
@@ -61,12 +72,53 @@ func Run(ctx context.Context, cfg config.Config) {
 			})
 		})
 
+		r.Route("/seeds", func(r chi.Router) {
+			r.Get("/{seed}", handlers.SeedByID)
+		})
+
 		r.Route("/transfers", func(r chi.Router) {
 			r.Get("/", handlers.TransferList)
 			r.Get("/{id}", handlers.TransferByID)
 			r.Get("/{hash}/withdrawal/sse", handlers.WithdrawalByHash)
 		})
+		r.Route("/journeys", func(r chi.Router) {
+			r.Get("/{id}", handlers.Journey)
+		})
+		r.Route("/alerts", func(r chi.Router) {
+			r.Get("/stuck-transfers", handlers.StuckTransferAlertList)
+		})
+		r.Route("/operations", func(r chi.Router) {
+			r.Get("/", handlers.OperationList)
+			r.Get("/{index}", handlers.OperationByIndex)
+		})
+		r.Route("/identity", func(r chi.Router) {
+			r.Get("/transfers", handlers.IdentityTransferList)
+			r.Get("/transfers/{index}", handlers.IdentityTransferByIndex)
+			r.Get("/chains/{chain}/latest", handlers.LatestIdentityTransfer)
+		})
 		r.Post("/buildtx", handlers.BuildTx)
+
+		if !cfg.Admin().Disabled() {
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(handlers.NewAdminMiddleware(cfg.Admin().Token))
+
+				r.Route("/dlq/{queue}", func(r chi.Router) {
+					r.Get("/", handlers.DeadLetterList)
+					r.Get("/{id}", handlers.DeadLetterByID)
+					r.Delete("/{id}", handlers.DiscardDeadLetter)
+					r.Post("/{id}/replay", handlers.ReplayDeadLetter)
+				})
+
+				r.Route("/reconcile", func(r chi.Router) {
+					r.Get("/report", handlers.ReconcileReport)
+					r.Get("/metrics", handlers.ReconcileMetrics)
+				})
+
+				if states != nil {
+					r.Get("/services", handlers.ServiceStateList)
+				}
+			})
+		}
 	})
 
 	cfg.Log().WithFields(logan.F{
@@ -31,13 +31,23 @@ type approvalIndexer struct {
 	storage data.Storage
 }
 
-func (p *approvalIndexer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	approvals := make([]data.Approval, 0, len(msgs))
-	approvedTransferIndices := make([]string, 0, len(msgs))
+func (p *approvalIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	return &fork
+}
+
+func (p *approvalIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
+	approvals := make([]data.Approval, 0, len(batch))
+	statusChanges := make([]data.TransferStatusChange, 0, len(batch))
 
-	for _, msg := range msgs {
-		amsg := msg.MustApprovalOpMessage()
-		approvedTransferIndices = append(approvedTransferIndices, amsg.OperationID)
+	for _, msg := range batch {
+		var amsg msgs.ApprovalOpMsg
+		if err := msg.Decode(&amsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
+		statusChanges = append(statusChanges,
+			newStatusChange(amsg.OperationID, rarimocore.OpStatus_APPROVED, amsg.TransactionHash, amsg.BlockTime))
 		approvals = append(approvals, data.Approval{
 			TransferIndex:     []byte(amsg.OperationID),
 			RarimoTransaction: data.MustDBHash(amsg.TransactionHash),
@@ -45,17 +55,12 @@ func (p *approvalIndexer) Handle(ctx context.Context, msgs []msgs.Message) error
 		})
 	}
 
-	return p.storage.Transaction(func() error {
-		err := p.storage.
-			TransferQ().
-			SetStatusByIndexCtx(ctx, int(rarimocore.OpStatus_APPROVED), approvedTransferIndices...)
-		if err != nil {
-			return errors.Wrap(err, "failed to set status by index", logan.F{
-				"status":  int(rarimocore.OpStatus_APPROVED),
-				"indices": approvedTransferIndices,
-			})
-		}
+	err := setOperationsStatus(ctx, p.storage, rarimocore.OpStatus_APPROVED, statusChanges, func() error {
+		return errors.Wrap(p.storage.ApprovalQ().InsertBatchCtx(ctx, approvals...), "failed to insert approvals")
+	})
 
-		return p.storage.ApprovalQ().InsertBatchCtx(ctx, approvals...)
+	return errors.Wrap(err, "failed to set status by index", logan.F{
+		"status":  int(rarimocore.OpStatus_APPROVED),
+		"changes": len(statusChanges),
 	})
 }
@@ -0,0 +1,489 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/core"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/mem"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const (
+	BackfillRarimoCore   = "rarimocore"
+	BackfillTokenManager = "tokenmanager"
+
+	defaultBackfillRangeSize = 100
+)
+
+type BackfillOpts struct {
+	Module string
+	// From and To are the first and the last blocks to backfill, both inclusive
+	From int64
+	To   int64
+	// RangeSize is the max number of blocks handled at once
+	RangeSize int64
+	// Inline makes block ranges to be processed in the current process, passing the extracted
+	// messages right to the indexers instead of the queues, so redis is not needed
+	Inline bool
+	// MissingOnly skips messages which are already reflected in the database, only available
+	// for the inline mode as it has to check rows right before the message is handled
+	MissingOnly bool
+}
+
+// RunBackfill re-indexes historical blocks of the rarimo chain. By default, block ranges are
+// published to the queue of the module operations producer, the same way the block range
+// producer does it.
+func RunBackfill(ctx context.Context, cfg config.Config, opts BackfillOpts) error {
+	if opts.From <= 0 || opts.To < opts.From {
+		return errors.From(errors.New("invalid blocks range"), logan.F{
+			"from": opts.From,
+			"to":   opts.To,
+		})
+	}
+
+	if opts.MissingOnly && !opts.Inline {
+		return errors.New("missing only mode is available only for the inline backfill")
+	}
+
+	if opts.RangeSize <= 0 {
+		opts.RangeSize = defaultBackfillRangeSize
+	}
+
+	log := cfg.Log().WithFields(logan.F{
+		"who":    "backfill_" + opts.Module,
+		"inline": opts.Inline,
+	})
+
+	var (
+		process func(ctx context.Context, blockRange msgs.BlockRangeMessage) error
+		stats   *inlineStats
+		err     error
+	)
+
+	if opts.Inline {
+		process, stats, err = newInlineBackfill(cfg, log, opts)
+	} else {
+		process, err = newQueueBackfill(cfg, opts)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to init backfill")
+	}
+
+	total := opts.To - opts.From + 1
+	started := time.Now()
+
+	for start := opts.From; start <= opts.To; {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "died by context")
+		}
+
+		// operations producers treat the end of range as exclusive
+		end := start + opts.RangeSize
+		if end > opts.To+1 {
+			end = opts.To + 1
+		}
+
+		if err := process(ctx, msgs.BlockRangeMessage{Start: start, End: end}); err != nil {
+			return errors.Wrap(err, "failed to backfill block range", logan.F{
+				"start": start,
+				"end":   end - 1,
+			})
+		}
+
+		done := end - opts.From
+		elapsed := time.Since(started)
+		eta := time.Duration(float64(elapsed) / float64(done) * float64(total-done))
+
+		fields := logan.F{
+			"start":    start,
+			"end":      end - 1,
+			"progress": fmt.Sprintf("%d/%d (%.2f%%)", done, total, float64(done)*100/float64(total)),
+			"elapsed":  elapsed.Round(time.Second).String(),
+			"eta":      eta.Round(time.Second).String(),
+		}
+		if stats != nil {
+			fields = fields.Merge(stats.fields())
+		}
+
+		log.WithFields(fields).Info("backfilled block range")
+
+		start = end
+	}
+
+	return nil
+}
+
+func newQueueBackfill(cfg config.Config, opts BackfillOpts) (func(context.Context, msgs.BlockRangeMessage) error, error) {
+	var queue string
+
+	switch opts.Module {
+	case BackfillRarimoCore:
+		queue = cfg.RarimoCoreProducer().BlockRangeConsumer.Queue
+	case BackfillTokenManager:
+		queue = cfg.TokenManagerProducer().BlockRangeConsumer.Queue
+	default:
+		return nil, errors.From(errors.New("unknown module"), logan.F{
+			"module": opts.Module,
+		})
+	}
+
+	publisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(), "backfill_"+opts.Module+"_publisher", queue)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create block range publisher")
+	}
+
+	return func(ctx context.Context, blockRange msgs.BlockRangeMessage) error {
+		return publisher.PublishMsgs(ctx, blockRange.Message())
+	}, nil
+}
+
+func newInlineBackfill(cfg config.Config, log *logan.Entry, opts BackfillOpts) (func(context.Context, msgs.BlockRangeMessage) error, *inlineStats, error) {
+	storage := cfg.NewStorage()
+	stats := &inlineStats{}
+
+	var diff *backfillDiff
+	if opts.MissingOnly {
+		diff = &backfillDiff{
+			log:        log,
+			storage:    storage.Clone(),
+			rarimocore: cfg.Core().Rarimocore(),
+			chains:     cfg.ChainsQ(),
+		}
+	}
+
+	publisher := func(handler msgs.Handler) QPublisher {
+		return &inlinePublisher{
+			handler: handler,
+			diff:    diff,
+			stats:   stats,
+		}
+	}
+
+	switch opts.Module {
+	case BackfillRarimoCore:
+		producer := newInlineRarimoCoreOpProducer(cfg, log, storage, publisher)
+		producer.thttp = cfg.Tendermint()
+
+		return producer.produceMsgs, stats, nil
+	case BackfillTokenManager:
+		saver := &TokenmanagerSaver{
+			log:          log.WithField("who", "tokenmanager_saver"),
+			storage:      storage.Clone(),
+			chains:       cfg.ChainsQ(),
+			kv:           mem.NewKeyValueQ(),
+			genesis:      cfg.Genesis(),
+			tokenmanager: cfg.Core().Tokenmanager(),
+		}
+
+		producer := &tokenManagerOpProducer{
+			log:   log,
+			thttp: cfg.Tendermint(),
+			itemEventsPublisher: publisher(&itemsIndexer{
+				log:          log.WithField("indexer", "items"),
+				tokenmanager: cfg.Core().Tokenmanager(),
+				storage:      storage.Clone(),
+				chains:       cfg.ChainsQ(),
+				saver:        saver,
+			}),
+			collectionEventsPublisher: publisher(&collectionIndexer{
+				log:          log.WithField("indexer", "collections"),
+				tokenmanager: cfg.Core().Tokenmanager(),
+				storage:      storage.Clone(),
+				chains:       cfg.ChainsQ(),
+				saver:        saver,
+			}),
+			kv:  mem.NewKeyValueQ(),
+			txQ: storage.Clone().TransactionQ(),
+		}
+
+		return producer.produceMsgs, stats, nil
+	default:
+		return nil, nil, errors.From(errors.New("unknown module"), logan.F{
+			"module": opts.Module,
+		})
+	}
+}
+
+// newInlineRarimoCoreOpProducer creates rarimocore operations producer which passes the messages
+// to the indexers with the provided publisher instead of the queues, tendermint client is not set
+func newInlineRarimoCoreOpProducer(
+	cfg config.Config,
+	log *logan.Entry,
+	storage data.Storage,
+	publisher func(handler msgs.Handler) QPublisher,
+) *rarimoCoreOpProducer {
+	return &rarimoCoreOpProducer{
+		log: log,
+		transfersPublisher: publisher(&transfersIndexer{
+			log:          log.WithField("indexer", "transfers"),
+			rarimocore:   cfg.Core().Rarimocore(),
+			tokenmanager: cfg.Core().Tokenmanager(),
+			storage:      storage.Clone(),
+		}),
+		confirmationsPublisher: publisher(&confirmationsIndexer{
+			log:        log.WithField("indexer", "confirmations"),
+			rarimocore: cfg.Core().Rarimocore(),
+			storage:    storage.Clone(),
+		}),
+		approvalsPublisher: publisher(&approvalIndexer{
+			log:     log.WithField("indexer", "approvals"),
+			storage: storage.Clone(),
+		}),
+		rejectionsPublisher: publisher(&rejectionIndexer{
+			log:     log.WithField("indexer", "rejections"),
+			storage: storage.Clone(),
+		}),
+		votesPublisher: publisher(&votesIndexer{
+			log:     log.WithField("indexer", "votes"),
+			storage: storage.Clone(),
+		}),
+		kv:  mem.NewKeyValueQ(),
+		txQ: storage.Clone().TransactionQ(),
+	}
+}
+
+type inlineStats struct {
+	extracted int
+	handled   int
+	skipped   int
+}
+
+func (s *inlineStats) fields() logan.F {
+	return logan.F{
+		"extracted": s.extracted,
+		"handled":   s.handled,
+		"skipped":   s.skipped,
+	}
+}
+
+// inlinePublisher passes messages right to the indexer instead of the queue
+type inlinePublisher struct {
+	handler msgs.Handler
+	diff    *backfillDiff
+	stats   *inlineStats
+}
+
+func (p *inlinePublisher) PublishMsgs(ctx context.Context, messages ...msgs.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	p.stats.extracted += len(messages)
+
+	if p.diff == nil {
+		if err := p.handler.Handle(ctx, messages); err != nil {
+			return errors.Wrap(err, "failed to handle messages")
+		}
+
+		p.stats.handled += len(messages)
+		return nil
+	}
+
+	// messages are checked and handled one by one, as the previous message could create
+	// the row the next one depends on
+	for _, msg := range messages {
+		missing, err := p.diff.isMissing(ctx, msg)
+		if err != nil {
+			return errors.Wrap(err, "failed to check message against database", logan.F{
+				"message": msg.String(),
+			})
+		}
+
+		if !missing {
+			p.stats.skipped++
+			continue
+		}
+
+		if err := p.handler.Handle(ctx, []msgs.Message{msg}); err != nil {
+			return errors.Wrap(err, "failed to handle message", logan.F{
+				"message": msg.String(),
+			})
+		}
+
+		p.stats.handled++
+	}
+
+	return nil
+}
+
+// backfillDiff checks whether the message effect is missing in the database. Creations are
+// missing when there is no such row yet, removals - when the row still exists. Other messages
+// are always handled, as they only refresh existing rows.
+type backfillDiff struct {
+	log        *logan.Entry
+	storage    data.Storage
+	rarimocore core.Rarimocore
+	chains     data.ChainsQ
+}
+
+func (d *backfillDiff) isMissing(ctx context.Context, msg msgs.Message) (bool, error) {
+	decoded, err := msg.DecodeAny()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to decode message", msg.Fields())
+	}
+
+	switch m := decoded.(type) {
+	case msgs.TransferOpMsg:
+		if m.OperationType != "" && m.OperationType != rarimocore.OpType_TRANSFER.String() {
+			if isIdentityOperation(rarimocore.OpType(rarimocore.OpType_value[m.OperationType])) {
+				transfer, err := d.storage.IdentityTransferQ().IdentityTransferByOperationIndexCtx(ctx, []byte(m.TransferID), false)
+				return transfer == nil, errors.Wrap(err, "failed to get identity transfer")
+			}
+
+			operation, err := d.storage.OperationQ().OperationByIndexCtx(ctx, []byte(m.TransferID), false)
+			return operation == nil, errors.Wrap(err, "failed to get operation")
+		}
+
+		transfer, err := d.storage.TransferQ().TransferByIndexCtx(ctx, []byte(m.TransferID), false)
+		return transfer == nil, errors.Wrap(err, "failed to get transfer")
+	case msgs.ConfirmationOpMsg:
+		return d.isConfirmationMissing(ctx, m)
+	case msgs.VoteOpMsg:
+		votes, err := d.storage.VoteQ().VotesByTransferIndexCtx(ctx, []byte(m.OperationID), false)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get votes")
+		}
+
+		for _, vote := range votes {
+			if bytes.Equal(vote.RarimoTransaction, data.MustDBHash(m.TransactionHash)) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	case msgs.ApprovalOpMsg:
+		approvals, err := d.storage.ApprovalQ().ApprovalsByTransferIndexCtx(ctx, []byte(m.OperationID), false)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get approvals")
+		}
+
+		for _, approval := range approvals {
+			if bytes.Equal(approval.RarimoTransaction, data.MustDBHash(m.TransactionHash)) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	case msgs.RejectionOpMsg:
+		rejections, err := d.storage.RejectionQ().RejectionsByTransferIndexCtx(ctx, []byte(m.OperationID), false)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get rejections")
+		}
+
+		for _, rejection := range rejections {
+			if bytes.Equal(rejection.RarimoTransaction, data.MustDBHash(m.TransactionHash)) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	case msgs.CollectionCreatedMessage:
+		collection, err := d.storage.CollectionQ().CollectionByIndexCtx(ctx, []byte(m.Index), false)
+		return collection == nil, errors.Wrap(err, "failed to get collection")
+	case msgs.CollectionRemovedMessage:
+		collection, err := d.storage.CollectionQ().CollectionByIndexCtx(ctx, []byte(m.Index), false)
+		return collection != nil && !collection.DeletedAt.Valid, errors.Wrap(err, "failed to get collection")
+	case msgs.CollectionDataCreatedMessage:
+		mapping, ok, err := d.getCollectionChainMapping(ctx, m.CollectionIndex, m.Chain)
+		return ok && mapping == nil, err
+	case msgs.CollectionDataRemovedMessage:
+		mapping, _, err := d.getCollectionChainMapping(ctx, m.CollectionIndex, m.Chain)
+		return mapping != nil, err
+	case msgs.ItemCreatedMessage:
+		item, err := d.storage.ItemQ().ItemByIndexCtx(ctx, []byte(m.Index), false)
+		return item == nil, errors.Wrap(err, "failed to get item")
+	case msgs.ItemRemovedMessage:
+		item, err := d.storage.ItemQ().ItemByIndexCtx(ctx, []byte(m.Index), false)
+		return item != nil && !item.DeletedAt.Valid, errors.Wrap(err, "failed to get item")
+	case msgs.ItemOnChainDataCreatedMessage:
+		mapping, ok, err := d.getItemChainMapping(ctx, m.ItemIndex, m.Chain)
+		return ok && mapping == nil, err
+	case msgs.ItemOnChainDataRemovedMessage:
+		mapping, _, err := d.getItemChainMapping(ctx, m.ItemIndex, m.Chain)
+		return mapping != nil, err
+	default:
+		return true, nil
+	}
+}
+
+func (d *backfillDiff) isConfirmationMissing(ctx context.Context, msg msgs.ConfirmationOpMsg) (bool, error) {
+	confirmation, err := d.rarimocore.GetConfirmation(ctx, msg.ConfirmationID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get confirmation from core")
+	}
+
+	for _, transferIndex := range confirmation.Indexes {
+		confirmations, err := d.storage.ConfirmationQ().ConfirmationsByTransferIndexCtx(ctx, []byte(transferIndex), false)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get confirmations", logan.F{
+				"transfer_index": transferIndex,
+			})
+		}
+
+		if len(confirmations) == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getCollectionChainMapping returns false if the collection or the chain is not known, so
+// the mapping could not be created
+func (d *backfillDiff) getCollectionChainMapping(ctx context.Context, index, chain string) (*data.CollectionChainMapping, bool, error) {
+	network := d.chains.Get(chain)
+	if network == nil {
+		return nil, false, nil
+	}
+
+	collection, err := d.storage.CollectionQ().CollectionByIndexCtx(ctx, []byte(index), false)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get collection")
+	}
+
+	if collection == nil {
+		d.log.WithField("index", index).Warn("collection not found, skipping its chain data")
+		return nil, false, nil
+	}
+
+	mapping, err := d.storage.CollectionChainMappingQ().CollectionChainMappingByCollectionNetworkCtx(ctx, collection.ID, network.ID, false)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get collection chain mapping")
+	}
+
+	return mapping, true, nil
+}
+
+// getItemChainMapping returns false if the item or the chain is not known, so the mapping
+// could not be created
+func (d *backfillDiff) getItemChainMapping(ctx context.Context, index, chain string) (*data.ItemChainMapping, bool, error) {
+	network := d.chains.Get(chain)
+	if network == nil {
+		return nil, false, nil
+	}
+
+	item, err := d.storage.ItemQ().ItemByIndexCtx(ctx, []byte(index), false)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get item")
+	}
+
+	if item == nil {
+		d.log.WithField("index", index).Warn("item not found, skipping its on-chain data")
+		return nil, false, nil
+	}
+
+	mapping, err := d.storage.ItemChainMappingQ().ItemChainMappingByItemNetworkCtx(ctx, item.ID, network.ID, false)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get item chain mapping")
+	}
+
+	return mapping, true, nil
+}
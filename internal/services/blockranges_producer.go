@@ -10,6 +10,7 @@ import (
 	"github.com/rarimo/horizon-svc/internal/data/redis"
 	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/types"
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
 	"gitlab.com/distributed_lab/running"
@@ -23,7 +24,7 @@ func RunBlockRangeProducer(ctx context.Context, cfg config.Config) {
 	log := cfg.Log().WithField("who", cfg.BlockRangeProducer().RunnerName)
 
 	blockRangePublisher, err := msgs.NewPublisher(cfg.Log(),
-		cfg.RedisClient(),
+		cfg.Queues(),
 		cfg.BlockRangeProducer().RunnerName+"_qpublisher",
 		cfg.BlockRangeProducer().QueueName)
 	if err != nil {
@@ -37,23 +38,31 @@ func RunBlockRangeProducer(ctx context.Context, cfg config.Config) {
 		publisher:       blockRangePublisher,
 		cursorKey:       cfg.BlockRangeProducer().CursorKey,
 		blockRangeLimit: cfg.BlockRangeProducer().BlockRangeLimit,
+
+		subscribe:           cfg.BlockRangeProducer().Subscribe,
+		subscriber:          cfg.BlockRangeProducer().RunnerName,
+		subscriptionTimeout: cfg.BlockRangeProducer().SubscriptionTimeout,
 	}
 
-	if len(cfg.BlockRangeProducer().SpecialCaseBlocks) != 0 {
-		err = producer.produceSpecialCase(ctx, cfg.BlockRangeProducer().SpecialCaseBlocks)
-		if err != nil {
-			panic(errors.Wrap(err, "failed to produce special case blocks"))
+	// replicas share the cursor, so only the lease holder publishes block ranges
+	RunAsLeader(ctx, cfg, "block_range_producer:"+cfg.BlockRangeProducer().CursorKey, func(ctx context.Context) {
+		if len(cfg.BlockRangeProducer().SpecialCaseBlocks) != 0 {
+			log.Warn("special_case_blocks are deprecated and published on every start, use backfill command instead")
+			err = producer.produceSpecialCase(ctx, cfg.BlockRangeProducer().SpecialCaseBlocks)
+			if err != nil {
+				panic(errors.Wrap(err, "failed to produce special case blocks"))
+			}
 		}
-	}
 
-	running.WithBackOff(ctx,
-		log,
-		cfg.BlockRangeProducer().RunnerName,
-		producer.produceOnce,
-		cfg.BlockRangeProducer().BlockTime,
-		time.Second*10,
-		time.Minute,
-	)
+		running.WithBackOff(ctx,
+			log,
+			cfg.BlockRangeProducer().RunnerName,
+			producer.produceOnce,
+			cfg.BlockRangeProducer().BlockTime,
+			time.Second*10,
+			time.Minute,
+		)
+	})
 }
 
 type blockRangeProducer struct {
@@ -64,6 +73,10 @@ type blockRangeProducer struct {
 
 	cursorKey       string
 	blockRangeLimit int64
+
+	subscribe           bool
+	subscriber          string
+	subscriptionTimeout time.Duration
 }
 
 func (p *blockRangeProducer) produceOnce(ctx context.Context) error {
@@ -115,42 +128,125 @@ func (p *blockRangeProducer) produceOnce(ctx context.Context) error {
 		}
 
 		if start > end {
+			if p.subscribe {
+				// caught up, so following new blocks until the gap or disconnect happens
+				return p.followNewBlocks(ctx, startCursorKV, start)
+			}
+
 			p.log.Debug("start > end waiting for next block")
 			return nil
 		}
 
-		p.log.WithFields(logan.F{
-			"start": start,
-			"end":   end,
-		}).Info("Producing block range")
+		if err := p.publishRange(ctx, startCursorKV, start, end); err != nil {
+			return errors.Wrap(err, "failed to publish block range")
+		}
 
-		msg := msgs.BlockRangeMessage{
-			Start: start,
-			End:   end,
+		start = end + 1
+	}
+}
+
+// followNewBlocks publishes single-block ranges on every NewBlock event. It returns once
+// some blocks were missed or there were no events for too long, so the next produceOnce
+// call would catch up by polling.
+func (p *blockRangeProducer) followNewBlocks(ctx context.Context, cursor *data.KeyValue, start int64) error {
+	query := types.EventQueryNewBlock.String()
+
+	events, err := p.thttp.Subscribe(ctx, p.subscriber, query)
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe to new blocks")
+	}
+
+	defer func() {
+		if err := p.thttp.Unsubscribe(context.Background(), p.subscriber, query); err != nil {
+			p.log.WithError(err).Warn("failed to unsubscribe from new blocks")
 		}
+	}()
+
+	p.log.WithField("start", start).Info("subscribed to new blocks")
+
+	timeout := time.NewTimer(p.subscriptionTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "died by context")
+		case <-timeout.C:
+			p.log.Warn("no new blocks received for too long, falling back to polling")
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				p.log.Warn("new blocks subscription closed, falling back to polling")
+				return nil
+			}
+
+			block, ok := event.Data.(types.EventDataNewBlock)
+			if !ok || block.Block == nil {
+				p.log.WithField("event", event.Data).Warn("unexpected event received, skipping")
+				continue
+			}
+
+			height := block.Block.Height
+			if height < start {
+				continue
+			}
+
+			if height > start {
+				p.log.WithFields(logan.F{
+					"start":  start,
+					"height": height,
+				}).Info("missed some blocks, falling back to polling")
+				return nil
+			}
+
+			if err := p.publishRange(ctx, cursor, start, start); err != nil {
+				return errors.Wrap(err, "failed to publish new block")
+			}
+
+			start++
 
-		if err := p.publisher.PublishMsgs(ctx, msg.Message()); err != nil {
-			return errors.Wrap(err, "failed to publish block range", logan.F{
-				"start": start,
-				"end":   end,
-			})
+			if !timeout.Stop() {
+				<-timeout.C
+			}
+			timeout.Reset(p.subscriptionTimeout)
 		}
+	}
+}
 
-		start = end + 1
+func (p *blockRangeProducer) publishRange(ctx context.Context, cursor *data.KeyValue, start, end int64) error {
+	p.log.WithFields(logan.F{
+		"start": start,
+		"end":   end,
+	}).Info("Producing block range")
+
+	msg := msgs.BlockRangeMessage{
+		Start: start,
+		End:   end,
+	}
 
-		err = p.kv.Upsert(ctx, data.KeyValue{
-			Key:       startCursorKV.Key,
-			Value:     strconv.FormatInt(start, 10),
-			CreatedAt: startCursorKV.CreatedAt,
-			UpdatedAt: time.Now().UTC(),
+	if err := p.publisher.PublishMsgs(ctx, msg.Message()); err != nil {
+		return errors.Wrap(err, "failed to publish block range", logan.F{
+			"start": start,
+			"end":   end,
 		})
-		if err != nil {
-			p.log.WithFields(logan.F{
-				"cursor_key":   startCursorKV.Key,
-				"cursor_value": start,
-			}).Warn("failed to save cursor")
-		}
 	}
+
+	next := end + 1
+
+	err := p.kv.Upsert(ctx, data.KeyValue{
+		Key:       cursor.Key,
+		Value:     strconv.FormatInt(next, 10),
+		CreatedAt: cursor.CreatedAt,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		p.log.WithFields(logan.F{
+			"cursor_key":   cursor.Key,
+			"cursor_value": next,
+		}).Warn("failed to save cursor")
+	}
+
+	return nil
 }
 
 func (p *blockRangeProducer) produceSpecialCase(ctx context.Context, blocks []config.SpecialCaseBlockRange) error {
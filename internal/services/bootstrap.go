@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/core"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/redis"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+type BootstrapOpts struct {
+	// Height is the block height of the core state to bootstrap from, indexing continues from the next block
+	Height int64
+	// CursorKeys are the keys of the block range producers cursors to be set to the next block, the cursor of the
+	// configured block range producer is used if empty
+	CursorKeys []string
+	// Force allows to move the block range cursors back if they are already ahead of the height
+	Force bool
+}
+
+// RunBootstrap imports the tokenmanager and rarimocore state of the core at the height and sets the block range
+// cursor to the next block, so indexing starts from it instead of replaying the whole chain. Hashes of the rarimo
+// transactions are not the part of the core state, so they are left empty for the imported entries.
+func RunBootstrap(ctx context.Context, cfg config.Config, opts BootstrapOpts) error {
+	if opts.Height <= 0 {
+		return errors.From(errors.New("invalid bootstrap height"), logan.F{
+			"height": opts.Height,
+		})
+	}
+
+	b := bootstrapper{
+		log:        cfg.Log().WithFields(logan.F{"who": "bootstrap", "height": opts.Height}),
+		storage:    cfg.CachedStorage().Clone(),
+		kv:         redis.NewKeyValueProvider(cfg),
+		rarimocore: cfg.Core().Rarimocore(),
+		reconciler: NewReconciler(cfg, true),
+		cursorKeys: opts.CursorKeys,
+	}
+
+	if len(b.cursorKeys) == 0 {
+		b.cursorKeys = []string{cfg.BlockRangeProducer().CursorKey}
+	}
+
+	b.indexer = &transfersIndexer{
+		log:          b.log,
+		rarimocore:   b.rarimocore,
+		tokenmanager: cfg.Core().Tokenmanager(),
+		storage:      b.storage,
+	}
+
+	for _, key := range b.cursorKeys {
+		if err := b.checkCursor(ctx, key, opts); err != nil {
+			return err
+		}
+	}
+
+	// every core query below is done against the state at the height
+	ctx = core.AtHeight(ctx, opts.Height)
+
+	// reconciler repairing everything saves the collections, items and their chain mappings of the empty database
+	// and brings the non-empty one to the state at the height
+	report, err := b.reconciler.ReconcileOnce(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to bootstrap tokenmanager state")
+	}
+
+	b.log.WithField("repaired", report.Repaired).Info("tokenmanager state bootstrapped")
+
+	if err := b.saveConfirmations(ctx); err != nil {
+		return errors.Wrap(err, "failed to bootstrap confirmations")
+	}
+
+	if err := b.saveOperations(ctx); err != nil {
+		return errors.Wrap(err, "failed to bootstrap operations")
+	}
+
+	if err := b.setValue(ctx, IsGenesisParsedKey, strconv.FormatBool(true)); err != nil {
+		return errors.Wrap(err, "failed to mark genesis parsed")
+	}
+
+	for _, key := range b.cursorKeys {
+		if err := b.setValue(ctx, key, strconv.FormatInt(opts.Height+1, 10)); err != nil {
+			return errors.Wrap(err, "failed to set block range cursor", logan.F{
+				"cursor_key": key,
+			})
+		}
+	}
+
+	b.log.WithField("cursor", opts.Height+1).Info("bootstrap finished")
+
+	return nil
+}
+
+type bootstrapper struct {
+	log        *logan.Entry
+	storage    data.Storage
+	kv         data.KeyValueQ
+	rarimocore core.Rarimocore
+	reconciler *Reconciler
+	indexer    *transfersIndexer
+	cursorKeys []string
+}
+
+// checkCursor does not let to move the cursor back accidentally, as the blocks after it would be indexed again
+func (b *bootstrapper) checkCursor(ctx context.Context, key string, opts BootstrapOpts) error {
+	cursor, err := b.kv.Get(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to get block range cursor", logan.F{
+			"cursor_key": key,
+		})
+	}
+
+	if cursor == nil || opts.Force {
+		return nil
+	}
+
+	next, err := strconv.ParseInt(cursor.Value, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse block range cursor", logan.F{
+			"cursor_key": key,
+			"raw":        cursor.Value,
+		})
+	}
+
+	if next > opts.Height+1 {
+		return errors.From(errors.New("block range cursor is ahead of the bootstrap height, use force to move it back"), logan.F{
+			"cursor_key": key,
+			"cursor":     next,
+		})
+	}
+
+	return nil
+}
+
+// saveConfirmations is done before the operations, so the statuses of the confirmed ones are known from the database
+func (b *bootstrapper) saveConfirmations(ctx context.Context) error {
+	var (
+		key   []byte
+		total int
+	)
+
+	for {
+		confirmations, next, err := b.rarimocore.GetConfirmationsPage(ctx, key)
+		if err != nil {
+			return errors.Wrap(err, "failed to get confirmations page")
+		}
+
+		batch := make([]data.Confirmation, 0, len(confirmations))
+		for _, confirmation := range confirmations {
+			for _, index := range confirmation.Indexes {
+				batch = append(batch, data.Confirmation{
+					TransferIndex:     []byte(index),
+					RarimoTransaction: nil, // transaction of the confirmation is unknown
+					CreatedAt:         time.Now().UTC(),
+				})
+			}
+		}
+
+		if err := b.storage.ConfirmationQ().InsertBatchCtx(ctx, batch...); err != nil {
+			return errors.Wrap(err, "failed to insert confirmations")
+		}
+
+		total += len(batch)
+
+		if len(next) == 0 {
+			break
+		}
+
+		key = next
+	}
+
+	b.log.WithField("confirmed_operations", total).Info("confirmations bootstrapped")
+
+	return nil
+}
+
+func (b *bootstrapper) saveOperations(ctx context.Context) error {
+	var (
+		key   []byte
+		total int
+	)
+
+	for {
+		operations, next, err := b.rarimocore.GetOperationsPage(ctx, key)
+		if err != nil {
+			return errors.Wrap(err, "failed to get operations page")
+		}
+
+		if err := b.saveOperationsPage(ctx, operations); err != nil {
+			return errors.Wrap(err, "failed to save operations page")
+		}
+
+		total += len(operations)
+
+		b.log.WithField("operations", total).Debug("operations page bootstrapped")
+
+		if len(next) == 0 {
+			break
+		}
+
+		key = next
+	}
+
+	b.log.WithField("operations", total).Info("operations bootstrapped")
+
+	return nil
+}
+
+func (b *bootstrapper) saveOperationsPage(ctx context.Context, page []rarimocore.Operation) error {
+	transfers := make([]data.Transfer, 0, len(page))
+	operations := make([]data.Operation, 0)
+	identityTransfers := make([]data.IdentityTransfer, 0)
+	statusChanges := make([]data.TransferStatusChange, 0, len(page))
+
+	for _, operation := range page {
+		switch {
+		case operation.OperationType == rarimocore.OpType_TRANSFER:
+			transfer, err := b.indexer.makeTransferFromOperation(ctx, "", operation)
+			if err != nil {
+				return errors.Wrap(err, "failed to make transfer", logan.F{
+					"index": operation.Index,
+				})
+			}
+
+			transfers = append(transfers, *transfer)
+		case isIdentityOperation(operation.OperationType):
+			identityTransfer, err := b.indexer.makeIdentityTransfer(ctx, "", operation)
+			if err != nil {
+				return errors.Wrap(err, "failed to make identity transfer", logan.F{
+					"index": operation.Index,
+				})
+			}
+
+			identityTransfers = append(identityTransfers, *identityTransfer)
+		case newOperationDetails(operation.OperationType) != nil:
+			operationData, err := b.indexer.makeOperation(ctx, "", operation)
+			if err != nil {
+				return errors.Wrap(err, "failed to make operation", logan.F{
+					"index": operation.Index,
+				})
+			}
+
+			operations = append(operations, *operationData)
+		default:
+			continue
+		}
+
+		statusChanges = append(statusChanges,
+			newStatusChange(operation.Index, rarimocore.OpStatus_INITIALIZED, "", int64(operation.Timestamp)))
+
+		// time of the later statuses is unknown, so only the fact they were reached is recorded
+		if operation.Status != rarimocore.OpStatus_INITIALIZED {
+			statusChanges = append(statusChanges, newStatusChange(operation.Index, operation.Status, "", 0))
+		}
+	}
+
+	return b.storage.Transaction(func() error {
+		if err := b.storage.OperationQ().UpsertBatchCtx(ctx, operations...); err != nil {
+			return errors.Wrap(err, "failed to upsert operations")
+		}
+
+		if err := b.storage.IdentityTransferQ().UpsertBatchCtx(ctx, identityTransfers...); err != nil {
+			return errors.Wrap(err, "failed to upsert identity transfers")
+		}
+
+		if err := b.storage.TransferQ().UpsertBatchCtx(ctx, transfers...); err != nil {
+			return errors.Wrap(err, "failed to upsert transfers")
+		}
+
+		return b.storage.TransferStatusChangeQ().InsertBatchCtx(ctx, statusChanges...)
+	})
+}
+
+func (b *bootstrapper) setValue(ctx context.Context, key, value string) error {
+	now := time.Now().UTC()
+
+	return b.kv.Upsert(ctx, data.KeyValue{
+		Key:       key,
+		Value:     value,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
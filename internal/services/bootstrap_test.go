@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/rarimo/horizon-svc/internal/core"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// pagedRarimocore returns the operations and confirmations one per page
+type pagedRarimocore struct {
+	core.Rarimocore
+	operations    []rarimocore.Operation
+	confirmations []rarimocore.Confirmation
+}
+
+func (c pagedRarimocore) GetOperationsPage(_ context.Context, key []byte) ([]rarimocore.Operation, []byte, error) {
+	i, next := page(key, len(c.operations))
+	return c.operations[i : i+1], next, nil
+}
+
+func (c pagedRarimocore) GetConfirmationsPage(_ context.Context, key []byte) ([]rarimocore.Confirmation, []byte, error) {
+	i, next := page(key, len(c.confirmations))
+	return c.confirmations[i : i+1], next, nil
+}
+
+func page(key []byte, total int) (int, []byte) {
+	i := 0
+	if len(key) != 0 {
+		i = int(key[0])
+	}
+
+	if i+1 == total {
+		return i, nil
+	}
+
+	return i, []byte{byte(i + 1)}
+}
+
+func TestBootstrapSavesCoreStateWithoutTransactions(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano()
+	signed := fmt.Sprintf("%x", suffix)
+	initialized := fmt.Sprintf("%x", suffix+1)
+
+	details, err := codectypes.NewAnyWithValue(&rarimocore.ChangeParties{NewPublicKey: "key"})
+	require.NoError(t, err)
+
+	rarimocoreQ := pagedRarimocore{
+		operations: []rarimocore.Operation{
+			{
+				Index:         signed,
+				OperationType: rarimocore.OpType_CHANGE_PARTIES,
+				Details:       details,
+				Status:        rarimocore.OpStatus_SIGNED,
+				Timestamp:     uint64(time.Now().Unix()),
+			},
+			{
+				Index:         initialized,
+				OperationType: rarimocore.OpType_CHANGE_PARTIES,
+				Details:       details,
+				Status:        rarimocore.OpStatus_INITIALIZED,
+				Timestamp:     uint64(time.Now().Unix()),
+			},
+		},
+		confirmations: []rarimocore.Confirmation{
+			{Root: "root", Indexes: []string{signed}},
+		},
+	}
+
+	log := logan.New().WithField("who", "test")
+	b := bootstrapper{
+		log:        log,
+		storage:    storage,
+		rarimocore: rarimocoreQ,
+		indexer: &transfersIndexer{
+			log:        log,
+			rarimocore: rarimocoreQ,
+			storage:    storage,
+		},
+	}
+
+	require.NoError(t, b.saveConfirmations(ctx))
+	require.NoError(t, b.saveOperations(ctx))
+
+	confirmations, err := storage.ConfirmationQ().ConfirmationsByTransferIndexCtx(ctx, []byte(signed), false)
+	require.NoError(t, err)
+	require.Len(t, confirmations, 1)
+	assert.Nil(t, confirmations[0].RarimoTransaction)
+
+	for index, status := range map[string]rarimocore.OpStatus{
+		signed:      rarimocore.OpStatus_SIGNED,
+		initialized: rarimocore.OpStatus_INITIALIZED,
+	} {
+		operation, err := storage.OperationQ().OperationByIndexCtx(ctx, []byte(index), false)
+		require.NoError(t, err)
+		require.NotNil(t, operation, index)
+		assert.Equal(t, int(status), operation.Status, index)
+		assert.Nil(t, operation.RarimoTx, index)
+	}
+
+	changes, err := storage.TransferStatusChangeQ().SelectByTransferIndexesCtx(ctx, []byte(signed), []byte(initialized))
+	require.NoError(t, err)
+	assert.Len(t, changes, 3)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/rarimo/horizon-svc/internal/config"
 	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/services"
 	"github.com/rarimo/horizon-svc/internal/services/bridge_producer/types"
 	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"gitlab.com/distributed_lab/logan/v3"
@@ -17,22 +18,25 @@ func RunBridgeEventsProducer(ctx context.Context, cfg config.Config) {
 	who := cfg.BridgeProducer().RunnerName + "_bridge_events_producer"
 	log := cfg.Log().WithField("who", who)
 
-	withdrawalsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	withdrawalsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.BridgeProducer().RunnerName+"_withdrawals_publisher",
 		cfg.BridgeProducer().WithdrawalsQueueName)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to create withdrawals publisher"))
 	}
 
-	running.WithBackOff(ctx, log, who,
-		func(ctx context.Context) error {
-			provider := &bridgeEventsProducer{
-				log:       log,
-				chains:    cfg.ChainsQ(),
-				producers: newProducerer(cfg, withdrawalsPublisher),
-			}
-			return provider.run(ctx)
-		}, 5*time.Second, 10*time.Second, time.Minute)
+	// replicas share the chain cursors, so only the lease holder scans the bridges
+	services.RunAsLeader(ctx, cfg, "bridge_events_producer:"+cfg.BridgeProducer().WithdrawalsQueueName, func(ctx context.Context) {
+		running.WithBackOff(ctx, log, who,
+			func(ctx context.Context) error {
+				provider := &bridgeEventsProducer{
+					log:       log,
+					chains:    cfg.ChainsQ(),
+					producers: newProducerer(cfg, withdrawalsPublisher),
+				}
+				return provider.run(ctx)
+			}, 5*time.Second, 10*time.Second, time.Minute)
+	})
 }
 
 type bridgeEventsProducer struct {
@@ -0,0 +1,221 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/redis"
+	"github.com/rarimo/horizon-svc/internal/services"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// trackedBlock is a recently scanned block which could still be reorganized. Withdrawals
+// published from the block are kept to be retracted in case of reorg.
+type trackedBlock struct {
+	Number      uint64               `json:"number"`
+	Hash        common.Hash          `json:"hash"`
+	Withdrawals []msgs.WithdrawalMsg `json:"withdrawals,omitempty"`
+}
+
+// blockTracker keeps hashes of the recently scanned blocks to detect reorgs
+// and retract withdrawals published from the orphaned blocks
+type blockTracker struct {
+	log   *logan.Entry
+	cli   *ethclient.Client
+	kv    *redis.KeyValueProvider
+	key   string
+	depth uint64
+
+	blocks []trackedBlock
+	loaded bool
+}
+
+func newBlockTracker(log *logan.Entry, cli *ethclient.Client, kv *redis.KeyValueProvider, key string, depth uint64) *blockTracker {
+	return &blockTracker{
+		log:   log,
+		cli:   cli,
+		kv:    kv,
+		key:   key,
+		depth: depth,
+	}
+}
+
+// CheckReorg compares tracked blocks with the canonical chain, publishes retractions of
+// withdrawals from the orphaned blocks and returns the block scanning has to be restarted from.
+// Returns nil if there was no reorg.
+func (t *blockTracker) CheckReorg(ctx context.Context, publisher services.QPublisher) (*uint64, error) {
+	if err := t.load(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to load tracked blocks")
+	}
+
+	// the highest block which is still canonical, all the blocks after it are considered orphaned
+	matched := -1
+	for i := len(t.blocks) - 1; i >= 0; i-- {
+		header, err := t.cli.HeaderByNumber(ctx, new(big.Int).SetUint64(t.blocks[i].Number))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get header", logan.F{
+				"number": t.blocks[i].Number,
+			})
+		}
+
+		if header.Hash() == t.blocks[i].Hash {
+			matched = i
+			break
+		}
+	}
+
+	if matched == len(t.blocks)-1 {
+		return nil, nil
+	}
+
+	orphaned := t.blocks[matched+1:]
+
+	rewind := orphaned[0].Number
+	if matched >= 0 {
+		rewind = t.blocks[matched].Number + 1
+	}
+
+	var retractions []msgs.Message
+	for _, block := range orphaned {
+		for _, withdrawal := range block.Withdrawals {
+			retractions = append(retractions, msgs.WithdrawalRetractedMsg{
+				Origin: withdrawal.Origin,
+				Hash:   withdrawal.Hash,
+			}.Message())
+		}
+	}
+
+	t.log.WithFields(logan.F{
+		"orphaned_from": orphaned[0].Number,
+		"orphaned_to":   orphaned[len(orphaned)-1].Number,
+		"rewind_to":     rewind,
+		"retractions":   len(retractions),
+	}).Warn("reorg detected")
+
+	if len(retractions) != 0 {
+		if err := publisher.PublishMsgs(ctx, retractions...); err != nil {
+			return nil, errors.Wrap(err, "failed to publish retractions")
+		}
+	}
+
+	t.blocks = t.blocks[:matched+1]
+	if err := t.save(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to save tracked blocks")
+	}
+
+	return &rewind, nil
+}
+
+// Track remembers published withdrawal, so it could be retracted if its block gets orphaned.
+// Tracked blocks are saved on the next Checkpoint.
+func (t *blockTracker) Track(head uint64, number uint64, hash common.Hash, withdrawal *msgs.WithdrawalMsg) {
+	if number+t.depth < head {
+		return // block is too deep to be reorganized
+	}
+
+	block := t.getOrAdd(number, hash)
+	block.Withdrawals = append(block.Withdrawals, *withdrawal)
+}
+
+// Checkpoint remembers the last scanned block and forgets blocks which are too deep to be reorganized
+func (t *blockTracker) Checkpoint(ctx context.Context, head uint64, number uint64) error {
+	header, err := t.cli.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return errors.Wrap(err, "failed to get header", logan.F{
+			"number": number,
+		})
+	}
+
+	t.getOrAdd(number, header.Hash())
+
+	pruned := t.blocks[:0]
+	for _, block := range t.blocks {
+		if block.Number+t.depth >= head {
+			pruned = append(pruned, block)
+		}
+	}
+	t.blocks = pruned
+
+	return t.save(ctx)
+}
+
+func (t *blockTracker) getOrAdd(number uint64, hash common.Hash) *trackedBlock {
+	for i := range t.blocks {
+		if t.blocks[i].Number == number {
+			t.blocks[i].Hash = hash
+			return &t.blocks[i]
+		}
+	}
+
+	t.blocks = append(t.blocks, trackedBlock{
+		Number: number,
+		Hash:   hash,
+	})
+	sort.Slice(t.blocks, func(i, j int) bool {
+		return t.blocks[i].Number < t.blocks[j].Number
+	})
+
+	for i := range t.blocks {
+		if t.blocks[i].Number == number {
+			return &t.blocks[i]
+		}
+	}
+
+	return nil // unreachable
+}
+
+func (t *blockTracker) load(ctx context.Context) error {
+	if t.loaded {
+		return nil
+	}
+
+	kv, err := t.kv.Get(ctx, t.key)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tracked blocks", logan.F{
+			"key": t.key,
+		})
+	}
+
+	t.blocks = nil
+	if kv != nil && kv.Value != "" {
+		if err := json.Unmarshal([]byte(kv.Value), &t.blocks); err != nil {
+			return errors.Wrap(err, "failed to unmarshal tracked blocks", logan.F{
+				"key": t.key,
+			})
+		}
+	}
+
+	t.loaded = true
+	return nil
+}
+
+func (t *blockTracker) save(ctx context.Context) error {
+	raw, err := json.Marshal(t.blocks)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal tracked blocks")
+	}
+
+	now := time.Now().UTC()
+
+	err = t.kv.Upsert(ctx, data.KeyValue{
+		Key:       t.key,
+		Value:     string(raw),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to save tracked blocks", logan.F{
+			"key": t.key,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	erc1155 "github.com/rarimo/evm-bridge-contracts/bindings/contracts/interfaces/handlers/ierc1155handler"
+	erc20 "github.com/rarimo/evm-bridge-contracts/bindings/contracts/interfaces/handlers/ierc20handler"
+	erc721 "github.com/rarimo/evm-bridge-contracts/bindings/contracts/interfaces/handlers/ierc721handler"
+	native "github.com/rarimo/evm-bridge-contracts/bindings/contracts/interfaces/handlers/inativehandler"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// withdrawalDecoder extracts withdrawal origin from the withdrawal event of the particular bridge handler
+type withdrawalDecoder struct {
+	name  string
+	topic common.Hash
+	parse func(log ethtypes.Log) ([32]byte, error)
+}
+
+func newWithdrawalDecoders(contract common.Address) []withdrawalDecoder {
+	nativeFilterer, err := native.NewINativeHandlerFilterer(contract, nil)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to init native filterer"))
+	}
+
+	erc20Filterer, err := erc20.NewIERC20HandlerFilterer(contract, nil)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to init erc20 filterer"))
+	}
+
+	erc721Filterer, err := erc721.NewIERC721HandlerFilterer(contract, nil)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to init erc721 filterer"))
+	}
+
+	erc1155Filterer, err := erc1155.NewIERC1155HandlerFilterer(contract, nil)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to init erc1155 filterer"))
+	}
+
+	return []withdrawalDecoder{
+		{
+			name:  HandlerNative,
+			topic: mustEventTopic(native.INativeHandlerMetaData, "WithdrawnNative"),
+			parse: func(log ethtypes.Log) ([32]byte, error) {
+				e, err := nativeFilterer.ParseWithdrawnNative(log)
+				if err != nil {
+					return [32]byte{}, err
+				}
+				return e.OriginHash, nil
+			},
+		},
+		{
+			name:  HandlerERC20,
+			topic: mustEventTopic(erc20.IERC20HandlerMetaData, "WithdrawnERC20"),
+			parse: func(log ethtypes.Log) ([32]byte, error) {
+				e, err := erc20Filterer.ParseWithdrawnERC20(log)
+				if err != nil {
+					return [32]byte{}, err
+				}
+				return e.OriginHash, nil
+			},
+		},
+		{
+			name:  HandlerERC721,
+			topic: mustEventTopic(erc721.IERC721HandlerMetaData, "WithdrawnERC721"),
+			parse: func(log ethtypes.Log) ([32]byte, error) {
+				e, err := erc721Filterer.ParseWithdrawnERC721(log)
+				if err != nil {
+					return [32]byte{}, err
+				}
+				return e.OriginHash, nil
+			},
+		},
+		{
+			name:  HandlerERC1155,
+			topic: mustEventTopic(erc1155.IERC1155HandlerMetaData, "WithdrawnERC1155"),
+			parse: func(log ethtypes.Log) ([32]byte, error) {
+				e, err := erc1155Filterer.ParseWithdrawnERC1155(log)
+				if err != nil {
+					return [32]byte{}, err
+				}
+				return e.OriginHash, nil
+			},
+		},
+	}
+}
+
+func mustEventTopic(meta *bind.MetaData, event string) common.Hash {
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		panic(errors.Wrap(err, "failed to parse abi"))
+	}
+
+	e, ok := parsed.Events[event]
+	if !ok {
+		panic(errors.From(errors.New("event not found in abi"), logan.F{
+			"event": event,
+		}))
+	}
+
+	return e.ID
+}
@@ -2,20 +2,25 @@ package evm
 
 import (
 	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rarimo/horizon-svc/internal/config"
 	"github.com/rarimo/horizon-svc/internal/data"
 	"github.com/rarimo/horizon-svc/internal/data/redis"
 	"github.com/rarimo/horizon-svc/internal/services"
 	"github.com/rarimo/horizon-svc/internal/services/bridge_producer/producers"
+	"github.com/rarimo/horizon-svc/internal/services/bridge_producer/producers/cursorer"
 	"github.com/rarimo/horizon-svc/internal/services/bridge_producer/types"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
-	"gitlab.com/distributed_lab/running"
-	"strconv"
-	"sync"
-	"time"
 )
 
 const (
@@ -25,10 +30,28 @@ const (
 	HandlerERC1155 = "erc1155"
 )
 
+const (
+	defaultReorgDepth  = 128
+	defaultBlockWindow = 2000
+	receiptsBatchSize  = 100
+)
+
+// evmProducer scans all the withdrawal events of the bridge contract with a single
+// eth_getLogs request per blocks window, and keeps one cursor for the chain
 type evmProducer struct {
 	log       *logan.Entry
+	cli       *ethclient.Client
+	rpc       *rpc.Client
+	chain     string
+	contract  common.Address
+	decoders  map[common.Hash]withdrawalDecoder
+	cursorer  types.Cursorer
+	tracker   *blockTracker
 	publisher services.QPublisher
-	handlers  []Handler
+
+	confirmations  uint64
+	maxBlockWindow uint64
+	blockWindow    uint64
 }
 
 func New(
@@ -45,56 +68,335 @@ func New(
 		"rpc":   chain.Rpc,
 	}
 
-	cli, err := ethclient.Dial(chain.Rpc)
+	rpcCli, err := rpc.DialContext(context.Background(), chain.Rpc)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to connect to ethereum node", f))
 	}
 
-	initialCursor := producers.DefaultInitialCursor
-	if cfg != nil && cfg.SkipCatchup {
-		lastBlockHeight, err := cli.BlockNumber(context.Background())
-		if err != nil {
-			panic(errors.Wrap(err, "failed to get last block height", f))
+	cli := ethclient.NewClient(rpcCli)
+
+	initialCursor, err := legacyInitialCursor(context.Background(), kv, cursorKey)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to get legacy cursors", f))
+	}
+
+	if initialCursor == "" {
+		initialCursor = producers.DefaultInitialCursor
+
+		if cfg != nil && cfg.SkipCatchup {
+			lastBlockHeight, err := cli.BlockNumber(context.Background())
+			if err != nil {
+				panic(errors.Wrap(err, "failed to get last block height", f))
+			}
+
+			initialCursor = strconv.FormatUint(lastBlockHeight, 10)
 		}
+	}
 
-		initialCursor = strconv.FormatUint(lastBlockHeight, 10)
+	confirmations, reorgDepth, blockWindow := uint64(0), uint64(defaultReorgDepth), uint64(defaultBlockWindow)
+	if cfg != nil {
+		confirmations = cfg.Confirmations
+		if cfg.ReorgDepth != 0 {
+			reorgDepth = cfg.ReorgDepth
+		}
+		if cfg.BlockWindow != 0 {
+			blockWindow = cfg.BlockWindow
+		}
 	}
 
 	contract := common.HexToAddress(bridgeContract)
 
-	handlers := []Handler{
-		newNativeHandler(log, cli, chain.Name, kv, publisher, contract, cursorKey, initialCursor),
-		newERC20Handler(log, cli, chain.Name, kv, publisher, contract, cursorKey, initialCursor),
-		newERC721Handler(log, cli, chain.Name, kv, publisher, contract, cursorKey, initialCursor),
-		newERC1155Handler(log, cli, chain.Name, kv, publisher, contract, cursorKey, initialCursor),
+	decoders := make(map[common.Hash]withdrawalDecoder)
+	for _, decoder := range newWithdrawalDecoders(contract) {
+		decoders[decoder.topic] = decoder
 	}
 
+	log = log.WithField("who", chain.Name+"_evm_bridge_events_producer")
+
 	return &evmProducer{
-		log.WithField("who", chain.Name+"_evm_bridge_events_producer"),
-		publisher,
-		handlers,
+		log:            log,
+		cli:            cli,
+		rpc:            rpcCli,
+		chain:          chain.Name,
+		contract:       contract,
+		decoders:       decoders,
+		cursorer:       cursorer.NewCursorer(log, kv, cursorKey, initialCursor),
+		tracker:        newBlockTracker(log, cli, kv, cursorKey+"_blocks", confirmations+reorgDepth),
+		publisher:      publisher,
+		confirmations:  confirmations,
+		maxBlockWindow: blockWindow,
+		blockWindow:    blockWindow,
 	}
 }
 
-type Handler interface {
-	Run(ctx context.Context) error
-	Name() string
+// legacyInitialCursor returns the lowest of the cursors saved by the per-handler producers
+// used before, so the chain scanning continues from where they stopped. Handlers saved the
+// cursor only after their first event, so the missing ones are skipped, and the empty string
+// is returned if there are none of them.
+func legacyInitialCursor(ctx context.Context, kv *redis.KeyValueProvider, cursorKey string) (string, error) {
+	result := ""
+
+	for _, handler := range []string{HandlerNative, HandlerERC20, HandlerERC721, HandlerERC1155} {
+		cursor, err := kv.Get(ctx, cursorKey+"_"+handler)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get cursor", logan.F{
+				"handler": handler,
+			})
+		}
+
+		if cursor == nil || cursor.Value == "" {
+			continue
+		}
+
+		if result == "" || types.NewCursor(cursor.Value).Uint() < types.NewCursor(result).Uint() {
+			result = cursor.Value
+		}
+	}
+
+	return result, nil
 }
 
 func (p *evmProducer) Run(ctx context.Context) error {
-	wg := &sync.WaitGroup{}
+	start, err := p.cursorer.GetStartCursor(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get start cursor")
+	}
 
-	for _, handler := range p.handlers {
-		wg.Add(1)
+	head, err := p.cli.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get last block number")
+	}
+
+	if head < p.confirmations {
+		return nil
+	}
 
-		p.log.WithField("handler", handler.Name()).Info("starting handler")
+	rewind, err := p.tracker.CheckReorg(ctx, p.publisher)
+	if err != nil {
+		return errors.Wrap(err, "failed to check reorg")
+	}
 
-		go func(handler Handler) {
-			defer wg.Done()
-			running.WithBackOff(ctx, p.log, handler.Name()+"_handler", handler.Run, 5*time.Second, 10*time.Second, time.Minute)
-		}(handler)
+	if rewind != nil && *rewind < start.Uint() {
+		start = start.SetUint64(*rewind)
+		if err := p.cursorer.SetStartCursor(ctx, start); err != nil {
+			return errors.Wrap(err, "failed to rewind cursor")
+		}
+	}
+
+	end := head - p.confirmations
+
+	for from := start.Uint(); from <= end; {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "died by context")
+		}
+
+		to := from + p.blockWindow - 1
+		if to > end {
+			to = end
+		}
+
+		logs, err := p.filterLogs(ctx, from, to)
+		if err != nil {
+			if isTooManyResultsError(err) && p.blockWindow > 1 {
+				p.blockWindow /= 2
+				p.log.WithError(err).WithField("block_window", p.blockWindow).Warn("shrinking block window")
+				continue
+			}
+
+			return errors.Wrap(err, "failed to filter logs", logan.F{
+				"from": from,
+				"to":   to,
+			})
+		}
+
+		if err := p.processLogs(ctx, head, logs); err != nil {
+			return errors.Wrap(err, "failed to process logs", logan.F{
+				"from": from,
+				"to":   to,
+			})
+		}
+
+		if err := p.tracker.Checkpoint(ctx, head, to); err != nil {
+			return errors.Wrap(err, "failed to checkpoint scanned block")
+		}
+
+		if err := p.cursorer.SetStartCursor(ctx, start.SetUint64(to+1)); err != nil {
+			return errors.Wrap(err, "failed to set cursor")
+		}
+
+		from = to + 1
+
+		// restoring the window gradually after it was shrunk
+		if p.blockWindow < p.maxBlockWindow {
+			p.blockWindow *= 2
+			if p.blockWindow > p.maxBlockWindow {
+				p.blockWindow = p.maxBlockWindow
+			}
+		}
 	}
-	wg.Wait()
 
 	return nil
 }
+
+func (p *evmProducer) filterLogs(ctx context.Context, from, to uint64) ([]ethtypes.Log, error) {
+	topics := make([]common.Hash, 0, len(p.decoders))
+	for topic := range p.decoders {
+		topics = append(topics, topic)
+	}
+
+	p.log.WithFields(logan.F{
+		"from": from,
+		"to":   to,
+	}).Debug("filtering logs")
+
+	return p.cli.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{p.contract},
+		Topics:    [][]common.Hash{topics},
+	})
+}
+
+func (p *evmProducer) processLogs(ctx context.Context, head uint64, logs []ethtypes.Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	receipts, err := p.getReceipts(ctx, logs)
+	if err != nil {
+		return errors.Wrap(err, "failed to get receipts")
+	}
+
+	messages := make([]msgs.Message, 0, len(logs))
+
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) == 0 {
+			continue
+		}
+
+		decoder, ok := p.decoders[log.Topics[0]]
+		if !ok {
+			continue
+		}
+
+		fields := logan.F{
+			"handler":   decoder.name,
+			"tx_hash":   log.TxHash,
+			"tx_index":  log.TxIndex,
+			"log_index": log.Index,
+		}
+
+		origin, err := decoder.parse(log)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse log", fields)
+		}
+
+		p.log.WithFields(fields).Debug("got event")
+
+		msg := logToWithdrawal(log, origin, receipts[log.TxHash])
+		messages = append(messages, msg.Message())
+		p.tracker.Track(head, log.BlockNumber, log.BlockHash, msg)
+	}
+
+	if err := p.publisher.PublishMsgs(ctx, messages...); err != nil {
+		return errors.Wrap(err, "failed to publish messages")
+	}
+
+	return nil
+}
+
+func (p *evmProducer) getReceipts(ctx context.Context, logs []ethtypes.Log) (map[common.Hash]*ethtypes.Receipt, error) {
+	receipts := make(map[common.Hash]*ethtypes.Receipt)
+
+	var hashes []common.Hash
+	for _, log := range logs {
+		if _, ok := receipts[log.TxHash]; ok {
+			continue
+		}
+
+		receipts[log.TxHash] = nil
+		hashes = append(hashes, log.TxHash)
+	}
+
+	for len(hashes) > 0 {
+		batchSize := receiptsBatchSize
+		if len(hashes) < batchSize {
+			batchSize = len(hashes)
+		}
+
+		batch := make([]rpc.BatchElem, batchSize)
+		results := make([]*ethtypes.Receipt, batchSize)
+
+		for i, hash := range hashes[:batchSize] {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{hash},
+				Result: &results[i],
+			}
+		}
+
+		if err := p.rpc.BatchCallContext(ctx, batch); err != nil {
+			return nil, errors.Wrap(err, "failed to batch receipts")
+		}
+
+		for i, elem := range batch {
+			if elem.Error != nil {
+				return nil, errors.Wrap(elem.Error, "failed to get receipt", logan.F{
+					"tx_hash": hashes[i],
+				})
+			}
+
+			if results[i] == nil {
+				return nil, errors.From(errors.New("receipt not found"), logan.F{
+					"tx_hash": hashes[i],
+				})
+			}
+
+			receipts[hashes[i]] = results[i]
+		}
+
+		hashes = hashes[batchSize:]
+	}
+
+	return receipts, nil
+}
+
+// tooManyResultsMessages are the errors of the providers refusing to return logs because the
+// range is too wide or there are too many of them
+var tooManyResultsMessages = []string{
+	"query returned more than",    // infura, polygon
+	"log response size exceeded",  // alchemy, cloudflare
+	"exceed maximum block range",  // bsc
+	"block range is too wide",     // ankr
+	"block range limit exceeded",  // chainstack
+	"eth_getlogs is limited to a", // quicknode
+	"query exceeds max results",   // erigon
+	"too many logs",               // nethermind
+}
+
+// limitExceededCode is the code of the JSON-RPC error returned when the request exceeds the
+// node limits (EIP-1474)
+const limitExceededCode = -32005
+
+// isTooManyResultsError checks whether node refused to return logs because the range is too
+// wide or there are too many of them. Only the errors returned by node are checked, so the
+// transport failures like timeouts are retried with the same window.
+func isTooManyResultsError(err error) bool {
+	rpcErr, ok := errors.Cause(err).(rpc.Error)
+	if !ok {
+		return false
+	}
+
+	if rpcErr.ErrorCode() == limitExceededCode {
+		return true
+	}
+
+	msg := strings.ToLower(rpcErr.Error())
+	for _, substr := range tooManyResultsMessages {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+
+	return false
+}
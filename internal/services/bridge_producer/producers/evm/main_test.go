@@ -0,0 +1,41 @@
+package evm
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+type rpcError struct {
+	code int
+	msg  string
+}
+
+func (e rpcError) Error() string  { return e.msg }
+func (e rpcError) ErrorCode() int { return e.code }
+
+func TestIsTooManyResultsError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"limit exceeded code", rpcError{-32005, "query returned more than 10000 results"}, true},
+		{"provider message", rpcError{-32000, "exceed maximum block range: 5000"}, true},
+		{"wrapped provider message", errors.Wrap(rpcError{-32602, "Log response size exceeded."}, "failed"), true},
+		{"other node error", rpcError{-32000, "header not found"}, false},
+		{"node timeout", rpcError{-32000, "request timeout"}, false},
+		{"context deadline", context.DeadlineExceeded, false},
+		{"network timeout", &net.OpError{Op: "read", Err: errors.New("i/o timeout")}, false},
+		{"message of transport error", errors.New("query returned more than 10000 results"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, isTooManyResultsError(c.err))
+		})
+	}
+}
@@ -3,6 +3,8 @@ package solana
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	bin "github.com/gagliardetto/binary"
 	"github.com/olegfomenko/solana-go"
@@ -22,6 +24,12 @@ import (
 
 const (
 	DataInstructionCodeIndex = 0
+	signaturesPageLimit      = 1000
+	// signaturesPagesPerPass limits the number of signatures pages processed in one run
+	signaturesPagesPerPass = 10
+	// getTransactionAttempts limits the number of attempts to get the transaction before it is skipped
+	getTransactionAttempts = 3
+	getTransactionBackOff  = time.Second
 )
 
 type solanaProducer struct {
@@ -31,6 +39,11 @@ type solanaProducer struct {
 	chain     string
 	programId solana.PublicKey
 	cli       *rpc.Client
+
+	// pages are the signatures the pages not processed yet are requested before, ordered from the
+	// newest page to the oldest one. They are kept between the runs, so the history is walked once
+	// for the whole catch-up.
+	pages []solana.Signature
 }
 
 func New(
@@ -50,81 +63,187 @@ func New(
 	cli := rpc.New(chain.Rpc)
 	programId := solana.PublicKeyFromBytes(hexutil.MustDecode(bridgeContract))
 
-	initialCursor := solana.Signature{}.String()
+	initialCursor := types.NewCursor("").SetSlotSignature(0, solana.Signature{}).String()
 	if cfg != nil && cfg.SkipCatchup {
-		signatures, err := cli.GetSignaturesForAddress(context.Background(), programId)
+		limit := 1
+		signatures, err := cli.GetSignaturesForAddressWithOpts(context.Background(), programId, &rpc.GetSignaturesForAddressOpts{
+			Limit:      &limit,
+			Commitment: rpc.CommitmentFinalized,
+		})
 		if err != nil {
 			panic(errors.Wrap(err, "failed to get last signatures", f))
 		}
 
 		if len(signatures) > 0 {
-			initialCursor = signatures[len(signatures)-1].Signature.String()
+			initialCursor = types.NewCursor("").SetSlotSignature(signatures[0].Slot, signatures[0].Signature).String()
 		}
 	}
 
 	return &solanaProducer{
-		log,
-		cursorer.NewCursorer(log, kv, cursorKey, initialCursor),
-		publisher,
-		chain.Name,
-		programId,
-		cli,
+		log:       log,
+		cursorer:  cursorer.NewCursorer(log, kv, cursorKey, initialCursor),
+		publisher: publisher,
+		chain:     chain.Name,
+		programId: programId,
+		cli:       cli,
 	}
 }
 
+// Run ingests the program transactions finalized after the cursor in the slot order. As the node
+// returns signatures only backwards in time, the pages of them are looked up from the newest one down
+// to the cursor first, and then up to signaturesPagesPerPass pages are processed from the oldest one,
+// moving the cursor after each transaction, so the long catch-up is made in the bounded passes. The
+// pages left are processed by the next runs without looking them up again.
 func (p *solanaProducer) Run(ctx context.Context) error {
 	start, err := p.cursorer.GetStartCursor(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to get start cursor")
 	}
 
-	p.log.Info(fmt.Sprintf("Catchupping history from %s", start.Value))
+	startSlot, until := start.SlotSignature()
 
-	for {
-		if err = ctx.Err(); err != nil {
-			return errors.Wrap(err, "died by context")
+	if len(p.pages) == 0 {
+		p.pages, err = p.getPagesUntil(ctx, until)
+		if err != nil {
+			return errors.Wrap(err, "failed to get signatures pages", logan.F{
+				"until": until.String(),
+			})
 		}
 
-		signatures, err := p.cli.GetSignaturesForAddressWithOpts(ctx, p.programId, &rpc.GetSignaturesForAddressOpts{
-			Before: start.Signature(),
-		})
+		if len(p.pages) == 0 {
+			return nil
+		}
+	}
+
+	p.log.WithFields(logan.F{
+		"from_slot": startSlot,
+		"pages":     len(p.pages),
+	}).Info("ingesting new transactions")
+
+	for processed := 0; len(p.pages) > 0 && processed < signaturesPagesPerPass; processed++ {
+		before := p.pages[len(p.pages)-1]
+
+		signatures, err := p.getSignaturesPage(ctx, before, until)
 		if err != nil {
-			return errors.Wrap(err, "failed to get signatures")
+			return errors.Wrap(err, "failed to get signatures", logan.F{
+				"before": before.String(),
+				"until":  until.String(),
+			})
+		}
+
+		// the newest page grows with the new transactions, so if it became full, the pages are looked
+		// up again by the next run instead of skipping the transactions older than the returned ones
+		if before.IsZero() && len(signatures) == signaturesPageLimit {
+			p.pages = nil
+			return nil
 		}
 
-		for _, sig := range signatures {
+		for j := len(signatures) - 1; j >= 0; j-- {
+			if err = ctx.Err(); err != nil {
+				return errors.Wrap(err, "died by context")
+			}
+
+			sig := signatures[j]
+
 			if err = p.processTransaction(ctx, sig.Signature); err != nil {
-				return errors.Wrap(err, "failed to process transaction")
+				return errors.Wrap(err, "failed to process transaction", logan.F{
+					"signature": sig.Signature.String(),
+					"slot":      sig.Slot,
+				})
+			}
+
+			if err = p.cursorer.SetStartCursor(ctx, start.SetSlotSignature(sig.Slot, sig.Signature)); err != nil {
+				return errors.Wrap(err, "failed to set start cursor")
 			}
+
+			until = sig.Signature
+		}
+
+		p.pages = p.pages[:len(p.pages)-1]
+	}
+
+	return nil
+}
+
+// getPagesUntil looks up the pages of the program transactions newer than the given signature and
+// returns the signatures they are requested before, ordered from the newest page to the oldest one.
+// The newest page is requested before the empty signature and every next one before the oldest
+// signature of the previous page.
+func (p *solanaProducer) getPagesUntil(ctx context.Context, until solana.Signature) ([]solana.Signature, error) {
+	var (
+		pages  []solana.Signature
+		before solana.Signature
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "died by context")
+		}
+
+		signatures, err := p.getSignaturesPage(ctx, before, until)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get signatures page", logan.F{
+				"before": before.String(),
+			})
 		}
 
 		if len(signatures) == 0 {
-			return nil
+			return pages, nil
 		}
 
-		if err = p.cursorer.SetStartCursor(ctx, start.SetSignature(signatures[len(signatures)-1].Signature)); err != nil {
-			return errors.Wrap(err, "failed to set start cursor")
+		pages = append(pages, before)
+
+		if len(signatures) < signaturesPageLimit {
+			return pages, nil
 		}
+
+		before = signatures[len(signatures)-1].Signature
 	}
 }
 
-func (p *solanaProducer) processTransaction(ctx context.Context, sig solana.Signature) error {
-	out, err := p.cli.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
-		Encoding: solana.EncodingBase64,
+// getSignaturesPage returns finalized signatures of the program transactions between the given ones,
+// ordered from the newest to the oldest
+func (p *solanaProducer) getSignaturesPage(ctx context.Context, before, until solana.Signature) ([]*rpc.TransactionSignature, error) {
+	limit := signaturesPageLimit
+
+	return p.cli.GetSignaturesForAddressWithOpts(ctx, p.programId, &rpc.GetSignaturesForAddressOpts{
+		Limit:      &limit,
+		Before:     before,
+		Until:      until,
+		Commitment: rpc.CommitmentFinalized,
 	})
+}
+
+// processTransaction publishes withdrawals of the transaction. Transactions which could not be
+// fetched after getTransactionAttempts or decoded are skipped with a warning, so they do not stall
+// the chain ingestion.
+func (p *solanaProducer) processTransaction(ctx context.Context, sig solana.Signature) error {
+	out, err := p.getTransaction(ctx, sig)
 	if err != nil {
-		return errors.Wrap(err, "error getting transaction from solana")
+		if ctx.Err() != nil {
+			return errors.Wrap(err, "died by context")
+		}
+
+		p.log.WithError(err).WithField("signature", sig.String()).Warn("skipping transaction which failed to get")
+		return nil
 	}
 
 	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(out.Transaction.GetBinary()))
 	if err != nil {
-		return errors.Wrap(err, "error decoding transaction")
+		p.log.WithError(err).WithField("signature", sig.String()).Warn("skipping transaction which failed to decode")
+		return nil
 	}
 
+	success := out.Meta != nil && out.Meta.Err == nil
+
 	accounts := tx.Message.AccountKeys
 	messages := make([]msgs.Message, 0)
 
 	for i, instruction := range tx.Message.Instructions {
+		if int(instruction.ProgramIDIndex) >= len(accounts) || len(instruction.Data) == 0 {
+			continue
+		}
+
 		if accounts[instruction.ProgramIDIndex] == p.programId {
 			switch bridge.Instruction(instruction.Data[DataInstructionCodeIndex]) {
 			case bridge.InstructionWithdrawNative, bridge.InstructionWithdrawFT, bridge.InstructionWithdrawNFT:
@@ -137,7 +256,7 @@ func (p *solanaProducer) processTransaction(ctx context.Context, sig solana.Sign
 				messages = append(messages, msgs.WithdrawalMsg{
 					Origin:  hexutil.Encode(hash[:]),
 					Hash:    sig.String(),
-					Success: out.Meta.Err == nil,
+					Success: success,
 				}.Message())
 			default:
 				continue
@@ -145,10 +264,49 @@ func (p *solanaProducer) processTransaction(ctx context.Context, sig solana.Sign
 		}
 	}
 
+	if len(messages) == 0 {
+		return nil
+	}
+
 	if err = p.publisher.PublishMsgs(ctx, messages...); err != nil {
 		return errors.Wrap(err, "error publishing messages")
 	}
 
 	return nil
+}
+
+// getTransaction requests the transaction up to getTransactionAttempts times, as the node may fail
+// to return it or return nothing for the signature it has just listed
+func (p *solanaProducer) getTransaction(ctx context.Context, sig solana.Signature) (*rpc.GetTransactionResult, error) {
+	var err error
+
+	for attempt := 1; attempt <= getTransactionAttempts; attempt++ {
+		var out *rpc.GetTransactionResult
+
+		out, err = p.cli.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
+			Encoding:   solana.EncodingBase64,
+			Commitment: rpc.CommitmentFinalized,
+		})
+		if err == nil && (out == nil || out.Transaction == nil) {
+			err = errors.New("transaction not found")
+		}
 
+		if err == nil {
+			return out, nil
+		}
+
+		if attempt == getTransactionAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(getTransactionBackOff * time.Duration(attempt)):
+		}
+	}
+
+	return nil, errors.Wrap(err, "failed to get transaction", logan.F{
+		"attempts": getTransactionAttempts,
+	})
 }
@@ -6,8 +6,11 @@ import (
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
 	"strconv"
+	"strings"
 )
 
+const slotSignatureSeparator = ":"
+
 type Producer interface {
 	Run(ctx context.Context) error
 }
@@ -43,6 +46,12 @@ func (c *Cursor) SetSignature(value solana.Signature) *Cursor {
 	return NewCursor(value.String())
 }
 
+// SetSlotSignature sets cursor to the `<slot>:<signature>` value, so the position in the chain
+// is known without requesting the transaction by its signature
+func (c *Cursor) SetSlotSignature(slot uint64, value solana.Signature) *Cursor {
+	return NewCursor(strconv.FormatUint(slot, 10) + slotSignatureSeparator + value.String())
+}
+
 func (c *Cursor) String() string {
 	return c.Value
 }
@@ -76,3 +85,21 @@ func (c *Cursor) Signature() solana.Signature {
 
 	return res
 }
+
+// SlotSignature parses cursor set by the SetSlotSignature. Cursor with only the signature
+// is parsed with the zero slot.
+func (c *Cursor) SlotSignature() (uint64, solana.Signature) {
+	rawSlot, rawSignature, found := strings.Cut(c.Value, slotSignatureSeparator)
+	if !found {
+		return 0, c.Signature()
+	}
+
+	slot, err := strconv.ParseUint(rawSlot, 10, 64)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to parse slot", logan.F{
+			"value": c.Value,
+		}))
+	}
+
+	return slot, NewCursor(rawSignature).Signature()
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"github.com/rarimo/horizon-svc/internal/core"
 	"time"
 
@@ -39,6 +40,13 @@ type collectionIndexer struct {
 	saver        *TokenmanagerSaver
 }
 
+func (p *collectionIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	fork.saver = p.saver.fork()
+	return &fork
+}
+
 func (p *collectionIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
 	for _, raw := range batch {
 		if err := p.handle(ctx, raw); err != nil {
@@ -52,19 +60,28 @@ func (p *collectionIndexer) Handle(ctx context.Context, batch []msgs.Message) er
 }
 
 func (p *collectionIndexer) handle(ctx context.Context, raw msgs.Message) error {
-	switch raw.Type() {
-	case msgs.MessageTypeCollectionCreated:
-		return p.handleCollectionCreated(ctx, raw.MustCollectionCreatedMessage())
-	case msgs.MessageTypeCollectionRemoved:
-		return p.handleCollectionRemoved(ctx, raw.MustCollectionRemovedMessage())
-	case msgs.MessageTypeCollectionDataCreated:
-		return p.handleCollectionDataCreated(ctx, raw.MustCollectionDataCreatedMessage())
-	case msgs.MessageTypeCollectionDataUpdated:
-		return p.handleCollectionDataUpdated(ctx, raw.MustCollectionDataUpdatedMessage())
-	case msgs.MessageTypeCollectionDataRemoved:
-		return p.handleCollectionDataRemoved(ctx, raw.MustCollectionDataRemovedMessage())
+	decoded, err := raw.DecodeAny()
+	if err != nil {
+		if errors.Cause(err) == msgs.ErrUnknownMessageType {
+			p.log.WithField("type", raw.Type()).Warn("unknown message type")
+			return nil
+		}
+		return errors.Wrap(err, "failed to decode message", raw.Fields())
+	}
+
+	switch msg := decoded.(type) {
+	case msgs.CollectionCreatedMessage:
+		return p.handleCollectionCreated(ctx, msg)
+	case msgs.CollectionRemovedMessage:
+		return p.handleCollectionRemoved(ctx, msg)
+	case msgs.CollectionDataCreatedMessage:
+		return p.handleCollectionDataCreated(ctx, msg)
+	case msgs.CollectionDataUpdatedMessage:
+		return p.handleCollectionDataUpdated(ctx, msg)
+	case msgs.CollectionDataRemovedMessage:
+		return p.handleCollectionDataRemoved(ctx, msg)
 	default:
-		p.log.WithField("type", raw.Type()).Warn("unknown message type")
+		p.log.WithField("type", raw.Type()).Warn("unexpected message type")
 		return nil
 	}
 }
@@ -75,8 +92,30 @@ func (p *collectionIndexer) handleCollectionCreated(ctx context.Context, msg msg
 		return errors.Wrap(err, "failed to get collection")
 	}
 
-	_, err = p.saver.SaveCollection(ctx, *collection)
-	return err
+	if _, err := p.saver.SaveCollection(ctx, *collection); err != nil {
+		return errors.Wrap(err, "failed to save collection", logan.F{
+			"index": msg.Index,
+		})
+	}
+
+	metadata, err := json.Marshal(collection.Meta)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal collection metadata", logan.F{
+			"index": msg.Index,
+		})
+	}
+
+	err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+		typ:        msgs.MessageTypeCollectionCreated,
+		source:     msg.EventSource,
+		collection: msg.Index,
+		details: tokenmanagerEventDetails{
+			Metadata: metadata,
+		},
+	})
+	return errors.Wrap(err, "failed to append collection event", logan.F{
+		"index": msg.Index,
+	})
 }
 
 func (p *collectionIndexer) handleCollectionRemoved(ctx context.Context, msg msgs.CollectionRemovedMessage) error {
@@ -88,6 +127,12 @@ func (p *collectionIndexer) handleCollectionRemoved(ctx context.Context, msg msg
 			})
 		}
 
+		if collection == nil {
+			return errors.From(errors.New("collection not found in storage"), logan.F{
+				"index": msg.Index,
+			})
+		}
+
 		err = p.storage.CollectionChainMappingQ().DeleteByCollectionCtx(ctx, collection.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to delete collection data for collection", logan.F{
@@ -95,14 +140,30 @@ func (p *collectionIndexer) handleCollectionRemoved(ctx context.Context, msg msg
 			})
 		}
 
-		err = p.storage.CollectionQ().DeleteCtx(ctx, collection)
-		if err != nil {
-			return errors.Wrap(err, "failed to delete collection", logan.F{
-				"collection": collection.ID,
-			})
+		// collection is kept, so the items and the history of it are still resolvable
+		if !collection.DeletedAt.Valid {
+			now := time.Now().UTC()
+			collection.DeletedAt = sql.NullTime{Time: now, Valid: true}
+			collection.UpdatedAt = now
+
+			if err := p.storage.CollectionQ().UpdateCtx(ctx, collection); err != nil {
+				return errors.Wrap(err, "failed to mark collection as deleted", logan.F{
+					"collection": collection.ID,
+				})
+			}
 		}
 
-		return nil
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:        msgs.MessageTypeCollectionRemoved,
+			source:     msg.EventSource,
+			collection: msg.Index,
+			details: tokenmanagerEventDetails{
+				Metadata: json.RawMessage(collection.Metadata),
+			},
+		})
+		return errors.Wrap(err, "failed to append collection event", logan.F{
+			"index": msg.Index,
+		})
 	})
 }
 
@@ -132,31 +193,42 @@ func (p *collectionIndexer) handleCollectionDataCreated(ctx context.Context, msg
 		return errors.Wrap(err, "failed to get collection data")
 	}
 
-	now := time.Now().UTC()
-	err = p.storage.CollectionChainMappingQ().InsertCtx(ctx, &data.CollectionChainMapping{
-		Collection: collection.ID,
-		Network:    network.ID,
-		Address:    []byte(coreCollection.Index.Address),
-		TokenType: sql.NullInt64{
-			Int64: int64(coreCollection.TokenType),
-			Valid: true,
-		},
-		Wrapped: sql.NullBool{
-			Bool:  coreCollection.Wrapped,
-			Valid: true,
-		},
-		Decimals: sql.NullInt64{
-			Int64: int64(coreCollection.Decimals),
-			Valid: true,
-		},
-		CreatedAt: now,
-		UpdatedAt: now,
-	})
-	if err != nil {
-		return errors.Wrap(err, "failed to insert collection data")
-	}
+	return p.storage.Transaction(func() error {
+		now := time.Now().UTC()
+		err := p.storage.CollectionChainMappingQ().InsertCtx(ctx, &data.CollectionChainMapping{
+			Collection: collection.ID,
+			Network:    network.ID,
+			Address:    []byte(coreCollection.Index.Address),
+			TokenType: sql.NullInt64{
+				Int64: int64(coreCollection.TokenType),
+				Valid: true,
+			},
+			Wrapped: sql.NullBool{
+				Bool:  coreCollection.Wrapped,
+				Valid: true,
+			},
+			Decimals: sql.NullInt64{
+				Int64: int64(coreCollection.Decimals),
+				Valid: true,
+			},
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to insert collection data")
+		}
 
-	return nil
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:        msgs.MessageTypeCollectionDataCreated,
+			source:     msg.EventSource,
+			collection: msg.CollectionIndex,
+			chain:      msg.Chain,
+			details:    collectionDataEventDetails(*coreCollection),
+		})
+		return errors.Wrap(err, "failed to append collection event", logan.F{
+			"index": msg.CollectionIndex,
+		})
+	})
 }
 
 func (p *collectionIndexer) handleCollectionDataUpdated(ctx context.Context, msg msgs.CollectionDataUpdatedMessage) error {
@@ -214,14 +286,25 @@ func (p *collectionIndexer) handleCollectionDataUpdated(ctx context.Context, msg
 		Valid: true,
 	}
 
-	if err := p.storage.CollectionChainMappingQ().UpsertCtx(ctx, ccm); err != nil {
-		return errors.Wrap(err, "failed to update collection data", logan.F{
-			"collection": collection.ID,
-			"network":    network.ID,
-		})
-	}
+	return p.storage.Transaction(func() error {
+		if err := p.storage.CollectionChainMappingQ().UpsertCtx(ctx, ccm); err != nil {
+			return errors.Wrap(err, "failed to update collection data", logan.F{
+				"collection": collection.ID,
+				"network":    network.ID,
+			})
+		}
 
-	return nil
+		err := appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:        msgs.MessageTypeCollectionDataUpdated,
+			source:     msg.EventSource,
+			collection: msg.CollectionIndex,
+			chain:      msg.Chain,
+			details:    collectionDataEventDetails(*collectionData),
+		})
+		return errors.Wrap(err, "failed to append collection event", logan.F{
+			"index": msg.CollectionIndex,
+		})
+	})
 }
 
 func (p *collectionIndexer) handleCollectionDataRemoved(ctx context.Context, msg msgs.CollectionDataRemovedMessage) error {
@@ -261,12 +344,25 @@ func (p *collectionIndexer) handleCollectionDataRemoved(ctx context.Context, msg
 		return nil
 	}
 
-	if err := p.storage.CollectionChainMappingQ().DeleteCtx(ctx, ccm); err != nil {
-		return errors.Wrap(err, "failed to delete collection data", logan.F{
-			"collection": collection.ID,
-			"network":    network.ID,
-		})
-	}
+	return p.storage.Transaction(func() error {
+		if err := p.storage.CollectionChainMappingQ().DeleteCtx(ctx, ccm); err != nil {
+			return errors.Wrap(err, "failed to delete collection data", logan.F{
+				"collection": collection.ID,
+				"network":    network.ID,
+			})
+		}
 
-	return nil
+		err := appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:        msgs.MessageTypeCollectionDataRemoved,
+			source:     msg.EventSource,
+			collection: msg.CollectionIndex,
+			chain:      msg.Chain,
+			details: tokenmanagerEventDetails{
+				Address: string(ccm.Address),
+			},
+		})
+		return errors.Wrap(err, "failed to append collection event", logan.F{
+			"index": msg.CollectionIndex,
+		})
+	})
 }
@@ -34,12 +34,21 @@ type confirmationsIndexer struct {
 	storage    data.Storage
 }
 
-func (p *confirmationsIndexer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	confirmations := make([]data.Confirmation, 0, 10*len(msgs))
-	confirmedTransferIDs := make([]string, 0, 10*len(msgs))
+func (p *confirmationsIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	return &fork
+}
+
+func (p *confirmationsIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
+	confirmations := make([]data.Confirmation, 0, 10*len(batch))
+	statusChanges := make([]data.TransferStatusChange, 0, 10*len(batch))
 
-	for _, msg := range msgs {
-		cmsg := msg.MustConfirmationOpMessage()
+	for _, msg := range batch {
+		var cmsg msgs.ConfirmationOpMsg
+		if err := msg.Decode(&cmsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
 
 		confirmation, err := p.rarimocore.GetConfirmation(ctx, cmsg.ConfirmationID)
 		if err != nil {
@@ -47,7 +56,8 @@ func (p *confirmationsIndexer) Handle(ctx context.Context, msgs []msgs.Message)
 		}
 
 		for _, transferIndex := range confirmation.Indexes {
-			confirmedTransferIDs = append(confirmedTransferIDs, transferIndex)
+			statusChanges = append(statusChanges,
+				newStatusChange(transferIndex, rarimocore.OpStatus_SIGNED, cmsg.TransactionHash, cmsg.BlockTime))
 			confirmations = append(confirmations, data.Confirmation{
 				TransferIndex:     []byte(transferIndex),
 				RarimoTransaction: data.MustDBHash(cmsg.TransactionHash),
@@ -56,15 +66,11 @@ func (p *confirmationsIndexer) Handle(ctx context.Context, msgs []msgs.Message)
 		}
 	}
 
-	return p.storage.Transaction(func() error {
-		err := p.storage.TransferQ().SetStatusByIndexCtx(ctx, int(rarimocore.OpStatus_SIGNED), confirmedTransferIDs...)
-		if err != nil {
-			return errors.Wrap(err, "failed to set status for confirmed transfers", logan.F{
-				"transfer_ids": confirmedTransferIDs,
-			})
-		}
+	err := setOperationsStatus(ctx, p.storage, rarimocore.OpStatus_SIGNED, statusChanges, func() error {
+		return errors.Wrap(p.storage.ConfirmationQ().InsertBatchCtx(ctx, confirmations...), "failed to insert confirmations")
+	})
 
-		err = p.storage.ConfirmationQ().InsertBatchCtx(ctx, confirmations...)
-		return errors.Wrap(err, "failed to insert confirmations")
+	return errors.Wrap(err, "failed to set status for confirmed transfers", logan.F{
+		"changes": len(statusChanges),
 	})
 }
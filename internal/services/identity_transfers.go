@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/rarimo/horizon-svc/internal/data"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+func isIdentityOperation(opType rarimocore.OpType) bool {
+	switch opType {
+	case rarimocore.OpType_IDENTITY_DEFAULT_TRANSFER,
+		rarimocore.OpType_IDENTITY_AGGREGATED_TRANSFER,
+		rarimocore.OpType_IDENTITY_GIST_TRANSFER,
+		rarimocore.OpType_IDENTITY_STATE_TRANSFER:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *transfersIndexer) makeIdentityTransfer(ctx context.Context, txHash string, operation rarimocore.Operation) (*data.IdentityTransfer, error) {
+	status, err := p.getOperationStatus(ctx, operation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get operation status", logan.F{
+			"operation_index": operation.Index,
+		})
+	}
+
+	if operation.Details == nil {
+		return nil, errors.New("operation details are empty")
+	}
+
+	now := time.Now().UTC()
+	transfer := data.IdentityTransfer{
+		OperationIndex:    []byte(operation.Index),
+		Type:              int(operation.OperationType),
+		Status:            int(status),
+		RarimoTx:          data.MustDBHash(txHash),
+		RarimoTxTimestamp: time.Unix(int64(operation.Timestamp), 0),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	switch operation.OperationType {
+	case rarimocore.OpType_IDENTITY_DEFAULT_TRANSFER:
+		var details rarimocore.IdentityDefaultTransfer
+		if err := proto.Unmarshal(operation.Details.Value, &details); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal identity default transfer details")
+		}
+
+		transfer.Chain = details.Chain
+		transfer.Contract = details.Contract
+		transfer.IdentityID = nullString(details.Id)
+		transfer.ReplacedStateHash = nullString(details.ReplacedStateHash)
+		transfer.ReplacedGistHash = nullString(details.ReplacedGISTHash)
+
+		err = setIdentityState(&transfer, details.StateHash, details.StateCreatedAtTimestamp, details.StateCreatedAtBlock)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set identity state")
+		}
+
+		err = setIdentityGIST(&transfer, details.GISTHash, details.GISTCreatedAtTimestamp, details.GISTCreatedAtBlock)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set identity GIST")
+		}
+	case rarimocore.OpType_IDENTITY_STATE_TRANSFER:
+		var details rarimocore.IdentityStateTransfer
+		if err := proto.Unmarshal(operation.Details.Value, &details); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal identity state transfer details")
+		}
+
+		transfer.Chain = details.Chain
+		transfer.Contract = details.Contract
+		transfer.IdentityID = nullString(details.Id)
+		transfer.ReplacedStateHash = nullString(details.ReplacedStateHash)
+
+		err = setIdentityState(&transfer, details.StateHash, details.StateCreatedAtTimestamp, details.StateCreatedAtBlock)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set identity state")
+		}
+	case rarimocore.OpType_IDENTITY_GIST_TRANSFER:
+		var details rarimocore.IdentityGISTTransfer
+		if err := proto.Unmarshal(operation.Details.Value, &details); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal identity GIST transfer details")
+		}
+
+		transfer.Chain = details.Chain
+		transfer.Contract = details.Contract
+		transfer.ReplacedGistHash = nullString(details.ReplacedGISTHash)
+
+		err = setIdentityGIST(&transfer, details.GISTHash, details.GISTCreatedAtTimestamp, details.GISTCreatedAtBlock)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set identity GIST")
+		}
+	case rarimocore.OpType_IDENTITY_AGGREGATED_TRANSFER:
+		var details rarimocore.IdentityAggregatedTransfer
+		if err := proto.Unmarshal(operation.Details.Value, &details); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal identity aggregated transfer details")
+		}
+
+		transfer.Chain = details.Chain
+		transfer.Contract = details.Contract
+		transfer.GistHash = nullString(details.GISTHash)
+		transfer.StateRootHash = nullString(details.StateRootHash)
+		// aggregated transfer carries the single timestamp of the state root
+		transfer.StateCreatedAt = sql.NullTime{
+			Time:  time.Unix(int64(details.Timestamp), 0).UTC(),
+			Valid: details.Timestamp != 0,
+		}
+	default:
+		return nil, errors.From(errors.New("not an identity operation"), logan.F{
+			"type": operation.OperationType.String(),
+		})
+	}
+
+	return &transfer, nil
+}
+
+func setIdentityState(transfer *data.IdentityTransfer, hash, timestamp, block string) error {
+	var err error
+
+	transfer.StateHash = nullString(hash)
+
+	transfer.StateCreatedAt, err = parseNullUnixTime(timestamp)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse state creation timestamp")
+	}
+
+	transfer.StateCreatedAtBlock, err = parseNullInt64(block)
+	return errors.Wrap(err, "failed to parse state creation block")
+}
+
+func setIdentityGIST(transfer *data.IdentityTransfer, hash, timestamp, block string) error {
+	var err error
+
+	transfer.GistHash = nullString(hash)
+
+	transfer.GistCreatedAt, err = parseNullUnixTime(timestamp)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse GIST creation timestamp")
+	}
+
+	transfer.GistCreatedAtBlock, err = parseNullInt64(block)
+	return errors.Wrap(err, "failed to parse GIST creation block")
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// parseNullInt64 parses the decimal number of the operation details, empty string is a null
+func parseNullInt64(raw string) (sql.NullInt64, error) {
+	if raw == "" {
+		return sql.NullInt64{}, nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return sql.NullInt64{}, errors.Wrap(err, "failed to parse int", logan.F{
+			"raw": raw,
+		})
+	}
+
+	return sql.NullInt64{Int64: value, Valid: true}, nil
+}
+
+func parseNullUnixTime(raw string) (sql.NullTime, error) {
+	seconds, err := parseNullInt64(raw)
+	if err != nil || !seconds.Valid {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: time.Unix(seconds.Int64, 0).UTC(), Valid: true}, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"github.com/rarimo/horizon-svc/internal/core"
 	"time"
@@ -40,6 +41,13 @@ type itemsIndexer struct {
 	saver        *TokenmanagerSaver
 }
 
+func (p *itemsIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	fork.saver = p.saver.fork()
+	return &fork
+}
+
 func (p *itemsIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
 	p.log.WithFields(logan.F{
 		"batch_size": len(batch),
@@ -58,21 +66,30 @@ func (p *itemsIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
 }
 
 func (p *itemsIndexer) handle(ctx context.Context, raw msgs.Message) error {
-	switch raw.Type() {
-	case tokenmanager.EventTypeItemCreated:
-		return p.handleItemCreated(ctx, raw.MustItemCreatedMessage())
-	case tokenmanager.EventTypeItemRemoved:
-		return p.handleItemRemoved(ctx, raw.MustItemRemovedMessage())
-	case tokenmanager.EventTypeOnChainItemCreated:
-		return p.handleOnChainItemCreated(ctx, raw.MustItemOnChainDataCreatedMessage())
-	case tokenmanager.EventTypeOnChainItemRemoved:
-		return p.handleOnChainItemRemoved(ctx, raw.MustItemOnChainDataRemovedMessage())
-	case tokenmanager.EventTypeSeedCreated:
-		return p.handleSeedCreated(ctx, raw.MustSeedCreatedMessage())
-	case tokenmanager.EventTypeSeedRemoved:
-		return p.handleSeedRemoved(ctx, raw.MustSeedRemovedMessage())
+	decoded, err := raw.DecodeAny()
+	if err != nil {
+		if errors.Cause(err) == msgs.ErrUnknownMessageType {
+			p.log.WithField("type", raw.Type()).Warn("unknown message type")
+			return nil
+		}
+		return errors.Wrap(err, "failed to decode message", raw.Fields())
+	}
+
+	switch msg := decoded.(type) {
+	case msgs.ItemCreatedMessage:
+		return p.handleItemCreated(ctx, msg)
+	case msgs.ItemRemovedMessage:
+		return p.handleItemRemoved(ctx, msg)
+	case msgs.ItemOnChainDataCreatedMessage:
+		return p.handleOnChainItemCreated(ctx, msg)
+	case msgs.ItemOnChainDataRemovedMessage:
+		return p.handleOnChainItemRemoved(ctx, msg)
+	case msgs.SeedCreatedMessage:
+		return p.handleSeedCreated(ctx, msg)
+	case msgs.SeedRemovedMessage:
+		return p.handleSeedRemoved(ctx, msg)
 	default:
-		p.log.WithField("type", raw.Type()).Warn("unknown message type")
+		p.log.WithField("type", raw.Type()).Warn("unexpected message type")
 		return nil
 	}
 }
@@ -85,8 +102,44 @@ func (p *itemsIndexer) handleItemCreated(ctx context.Context, msg msgs.ItemCreat
 		return errors.Wrap(err, "failed to get item from core")
 	}
 
-	_, err = p.saver.SaveItem(ctx, *item)
-	return errors.Wrap(err, "failed to save item", logan.F{
+	// item is already removed on core, e.g. when the old blocks are backfilled, so only the fact of
+	// its creation is recorded, as the removed event follows
+	if item == nil {
+		p.log.WithField("index", msg.Index).Warn("created item not found on core, skipping saving")
+
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:    msgs.MessageTypeItemCreated,
+			source: msg.EventSource,
+			item:   msg.Index,
+		})
+		return errors.Wrap(err, "failed to append item event", logan.F{
+			"index": msg.Index,
+		})
+	}
+
+	if _, err := p.saver.SaveItem(ctx, *item); err != nil {
+		return errors.Wrap(err, "failed to save item", logan.F{
+			"index": msg.Index,
+		})
+	}
+
+	metadata, err := json.Marshal(item.Meta)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal item metadata", logan.F{
+			"index": msg.Index,
+		})
+	}
+
+	err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+		typ:    msgs.MessageTypeItemCreated,
+		source: msg.EventSource,
+		item:   msg.Index,
+		details: tokenmanagerEventDetails{
+			Collection: item.Collection,
+			Metadata:   metadata,
+		},
+	})
+	return errors.Wrap(err, "failed to append item event", logan.F{
 		"index": msg.Index,
 	})
 }
@@ -102,6 +155,20 @@ func (p *itemsIndexer) handleItemRemoved(ctx context.Context, msg msgs.ItemRemov
 			})
 		}
 
+		// item removed before it was indexed is never saved, see handleItemCreated
+		if item == nil {
+			p.log.WithField("index", msg.Index).Warn("removed item not found in storage, skipping")
+
+			err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+				typ:    msgs.MessageTypeItemRemoved,
+				source: msg.EventSource,
+				item:   msg.Index,
+			})
+			return errors.Wrap(err, "failed to append item event", logan.F{
+				"index": msg.Index,
+			})
+		}
+
 		err = p.storage.ItemChainMappingQ().DeleteByItemCtx(ctx, item.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to delete item chain mappings", logan.F{
@@ -109,14 +176,37 @@ func (p *itemsIndexer) handleItemRemoved(ctx context.Context, msg msgs.ItemRemov
 			})
 		}
 
-		err = p.storage.ItemQ().DeleteCtx(ctx, item)
+		err = p.storage.SeedQ().DeleteByItemCtx(ctx, item.ID)
 		if err != nil {
-			return errors.Wrap(err, "failed to delete item", logan.F{
+			return errors.Wrap(err, "failed to delete item seeds", logan.F{
 				"index": msg.Index,
 			})
 		}
 
-		return nil
+		// item is kept, so the transfers and the history of it are still resolvable
+		if !item.DeletedAt.Valid {
+			now := time.Now().UTC()
+			item.DeletedAt = sql.NullTime{Time: now, Valid: true}
+			item.UpdatedAt = now
+
+			if err := p.storage.ItemQ().UpdateCtx(ctx, item); err != nil {
+				return errors.Wrap(err, "failed to mark item as deleted", logan.F{
+					"index": msg.Index,
+				})
+			}
+		}
+
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:    msgs.MessageTypeItemRemoved,
+			source: msg.EventSource,
+			item:   msg.Index,
+			details: tokenmanagerEventDetails{
+				Metadata: json.RawMessage(item.Metadata),
+			},
+		})
+		return errors.Wrap(err, "failed to append item event", logan.F{
+			"index": msg.Index,
+		})
 	})
 }
 
@@ -152,21 +242,35 @@ func (p *itemsIndexer) handleOnChainItemCreated(ctx context.Context, msg msgs.It
 		return errors.Wrap(err, "failed to get on-chain item from core")
 	}
 
-	now := time.Now().UTC()
-	err = p.storage.ItemChainMappingQ().InsertCtx(ctx, &data.ItemChainMapping{
-		Item:      item.ID,
-		Network:   network.ID,
-		Address:   []byte(onChainItem.Index.Address),
-		TokenID:   []byte(onChainItem.Index.TokenID),
-		CreatedAt: now,
-		UpdatedAt: now,
-	})
+	return p.storage.Transaction(func() error {
+		now := time.Now().UTC()
+		err := p.storage.ItemChainMappingQ().InsertCtx(ctx, &data.ItemChainMapping{
+			Item:      item.ID,
+			Network:   network.ID,
+			Address:   []byte(onChainItem.Index.Address),
+			TokenID:   []byte(onChainItem.Index.TokenID),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
 
-	if err != nil {
-		return errors.Wrap(err, "failed to insert item chain mapping")
-	}
+		if err != nil {
+			return errors.Wrap(err, "failed to insert item chain mapping")
+		}
 
-	return nil
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:    msgs.MessageTypeItemOnChainDataCreated,
+			source: msg.EventSource,
+			item:   msg.ItemIndex,
+			chain:  msg.Chain,
+			details: tokenmanagerEventDetails{
+				Address: onChainItem.Index.Address,
+				TokenID: onChainItem.Index.TokenID,
+			},
+		})
+		return errors.Wrap(err, "failed to append item event", logan.F{
+			"index": msg.ItemIndex,
+		})
+	})
 }
 
 func (p *itemsIndexer) handleOnChainItemRemoved(ctx context.Context, msg msgs.ItemOnChainDataRemovedMessage) error {
@@ -211,13 +315,27 @@ func (p *itemsIndexer) handleOnChainItemRemoved(ctx context.Context, msg msgs.It
 		return nil
 	}
 
-	if err := p.storage.ItemChainMappingQ().DeleteCtx(ctx, icm); err != nil {
-		return errors.Wrap(err, "failed to delete item chain mapping", logan.F{
+	return p.storage.Transaction(func() error {
+		if err := p.storage.ItemChainMappingQ().DeleteCtx(ctx, icm); err != nil {
+			return errors.Wrap(err, "failed to delete item chain mapping", logan.F{
+				"index": msg.ItemIndex,
+			})
+		}
+
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:    msgs.MessageTypeItemOnChainDataRemoved,
+			source: msg.EventSource,
+			item:   msg.ItemIndex,
+			chain:  msg.Chain,
+			details: tokenmanagerEventDetails{
+				Address: string(icm.Address),
+				TokenID: string(icm.TokenID),
+			},
+		})
+		return errors.Wrap(err, "failed to append item event", logan.F{
 			"index": msg.ItemIndex,
 		})
-	}
-
-	return nil
+	})
 }
 
 func (p *itemsIndexer) handleSeedCreated(ctx context.Context, msg msgs.SeedCreatedMessage) error {
@@ -268,6 +386,34 @@ func (p *itemsIndexer) handleSeedCreated(ctx context.Context, msg msgs.SeedCreat
 			})
 		}
 
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:    msgs.MessageTypeSeedCreated,
+			source: msg.EventSource,
+			item:   msg.ItemIndex,
+			details: tokenmanagerEventDetails{
+				Metadata: json.RawMessage(item.Metadata),
+				Seed:     msg.Seed,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to append item event", logan.F{
+				"index": msg.ItemIndex,
+			})
+		}
+
+		err = p.storage.SeedQ().UpsertCtx(ctx, &data.Seed{
+			Seed:      msg.Seed,
+			Item:      item.ID,
+			CreatedTx: nullString(msg.TxHash),
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to upsert seed", logan.F{
+				"index": msg.ItemIndex,
+				"seed":  msg.Seed,
+			})
+		}
+
 		return nil
 	})
 }
@@ -322,6 +468,39 @@ func (p *itemsIndexer) handleSeedRemoved(ctx context.Context, msg msgs.SeedRemov
 			})
 		}
 
+		err = appendTokenmanagerEvent(ctx, p.storage, tokenmanagerEvent{
+			typ:    msgs.MessageTypeSeedRemoved,
+			source: msg.EventSource,
+			item:   msg.ItemIndex,
+			details: tokenmanagerEventDetails{
+				Metadata: json.RawMessage(item.Metadata),
+				Seed:     msg.Seed,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to append item event", logan.F{
+				"index": msg.ItemIndex,
+			})
+		}
+
+		seed, err := p.storage.SeedQ().SeedBySeedCtx(ctx, msg.Seed, true)
+		if err != nil {
+			return errors.Wrap(err, "failed to get seed from storage", logan.F{
+				"seed": msg.Seed,
+			})
+		}
+
+		if seed == nil || seed.Item != item.ID { // the seed could have already been relinked to another item
+			return nil
+		}
+
+		if err := p.storage.SeedQ().DeleteCtx(ctx, seed); err != nil {
+			return errors.Wrap(err, "failed to delete seed", logan.F{
+				"index": msg.ItemIndex,
+				"seed":  msg.Seed,
+			})
+		}
+
 		return nil
 	})
 }
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/pkg/rd"
+)
+
+// RunAsLeader runs the singleton service only on the replica holding the lease if the leader
+// election is enabled, so its replicas do not publish the same messages and race on cursors
+func RunAsLeader(ctx context.Context, cfg config.Config, lease string, run func(ctx context.Context)) {
+	election := cfg.LeaderElection()
+	if !election.Enabled {
+		run(ctx)
+		return
+	}
+
+	mutex := rd.NewMutex(cfg.RedisClient(), "leader:"+lease, rd.MutexOpts{
+		Expiry: election.LeaseTTL,
+	})
+
+	rd.RunAsLeader(ctx, cfg.Log().WithField("who", lease+"_leader_election"), mutex, election.LeaseTTL, run)
+}
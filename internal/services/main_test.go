@@ -0,0 +1,37 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	"github.com/rarimo/horizon-svc/internal/assets"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/pg"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/distributed_lab/kit/pgdb"
+)
+
+// newTestStorage returns the storage of the migrated database from TEST_DATABASE_URL, tests using it are skipped
+// if it is not set. Rows of the tests are not cleaned up, so they have to use unique keys.
+func newTestStorage(t *testing.T) data.Storage {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := pgdb.Open(pgdb.Opts{
+		URL:                url,
+		MaxOpenConnections: 4,
+		MaxIdleConnections: 4,
+	})
+	require.NoError(t, err)
+
+	_, err = migrate.Exec(db.RawDB(), "postgres", &migrate.EmbedFileSystemMigrationSource{
+		FileSystem: assets.Migrations,
+		Root:       "migrations",
+	}, migrate.Up)
+	require.NoError(t, err)
+
+	return pg.New(db)
+}
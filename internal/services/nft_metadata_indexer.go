@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/proxy"
+	proxytypes "github.com/rarimo/horizon-svc/internal/proxy/types"
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/running"
+)
+
+// RunNftMetadataIndexer stores the metadata of the nft items created on the chains, so the API serves it without
+// fetching from the chain and IPFS on every request. Metadata is refreshed once in the refresh period, items failed
+// to be fetched are retried with the exponential backoff.
+func RunNftMetadataIndexer(ctx context.Context, cfg config.Config) {
+	log := cfg.Log().WithField("who", cfg.NftMetadataIndexer().RunnerName)
+
+	indexer := nftMetadataIndexer{
+		log:     log,
+		storage: cfg.NewStorage(),
+		chains:  cfg.ChainsQ(),
+		proxies: proxy.New(cfg.ChainsQ(), cfg.MetadataFetcher()),
+		config:  cfg.NftMetadataIndexer(),
+	}
+
+	// replicas would fetch the same items, so only the lease holder runs the indexer
+	RunAsLeader(ctx, cfg, "nft_metadata_indexer", func(ctx context.Context) {
+		running.WithBackOff(ctx,
+			log,
+			cfg.NftMetadataIndexer().RunnerName,
+			indexer.indexOnce,
+			cfg.NftMetadataIndexer().Period,
+			time.Second*10,
+			time.Minute*5,
+		)
+	})
+}
+
+type nftMetadataIndexer struct {
+	log     *logan.Entry
+	storage data.Storage
+	chains  data.ChainsQ
+	proxies proxy.ProxyRepo
+	config  *config.NftMetadataIndexerConfig
+}
+
+func (i *nftMetadataIndexer) indexOnce(ctx context.Context) error {
+	tokenTypes := make([]int, len(data.NftTokenTypes))
+	for j, tokenType := range data.NftTokenTypes {
+		tokenTypes[j] = int(tokenType)
+	}
+
+	if err := i.storage.ItemMetadatumQ().InsertMissingCtx(ctx, tokenTypes...); err != nil {
+		return errors.Wrap(err, "failed to add new items")
+	}
+
+	due, err := i.storage.ItemMetadatumQ().SelectDueCtx(ctx, time.Now().UTC(), i.config.BatchSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to select items to fetch")
+	}
+
+	for _, entry := range due {
+		if err := i.index(ctx, entry); err != nil {
+			return errors.Wrap(err, "failed to index item metadata", logan.F{
+				"item":    entry.Item,
+				"network": entry.Network,
+			})
+		}
+	}
+
+	if len(due) != 0 {
+		i.log.WithField("items", len(due)).Info("item metadata indexed")
+	}
+
+	return nil
+}
+
+// index fetches the metadata of the item and stores it or the failure, only the storage errors are returned
+func (i *nftMetadataIndexer) index(ctx context.Context, entry data.ItemMetadatum) error {
+	opts, err := i.fetchOpts(ctx, entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to get fetch options")
+	}
+
+	if opts == nil {
+		// item or its chain is gone, it is added back if the item appears on the chain again
+		return i.storage.ItemMetadatumQ().DeleteCtx(ctx, &entry)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, i.config.FetchTimeout)
+	defer cancel()
+
+	metadata, fetchErr := i.proxies.Get(opts.Chain).NftMetadata(fetchCtx, opts)
+	if fetchErr == nil && metadata == nil {
+		fetchErr = proxytypes.ErrorNotFound
+	}
+
+	now := time.Now().UTC()
+	entry.UpdatedAt = now
+
+	if fetchErr != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		entry.Failures++
+		entry.LastError = sql.NullString{String: fetchErr.Error(), Valid: true}
+		entry.NextFetchAt = now.Add(nftMetadataRetryPeriod(entry.Failures, i.config.MinRetryPeriod, i.config.MaxRetryPeriod))
+
+		i.log.WithError(fetchErr).WithFields(logan.F{
+			"item":          entry.Item,
+			"chain":         opts.Chain,
+			"failures":      entry.Failures,
+			"next_fetch_at": entry.NextFetchAt,
+		}).Warn("failed to fetch item metadata")
+
+		return i.storage.ItemMetadatumQ().UpsertCtx(ctx, &entry)
+	}
+
+	if err := entry.SetFetched(*metadata, i.config.RefreshPeriod); err != nil {
+		return errors.Wrap(err, "failed to set fetched metadata")
+	}
+
+	return i.storage.ItemMetadatumQ().UpsertCtx(ctx, &entry)
+}
+
+// fetchOpts returns the options of fetching the metadata of the item from the stored item, its chain mapping and
+// collection, or nil if the item is not on the chain anymore
+func (i *nftMetadataIndexer) fetchOpts(ctx context.Context, entry data.ItemMetadatum) (*proxytypes.NftMetadataOpts, error) {
+	var chain *data.Chain
+	for _, c := range i.chains.List() {
+		if c.ID == entry.Network {
+			chain = &c
+			break
+		}
+	}
+
+	if chain == nil || i.proxies.Get(chain.Name) == nil {
+		return nil, nil
+	}
+
+	item, err := i.storage.ItemQ().ItemByIDCtx(ctx, entry.Item, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item")
+	}
+
+	if item == nil || item.DeletedAt.Valid || !item.Collection.Valid {
+		return nil, nil
+	}
+
+	mapping, err := i.storage.ItemChainMappingQ().ItemChainMappingByItemNetworkCtx(ctx, entry.Item, entry.Network, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item chain mapping")
+	}
+
+	collectionMapping, err := i.storage.CollectionChainMappingQ().
+		CollectionChainMappingByCollectionNetworkCtx(ctx, item.Collection.Int64, entry.Network, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get collection chain mapping")
+	}
+
+	if mapping == nil || collectionMapping == nil {
+		return nil, nil
+	}
+
+	return &proxytypes.NftMetadataOpts{
+		Chain:        chain.Name,
+		TokenType:    tokenmanager.Type(collectionMapping.TokenType.Int64),
+		TokenAddress: string(mapping.Address),
+		TokenID:      string(mapping.TokenID),
+	}, nil
+}
+
+// nftMetadataRetryPeriod doubles the min period with every consecutive failure up to the max one
+func nftMetadataRetryPeriod(failures int, min, max time.Duration) time.Duration {
+	period := min
+	for j := 1; j < failures && period < max; j++ {
+		period *= 2
+	}
+
+	if period > max {
+		return max
+	}
+
+	return period
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/rarimo/horizon-svc/internal/data"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/xo/types/xo"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// newOperationDetails returns an empty typed details message for the rarimocore operations stored
+// in the operations table, or nil if operations of the type are not indexed there
+func newOperationDetails(opType rarimocore.OpType) proto.Message {
+	switch opType {
+	case rarimocore.OpType_CHANGE_PARTIES:
+		return &rarimocore.ChangeParties{}
+	case rarimocore.OpType_CONTRACT_UPGRADE:
+		return &rarimocore.ContractUpgrade{}
+	case rarimocore.OpType_FEE_TOKEN_MANAGEMENT:
+		return &rarimocore.FeeTokenManagement{}
+	default:
+		return nil
+	}
+}
+
+// marshalOperationDetails decodes details of the operation and encodes them to the proto json,
+// so enums are stored by names and the field names are the same as in the core API
+func marshalOperationDetails(operation rarimocore.Operation) ([]byte, error) {
+	details := newOperationDetails(operation.OperationType)
+	if details == nil {
+		return nil, errors.From(errors.New("unsupported operation type"), logan.F{
+			"type": operation.OperationType.String(),
+		})
+	}
+
+	if operation.Details == nil {
+		return nil, errors.New("operation details are empty")
+	}
+
+	if err := proto.Unmarshal(operation.Details.Value, details); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal operation details")
+	}
+
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, details); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal operation details")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p *transfersIndexer) makeOperation(ctx context.Context, txHash string, operation rarimocore.Operation) (*data.Operation, error) {
+	status, err := p.getOperationStatus(ctx, operation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get operation status", logan.F{
+			"operation_index": operation.Index,
+		})
+	}
+
+	details, err := marshalOperationDetails(operation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal operation details", logan.F{
+			"operation_index": operation.Index,
+		})
+	}
+
+	now := time.Now().UTC()
+	return &data.Operation{
+		Index:             []byte(operation.Index),
+		Type:              int(operation.OperationType),
+		Status:            int(status),
+		Creator:           nullString(operation.Creator),
+		RarimoTx:          data.MustDBHash(txHash),
+		RarimoTxTimestamp: time.Unix(int64(operation.Timestamp), 0),
+		Details:           xo.Jsonb(details),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// setOperationsStatus moves status of the operations of every indexed kind, as approvals, rejections
+// and confirmations are published by the operation index only, and records the changes to the timeline.
+// Only the changes of the operations which status was actually moved are recorded, so the late messages
+// do not add the transitions which never happened. The status, its changes and the records written by
+// save are stored in the single transaction.
+func setOperationsStatus(ctx context.Context, storage data.Storage, status rarimocore.OpStatus,
+	changes []data.TransferStatusChange, save func() error) error {
+	indexes := make([]string, len(changes))
+	for i, change := range changes {
+		indexes[i] = string(change.TransferIndex)
+	}
+
+	return storage.Transaction(func() error {
+		movedTransfers, err := storage.TransferQ().SetStatusByIndexCtx(ctx, int(status), indexes...)
+		if err != nil {
+			return errors.Wrap(err, "failed to set transfers status")
+		}
+
+		movedOperations, err := storage.OperationQ().SetStatusByIndexCtx(ctx, int(status), indexes...)
+		if err != nil {
+			return errors.Wrap(err, "failed to set operations status")
+		}
+
+		movedIdentityTransfers, err := storage.IdentityTransferQ().SetStatusByIndexCtx(ctx, int(status), indexes...)
+		if err != nil {
+			return errors.Wrap(err, "failed to set identity transfers status")
+		}
+
+		moved := make(map[string]bool)
+		for _, index := range append(append(movedTransfers, movedOperations...), movedIdentityTransfers...) {
+			moved[index] = true
+		}
+
+		movedChanges := make([]data.TransferStatusChange, 0, len(moved))
+		for _, change := range changes {
+			if moved[string(change.TransferIndex)] {
+				movedChanges = append(movedChanges, change)
+			}
+		}
+
+		if err := storage.TransferStatusChangeQ().InsertBatchCtx(ctx, movedChanges...); err != nil {
+			return errors.Wrap(err, "failed to insert status changes")
+		}
+
+		return save()
+	})
+}
+
+// newStatusChange makes the timeline entry of the operation status reached by the rarimo transaction,
+// blockTime is the unix time of the block with it or zero if it is unknown
+func newStatusChange(index string, status rarimocore.OpStatus, txHash string, blockTime int64) data.TransferStatusChange {
+	change := data.TransferStatusChange{
+		TransferIndex:     []byte(index),
+		Status:            int(status),
+		RarimoTransaction: data.MustDBHash(txHash),
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	if blockTime != 0 {
+		change.RarimoTxTimestamp = sql.NullTime{
+			Time:  time.Unix(blockTime, 0).UTC(),
+			Valid: true,
+		}
+	}
+
+	return change
+}
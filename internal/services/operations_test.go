@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/data"
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOperationsStatusRecordsOnlyMovedStatuses(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano()
+	signed := fmt.Sprintf("%x", suffix)
+	initialized := fmt.Sprintf("%x", suffix+1)
+
+	now := time.Now().UTC()
+	operations := make([]data.Operation, 0, 2)
+	for index, status := range map[string]rarimocore.OpStatus{
+		signed:      rarimocore.OpStatus_SIGNED,
+		initialized: rarimocore.OpStatus_INITIALIZED,
+	} {
+		operations = append(operations, data.Operation{
+			Index:             []byte(index),
+			Type:              int(rarimocore.OpType_CHANGE_PARTIES),
+			Status:            int(status),
+			RarimoTxTimestamp: now,
+			Details:           []byte(`{}`),
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+	}
+
+	require.NoError(t, storage.OperationQ().UpsertBatchCtx(ctx, operations...))
+
+	saved := false
+	err := setOperationsStatus(ctx, storage, rarimocore.OpStatus_NOT_APPROVED, []data.TransferStatusChange{
+		newStatusChange(signed, rarimocore.OpStatus_NOT_APPROVED, "", now.Unix()),
+		newStatusChange(initialized, rarimocore.OpStatus_NOT_APPROVED, "", now.Unix()),
+	}, func() error {
+		saved = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	operation, err := storage.OperationQ().OperationByIndexCtx(ctx, []byte(signed), false)
+	require.NoError(t, err)
+	require.NotNil(t, operation)
+	assert.Equal(t, int(rarimocore.OpStatus_SIGNED), operation.Status)
+
+	changes, err := storage.TransferStatusChangeQ().SelectByTransferIndexesCtx(ctx, []byte(signed), []byte(initialized))
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, initialized, string(changes[0].TransferIndex))
+	assert.Equal(t, int(rarimocore.OpStatus_NOT_APPROVED), changes[0].Status)
+}
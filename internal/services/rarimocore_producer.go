@@ -26,35 +26,35 @@ import (
 func RunRarimoCoreOpProducer(ctx context.Context, cfg config.Config) {
 	log := cfg.Log().WithField("who", cfg.RarimoCoreProducer().RunnerName+"_rarimocore_events_producer")
 
-	transfersPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	transfersPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.RarimoCoreProducer().RunnerName+"_transfers_publisher",
 		cfg.RarimoCoreProducer().TransfersQueueName)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to create transfers publisher"))
 	}
 
-	confirmationsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	confirmationsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.RarimoCoreProducer().RunnerName+"_confirmations_publisher",
 		cfg.RarimoCoreProducer().ConfirmationsQueueName)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to create confirmations publisher"))
 	}
 
-	approvalsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	approvalsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.RarimoCoreProducer().RunnerName+"_approvals_publisher",
 		cfg.RarimoCoreProducer().ApprovalsQueueName)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to create approvals publisher"))
 	}
 
-	rejectionsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	rejectionsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.RarimoCoreProducer().RunnerName+"_rejections_publisher",
 		cfg.RarimoCoreProducer().RejectionsQueueName)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to create rejections publisher"))
 	}
 
-	votesPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	votesPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.RarimoCoreProducer().RunnerName+"_votes_publisher",
 		cfg.RarimoCoreProducer().VotesQueueName)
 	if err != nil {
@@ -94,15 +94,21 @@ type rarimoCoreOpProducer struct {
 	txQ data.TransactionQ
 }
 
-func (p *rarimoCoreOpProducer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	for _, msg := range msgs {
-		brMsg := msg.MustBlockRangeMessage()
+func (p *rarimoCoreOpProducer) Handle(ctx context.Context, batch []msgs.Message) error {
+	for _, msg := range batch {
+		var brMsg msgs.BlockRangeMessage
+		if err := msg.Decode(&brMsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
+
 		p.log.WithFields(logan.F{
-			"start": brMsg.Start,
-			"end":   brMsg.End,
+			"start":    brMsg.Start,
+			"end":      brMsg.End,
+			"trace_id": msg.TraceID(),
 		}).Info("received block range message")
 
-		if err := p.produceMsgs(ctx, brMsg); err != nil {
+		// events of the range are published within the trace of the block range message
+		if err := p.produceMsgs(msgs.ContextWithTraceID(ctx, msg.TraceID()), brMsg); err != nil {
 			return errors.Wrap(err, "failed to produce op msgs", logan.F{
 				"start": brMsg.Start,
 				"end":   brMsg.End,
@@ -191,44 +197,8 @@ func (p *rarimoCoreOpProducer) produceMsgs(ctx context.Context, blockRange msgs.
 			"end":   blockRange.End,
 		}
 
-		p.log.WithFields(f.Merge(logan.F{
-			"transfers": len(eventsSet.newTransferEvents),
-		})).Debug("publishing transfers")
-
-		if err := p.transfersPublisher.PublishMsgs(ctx, eventsSet.newTransferEvents...); err != nil {
-			return errors.Wrap(err, "failed to publish transfer messages", f)
-		}
-
-		p.log.WithFields(f.Merge(logan.F{
-			"confirmations": len(eventsSet.newConfirmationEvents),
-		})).Debug("publishing confirmations")
-
-		if err := p.confirmationsPublisher.PublishMsgs(ctx, eventsSet.newConfirmationEvents...); err != nil {
-			return errors.Wrap(err, "failed to publish confirmation messages", f)
-		}
-
-		p.log.WithFields(f.Merge(logan.F{
-			"approvals": len(eventsSet.newApprovalEvents),
-		})).Debug("publishing approvals")
-
-		if err := p.approvalsPublisher.PublishMsgs(ctx, eventsSet.newApprovalEvents...); err != nil {
-			return errors.Wrap(err, "failed to publish approval messages", f)
-		}
-
-		p.log.WithFields(f.Merge(logan.F{
-			"rejections": len(eventsSet.newRejectionEvents),
-		})).Debug("publishing rejections")
-
-		if err := p.rejectionsPublisher.PublishMsgs(ctx, eventsSet.newRejectionEvents...); err != nil {
-			return errors.Wrap(err, "failed to publish rejection messages", f)
-		}
-
-		p.log.WithFields(f.Merge(logan.F{
-			"votes": len(eventsSet.newVoteEvents),
-		})).Debug("publishing votes")
-
-		if err := p.votesPublisher.PublishMsgs(ctx, eventsSet.newVoteEvents...); err != nil {
-			return errors.Wrap(err, "failed to publish vote messages", f)
+		if err := p.publishEvents(ctx, eventsSet, f); err != nil {
+			return errors.Wrap(err, "failed to publish events")
 		}
 
 		p.log.WithFields(f).Debug("published messages")
@@ -255,6 +225,50 @@ func (p *rarimoCoreOpProducer) produceMsgs(ctx context.Context, blockRange msgs.
 	return nil
 }
 
+func (p *rarimoCoreOpProducer) publishEvents(ctx context.Context, eventsSet eventMsgs, f logan.F) error {
+	p.log.WithFields(f.Merge(logan.F{
+		"transfers": len(eventsSet.newTransferEvents),
+	})).Debug("publishing transfers")
+
+	if err := p.transfersPublisher.PublishMsgs(ctx, eventsSet.newTransferEvents...); err != nil {
+		return errors.Wrap(err, "failed to publish transfer messages", f)
+	}
+
+	p.log.WithFields(f.Merge(logan.F{
+		"confirmations": len(eventsSet.newConfirmationEvents),
+	})).Debug("publishing confirmations")
+
+	if err := p.confirmationsPublisher.PublishMsgs(ctx, eventsSet.newConfirmationEvents...); err != nil {
+		return errors.Wrap(err, "failed to publish confirmation messages", f)
+	}
+
+	p.log.WithFields(f.Merge(logan.F{
+		"approvals": len(eventsSet.newApprovalEvents),
+	})).Debug("publishing approvals")
+
+	if err := p.approvalsPublisher.PublishMsgs(ctx, eventsSet.newApprovalEvents...); err != nil {
+		return errors.Wrap(err, "failed to publish approval messages", f)
+	}
+
+	p.log.WithFields(f.Merge(logan.F{
+		"rejections": len(eventsSet.newRejectionEvents),
+	})).Debug("publishing rejections")
+
+	if err := p.rejectionsPublisher.PublishMsgs(ctx, eventsSet.newRejectionEvents...); err != nil {
+		return errors.Wrap(err, "failed to publish rejection messages", f)
+	}
+
+	p.log.WithFields(f.Merge(logan.F{
+		"votes": len(eventsSet.newVoteEvents),
+	})).Debug("publishing votes")
+
+	if err := p.votesPublisher.PublishMsgs(ctx, eventsSet.newVoteEvents...); err != nil {
+		return errors.Wrap(err, "failed to publish vote messages", f)
+	}
+
+	return nil
+}
+
 type txWithBlockInfo struct {
 	tx          *coretypes.ResultTx
 	blockHeight int64
@@ -335,16 +349,16 @@ func (p *rarimoCoreOpProducer) extractEvents(txs []txWithBlockInfo) eventMsgs {
 					mustTransferOpMsg(txInfo.tx.Hash.String(), cosmostypes.Event(event)).Message())
 			case rarimotypes.EventTypeNewConfirmation:
 				eventsSet.newConfirmationEvents = append(eventsSet.newConfirmationEvents,
-					mustConfirmationOpMsg(txInfo.tx.Hash.String(), cosmostypes.Event(event)).Message())
+					mustConfirmationOpMsg(txInfo.tx.Hash.String(), txInfo.blockTime, cosmostypes.Event(event)).Message())
 			case rarimotypes.EventTypeVoted:
 				eventsSet.newVoteEvents = append(eventsSet.newVoteEvents,
 					mustVoteOpMsg(txInfo.tx.Hash.String(), cosmostypes.Event(event)).Message())
 			case rarimotypes.EventTypeOperationApproved:
 				eventsSet.newApprovalEvents = append(eventsSet.newApprovalEvents,
-					mustApprovalOpMsg(txInfo.tx.Hash.String(), cosmostypes.Event(event)).Message())
+					mustApprovalOpMsg(txInfo.tx.Hash.String(), txInfo.blockTime, cosmostypes.Event(event)).Message())
 			case rarimotypes.EventTypeOperationRejected:
 				eventsSet.newRejectionEvents = append(eventsSet.newRejectionEvents,
-					mustRejectionOpMsg(txInfo.tx.Hash.String(), cosmostypes.Event(event)).Message())
+					mustRejectionOpMsg(txInfo.tx.Hash.String(), txInfo.blockTime, cosmostypes.Event(event)).Message())
 			}
 		}
 	}
@@ -394,8 +408,11 @@ func mustTransferOpMsg(txHash string, event cosmostypes.Event) msgs.TransferOpMs
 	}
 
 	for _, attr := range event.Attributes {
-		if string(attr.Key) == rarimotypes.AttributeKeyOperationId {
+		switch string(attr.Key) {
+		case rarimotypes.AttributeKeyOperationId:
 			transferOpMsg.TransferID = string(attr.Value)
+		case rarimotypes.AttributeKeyOperationType:
+			transferOpMsg.OperationType = string(attr.Value)
 		}
 	}
 
@@ -406,9 +423,10 @@ func mustTransferOpMsg(txHash string, event cosmostypes.Event) msgs.TransferOpMs
 	return transferOpMsg
 }
 
-func mustConfirmationOpMsg(txHash string, event cosmostypes.Event) msgs.ConfirmationOpMsg {
+func mustConfirmationOpMsg(txHash string, blockTime time.Time, event cosmostypes.Event) msgs.ConfirmationOpMsg {
 	confirmationOpMsg := msgs.ConfirmationOpMsg{
 		TransactionHash: txHash,
+		BlockTime:       blockTime.Unix(),
 	}
 
 	for _, attr := range event.Attributes {
@@ -455,9 +473,10 @@ func mustVoteOpMsg(txHash string, event cosmostypes.Event) msgs.VoteOpMsg {
 	return voteOpMsg
 }
 
-func mustApprovalOpMsg(txHash string, event cosmostypes.Event) msgs.ApprovalOpMsg {
+func mustApprovalOpMsg(txHash string, blockTime time.Time, event cosmostypes.Event) msgs.ApprovalOpMsg {
 	approvalOpMsg := msgs.ApprovalOpMsg{
 		TransactionHash: txHash,
+		BlockTime:       blockTime.Unix(),
 	}
 
 	for _, attr := range event.Attributes {
@@ -480,9 +499,10 @@ func mustApprovalOpMsg(txHash string, event cosmostypes.Event) msgs.ApprovalOpMs
 	return approvalOpMsg
 }
 
-func mustRejectionOpMsg(txHash string, event cosmostypes.Event) msgs.RejectionOpMsg {
+func mustRejectionOpMsg(txHash string, blockTime time.Time, event cosmostypes.Event) msgs.RejectionOpMsg {
 	rejectionOpMsg := msgs.RejectionOpMsg{
 		TransactionHash: txHash,
+		BlockTime:       blockTime.Unix(),
 	}
 
 	for _, attr := range event.Attributes {
@@ -0,0 +1,682 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/core"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/redis"
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/running"
+)
+
+// RunReconciler periodically compares the tokenmanager tables with the rarimo core state, so the drift caused by
+// the lost messages is reported or repaired
+func RunReconciler(ctx context.Context, cfg config.Config) {
+	reconciler := NewReconciler(cfg, cfg.Reconciler().Repair)
+
+	// repairs of the replicas would race, so only the lease holder reconciles
+	RunAsLeader(ctx, cfg, "reconciler", func(ctx context.Context) {
+		running.WithBackOff(ctx,
+			reconciler.log,
+			cfg.Reconciler().RunnerName,
+			func(ctx context.Context) error {
+				_, err := reconciler.ReconcileOnce(ctx)
+				return err
+			},
+			cfg.Reconciler().Period,
+			time.Minute,
+			cfg.Reconciler().Period,
+		)
+	})
+}
+
+type Reconciler struct {
+	log          *logan.Entry
+	storage      data.Storage
+	chains       data.ChainsQ
+	kv           data.KeyValueQ
+	tokenmanager core.Tokenmanager
+	saver        *TokenmanagerSaver
+
+	repair         bool
+	pageSize       uint64
+	maxDifferences int
+}
+
+func NewReconciler(cfg config.Config, repair bool) *Reconciler {
+	saver := NewTokenmanagerSaver(cfg)
+
+	return &Reconciler{
+		log:          cfg.Log().WithField("who", cfg.Reconciler().RunnerName),
+		storage:      saver.storage,
+		chains:       cfg.ChainsQ(),
+		kv:           redis.NewKeyValueProvider(cfg),
+		tokenmanager: cfg.Core().Tokenmanager(),
+		saver:        saver,
+
+		repair:         repair,
+		pageSize:       cfg.Reconciler().PageSize,
+		maxDifferences: cfg.Reconciler().MaxDifferences,
+	}
+}
+
+// ReconcileOnce compares the database with core, repairs the differences if repair is enabled and stores the report
+func (r *Reconciler) ReconcileOnce(ctx context.Context) (*data.ReconcileReport, error) {
+	report := data.ReconcileReport{
+		StartedAt: time.Now().UTC(),
+		Repair:    r.repair,
+		Counts:    make(map[string]int64),
+	}
+
+	collections, err := r.reconcileCollections(ctx, &report)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile collections")
+	}
+
+	if err := r.reconcileItems(ctx, &report, collections); err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile items")
+	}
+
+	report.FinishedAt = time.Now().UTC()
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal reconcile report")
+	}
+
+	err = r.kv.Upsert(ctx, data.KeyValue{
+		Key:       data.ReconcileReportKey,
+		Value:     string(raw),
+		CreatedAt: report.FinishedAt,
+		UpdatedAt: report.FinishedAt,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save reconcile report")
+	}
+
+	fields := logan.F{
+		"repaired": report.Repaired,
+		"took":     report.FinishedAt.Sub(report.StartedAt).String(),
+	}
+	for kind, count := range report.Counts {
+		fields[kind] = count
+	}
+
+	r.log.WithFields(fields).Info("reconciled with core")
+
+	return &report, nil
+}
+
+// difference adds the difference to the report and repairs it with the provided func if repair is enabled, the repair
+// is recorded to the history by the event with details returned by the func in the same transaction
+func (r *Reconciler) difference(
+	ctx context.Context,
+	report *data.ReconcileReport,
+	diff data.ReconcileDifference,
+	repair func() (tokenmanagerEventDetails, error),
+) error {
+	created, err := r.createdOnCore(ctx, diff)
+	if err != nil {
+		return errors.Wrap(err, "failed to check difference on core", logan.F{
+			"kind":       diff.Kind,
+			"collection": diff.Collection,
+			"item":       diff.Item,
+			"chain":      diff.Chain,
+		})
+	}
+
+	if created {
+		return nil
+	}
+
+	if r.repair {
+		err := r.storage.Transaction(func() error {
+			details, err := repair()
+			if err != nil {
+				return err
+			}
+
+			err = appendTokenmanagerEvent(ctx, r.storage, tokenmanagerEvent{
+				typ:        reconcileEventType(diff.Kind),
+				collection: diff.Collection,
+				item:       diff.Item,
+				chain:      diff.Chain,
+				details:    details,
+			})
+			return errors.Wrap(err, "failed to append repair event")
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to repair difference", logan.F{
+				"kind":       diff.Kind,
+				"collection": diff.Collection,
+				"item":       diff.Item,
+				"chain":      diff.Chain,
+			})
+		}
+
+		diff.Repaired = true
+		report.Repaired++
+	}
+
+	report.Counts[diff.Kind]++
+
+	if len(report.Differences) >= r.maxDifferences {
+		report.Truncated = true
+		return nil
+	}
+
+	report.Differences = append(report.Differences, diff)
+	return nil
+}
+
+// createdOnCore checks whether the entity of the extra difference exists on core now. Core is read before the
+// database is paged, so the entities created on core and indexed in between look extra, while their repair would
+// remove them with the seeds, which are not restored by the next runs.
+func (r *Reconciler) createdOnCore(ctx context.Context, diff data.ReconcileDifference) (bool, error) {
+	switch diff.Kind {
+	case data.ReconcileCollectionExtra:
+		collection, err := r.tokenmanager.GetCollection(ctx, diff.Collection)
+		return collection != nil, err
+	case data.ReconcileCollectionDataExtra:
+		collectionData, err := r.tokenmanager.GetCollectionDataByCollectionForChain(ctx, diff.Collection, diff.Chain)
+		return collectionData != nil, err
+	case data.ReconcileItemExtra:
+		item, err := r.tokenmanager.GetItem(ctx, diff.Item)
+		return item != nil, err
+	case data.ReconcileItemOnChainExtra:
+		onChainItem, err := r.tokenmanager.GetOnChainItemByItemForChain(ctx, diff.Item, diff.Chain)
+		return onChainItem != nil, err
+	default:
+		return false, nil
+	}
+}
+
+// reconcileCollections returns ids of the collections by their indexes, including the created ones
+func (r *Reconciler) reconcileCollections(ctx context.Context, report *data.ReconcileReport) (map[string]int64, error) {
+	coreCollections, err := r.tokenmanager.GetCollections(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get collections from core")
+	}
+
+	pending := make(map[string]tokenmanager.Collection, len(coreCollections))
+	for _, collection := range coreCollections {
+		pending[collection.Index] = collection
+	}
+
+	ids := make(map[string]int64)
+	selector := data.CollectionsSelector{PageSize: r.pageSize}
+
+	for {
+		collections, err := r.storage.CollectionQ().SelectCtx(ctx, selector)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to select collections")
+		}
+
+		for _, collection := range collections {
+			collection := collection
+			ids[string(collection.Index)] = collection.ID
+
+			coreCollection, ok := pending[string(collection.Index)]
+			delete(pending, string(collection.Index))
+
+			if err := r.reconcileCollection(ctx, report, &collection, coreCollection, ok); err != nil {
+				return nil, errors.Wrap(err, "failed to reconcile collection", logan.F{
+					"index": string(collection.Index),
+				})
+			}
+		}
+
+		if uint64(len(collections)) < r.pageSize {
+			break
+		}
+
+		selector.PageCursor = uint64(collections[len(collections)-1].ID)
+	}
+
+	for index, coreCollection := range pending {
+		coreCollection := coreCollection
+
+		var collection *data.Collection
+		err := r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       data.ReconcileCollectionMissing,
+			Collection: index,
+		}, func() (tokenmanagerEventDetails, error) {
+			var err error
+			if collection, err = r.saver.SaveCollection(ctx, coreCollection); err != nil {
+				return tokenmanagerEventDetails{}, errors.Wrap(err, "failed to save collection")
+			}
+
+			return tokenmanagerEventDetails{Metadata: json.RawMessage(collection.Metadata)}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// data of the missing collection is reconciled only if it was created by the repair
+		if collection == nil {
+			continue
+		}
+
+		ids[index] = collection.ID
+		if err := r.reconcileCollectionData(ctx, report, collection.ID, coreCollection); err != nil {
+			return nil, errors.Wrap(err, "failed to reconcile collection data", logan.F{
+				"index": index,
+			})
+		}
+	}
+
+	return ids, nil
+}
+
+func (r *Reconciler) reconcileCollection(
+	ctx context.Context,
+	report *data.ReconcileReport,
+	collection *data.Collection,
+	coreCollection tokenmanager.Collection,
+	inCore bool,
+) error {
+	index := string(collection.Index)
+
+	if !inCore {
+		if collection.DeletedAt.Valid {
+			return nil
+		}
+
+		return r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       data.ReconcileCollectionExtra,
+			Collection: index,
+		}, func() (tokenmanagerEventDetails, error) {
+			if err := r.storage.CollectionChainMappingQ().DeleteByCollectionCtx(ctx, collection.ID); err != nil {
+				return tokenmanagerEventDetails{}, errors.Wrap(err, "failed to delete collection data")
+			}
+
+			now := time.Now().UTC()
+			collection.DeletedAt = sql.NullTime{Time: now, Valid: true}
+			collection.UpdatedAt = now
+
+			err := r.storage.CollectionQ().UpdateCtx(ctx, collection)
+			return tokenmanagerEventDetails{Metadata: json.RawMessage(collection.Metadata)}, err
+		})
+	}
+
+	kind := ""
+	switch {
+	case collection.DeletedAt.Valid:
+		kind = data.ReconcileCollectionMissing
+	case !jsonEqual(collection.Metadata, coreCollection.Meta):
+		kind = data.ReconcileCollectionMetadata
+	}
+
+	if kind != "" {
+		err := r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       kind,
+			Collection: index,
+		}, func() (tokenmanagerEventDetails, error) {
+			saved, err := r.saver.SaveCollection(ctx, coreCollection)
+			if err != nil {
+				return tokenmanagerEventDetails{}, err
+			}
+
+			return tokenmanagerEventDetails{Metadata: json.RawMessage(saved.Metadata)}, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.reconcileCollectionData(ctx, report, collection.ID, coreCollection)
+}
+
+func (r *Reconciler) reconcileCollectionData(
+	ctx context.Context,
+	report *data.ReconcileReport,
+	collectionID int64,
+	coreCollection tokenmanager.Collection,
+) error {
+	mappings, err := r.storage.CollectionChainMappingQ().CollectionChainMappingsByCollectionCtx(ctx, collectionID, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to select collection data")
+	}
+
+	pending := make(map[int]data.CollectionChainMapping, len(mappings))
+	for _, mapping := range mappings {
+		pending[mapping.Network] = mapping
+	}
+
+	for _, dataIndex := range coreCollection.Data {
+		network := r.chains.Get(dataIndex.Chain)
+		if network == nil {
+			continue // chain is not supported on horizon, so it is not indexed
+		}
+
+		coreData, err := r.tokenmanager.GetCollectionData(ctx, dataIndex.Chain, dataIndex.Address)
+		if err != nil {
+			return errors.Wrap(err, "failed to get collection data from core", logan.F{
+				"chain":   dataIndex.Chain,
+				"address": dataIndex.Address,
+			})
+		}
+
+		now := time.Now().UTC()
+		expected := data.CollectionChainMapping{
+			Collection: collectionID,
+			Network:    network.ID,
+			Address:    []byte(coreData.Index.Address),
+			TokenType:  sql.NullInt64{Int64: int64(coreData.TokenType), Valid: true},
+			Wrapped:    sql.NullBool{Bool: coreData.Wrapped, Valid: true},
+			Decimals:   sql.NullInt64{Int64: int64(coreData.Decimals), Valid: true},
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+
+		existing, ok := pending[network.ID]
+		delete(pending, network.ID)
+
+		kind := data.ReconcileCollectionDataMissing
+		if ok {
+			if collectionDataEqual(existing, expected) {
+				continue
+			}
+
+			kind = data.ReconcileCollectionDataChanged
+			expected.CreatedAt = existing.CreatedAt
+		}
+
+		err = r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       kind,
+			Collection: coreCollection.Index,
+			Chain:      network.Name,
+		}, func() (tokenmanagerEventDetails, error) {
+			err := r.storage.CollectionChainMappingQ().UpsertCtx(ctx, &expected)
+			return collectionDataEventDetails(*coreData), err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, mapping := range pending {
+		mapping := mapping
+
+		err := r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       data.ReconcileCollectionDataExtra,
+			Collection: coreCollection.Index,
+			Chain:      r.chainName(mapping.Network),
+		}, func() (tokenmanagerEventDetails, error) {
+			err := r.storage.CollectionChainMappingQ().DeleteCtx(ctx, &mapping)
+			return tokenmanagerEventDetails{Address: string(mapping.Address)}, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileItems(ctx context.Context, report *data.ReconcileReport, collections map[string]int64) error {
+	coreItems, err := r.tokenmanager.GetItems(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get items from core")
+	}
+
+	pending := make(map[string]tokenmanager.Item, len(coreItems))
+	for _, item := range coreItems {
+		pending[item.Index] = item
+	}
+
+	selector := data.ItemsSelector{PageSize: r.pageSize}
+
+	for {
+		items, err := r.storage.ItemQ().SelectCtx(ctx, selector)
+		if err != nil {
+			return errors.Wrap(err, "failed to select items")
+		}
+
+		for _, item := range items {
+			item := item
+
+			coreItem, ok := pending[string(item.Index)]
+			delete(pending, string(item.Index))
+
+			if err := r.reconcileItem(ctx, report, &item, coreItem, ok, collections); err != nil {
+				return errors.Wrap(err, "failed to reconcile item", logan.F{
+					"index": string(item.Index),
+				})
+			}
+		}
+
+		if uint64(len(items)) < r.pageSize {
+			break
+		}
+
+		selector.PageCursor = uint64(items[len(items)-1].ID)
+	}
+
+	for index, coreItem := range pending {
+		coreItem := coreItem
+
+		var item *data.Item
+		err := r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       data.ReconcileItemMissing,
+			Collection: coreItem.Collection,
+			Item:       index,
+		}, func() (tokenmanagerEventDetails, error) {
+			var err error
+			if item, err = r.saver.SaveItem(ctx, coreItem); err != nil {
+				return tokenmanagerEventDetails{}, errors.Wrap(err, "failed to save item")
+			}
+
+			return itemEventDetails(coreItem.Collection, item), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// on-chain data of the missing item is reconciled only if it was created by the repair
+		if item == nil {
+			continue
+		}
+
+		if err := r.reconcileOnChainItems(ctx, report, item.ID, coreItem); err != nil {
+			return errors.Wrap(err, "failed to reconcile item on-chain data", logan.F{
+				"index": index,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileItem(
+	ctx context.Context,
+	report *data.ReconcileReport,
+	item *data.Item,
+	coreItem tokenmanager.Item,
+	inCore bool,
+	collections map[string]int64,
+) error {
+	index := string(item.Index)
+
+	if !inCore {
+		if item.DeletedAt.Valid {
+			return nil
+		}
+
+		return r.difference(ctx, report, data.ReconcileDifference{
+			Kind: data.ReconcileItemExtra,
+			Item: index,
+		}, func() (tokenmanagerEventDetails, error) {
+			if err := r.storage.ItemChainMappingQ().DeleteByItemCtx(ctx, item.ID); err != nil {
+				return tokenmanagerEventDetails{}, errors.Wrap(err, "failed to delete item chain mappings")
+			}
+
+			if err := r.storage.SeedQ().DeleteByItemCtx(ctx, item.ID); err != nil {
+				return tokenmanagerEventDetails{}, errors.Wrap(err, "failed to delete item seeds")
+			}
+
+			now := time.Now().UTC()
+			item.DeletedAt = sql.NullTime{Time: now, Valid: true}
+			item.UpdatedAt = now
+
+			err := r.storage.ItemQ().UpdateCtx(ctx, item)
+			return tokenmanagerEventDetails{Metadata: json.RawMessage(item.Metadata)}, err
+		})
+	}
+
+	kind := ""
+	switch {
+	case item.DeletedAt.Valid:
+		kind = data.ReconcileItemMissing
+	case !jsonEqual(item.Metadata, coreItem.Meta),
+		item.Collection.Int64 != collections[coreItem.Collection]:
+		kind = data.ReconcileItemChanged
+	}
+
+	if kind != "" {
+		err := r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       kind,
+			Collection: coreItem.Collection,
+			Item:       index,
+		}, func() (tokenmanagerEventDetails, error) {
+			saved, err := r.saver.SaveItem(ctx, coreItem)
+			if err != nil {
+				return tokenmanagerEventDetails{}, err
+			}
+
+			return itemEventDetails(coreItem.Collection, saved), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.reconcileOnChainItems(ctx, report, item.ID, coreItem)
+}
+
+func (r *Reconciler) reconcileOnChainItems(ctx context.Context, report *data.ReconcileReport, itemID int64, coreItem tokenmanager.Item) error {
+	mappings, err := r.storage.ItemChainMappingQ().ItemChainMappingsByItemCtx(ctx, itemID, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to select item chain mappings")
+	}
+
+	pending := make(map[int]data.ItemChainMapping, len(mappings))
+	for _, mapping := range mappings {
+		pending[mapping.Network] = mapping
+	}
+
+	for _, onChain := range coreItem.OnChain {
+		network := r.chains.Get(onChain.Chain)
+		if network == nil {
+			continue // chain is not supported on horizon, so it is not indexed
+		}
+
+		now := time.Now().UTC()
+		expected := data.ItemChainMapping{
+			Item:      itemID,
+			Network:   network.ID,
+			Address:   []byte(onChain.Address),
+			TokenID:   []byte(onChain.TokenID),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		existing, ok := pending[network.ID]
+		delete(pending, network.ID)
+
+		kind := data.ReconcileItemOnChainMissing
+		if ok {
+			if bytes.Equal(existing.Address, expected.Address) && bytes.Equal(existing.TokenID, expected.TokenID) {
+				continue
+			}
+
+			kind = data.ReconcileItemOnChainChanged
+			expected.CreatedAt = existing.CreatedAt
+		}
+
+		err = r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       kind,
+			Collection: coreItem.Collection,
+			Item:       coreItem.Index,
+			Chain:      network.Name,
+		}, func() (tokenmanagerEventDetails, error) {
+			err := r.storage.ItemChainMappingQ().UpsertCtx(ctx, &expected)
+			return tokenmanagerEventDetails{Address: onChain.Address, TokenID: onChain.TokenID}, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, mapping := range pending {
+		mapping := mapping
+
+		err := r.difference(ctx, report, data.ReconcileDifference{
+			Kind:       data.ReconcileItemOnChainExtra,
+			Collection: coreItem.Collection,
+			Item:       coreItem.Index,
+			Chain:      r.chainName(mapping.Network),
+		}, func() (tokenmanagerEventDetails, error) {
+			err := r.storage.ItemChainMappingQ().DeleteCtx(ctx, &mapping)
+			return tokenmanagerEventDetails{Address: string(mapping.Address), TokenID: string(mapping.TokenID)}, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// itemEventDetails is the state of the item saved by the repair
+func itemEventDetails(collection string, item *data.Item) tokenmanagerEventDetails {
+	return tokenmanagerEventDetails{
+		Collection: collection,
+		Metadata:   json.RawMessage(item.Metadata),
+	}
+}
+
+func (r *Reconciler) chainName(network int) string {
+	for _, chain := range r.chains.List() {
+		if chain.ID == network {
+			return chain.Name
+		}
+	}
+
+	return ""
+}
+
+func collectionDataEqual(a, b data.CollectionChainMapping) bool {
+	return bytes.Equal(a.Address, b.Address) &&
+		a.TokenType == b.TokenType &&
+		a.Wrapped == b.Wrapped &&
+		a.Decimals == b.Decimals
+}
+
+// jsonEqual compares the stored jsonb with the core value ignoring the keys order and formatting
+func jsonEqual(stored []byte, value interface{}) bool {
+	expected, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	var a, b interface{}
+	if err := json.Unmarshal(stored, &a); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal(expected, &b); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(a, b)
+}
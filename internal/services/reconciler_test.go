@@ -0,0 +1,503 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/core"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/internal/data/mem"
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcilerState is the state of the tokenmanager in core and in the database compared by the reconciler
+type reconcilerState struct {
+	coreCollections    []tokenmanager.Collection
+	coreCollectionData []tokenmanager.CollectionData
+	coreItems          []tokenmanager.Item
+	// created on core after the collections and items were read from it
+	createdCollections []tokenmanager.Collection
+	createdItems       []tokenmanager.Item
+
+	collections    []data.Collection
+	collectionData []data.CollectionChainMapping
+	items          []data.Item
+	itemMappings   []data.ItemChainMapping
+}
+
+type reconcilerStorage struct {
+	data.Storage
+	state *reconcilerState
+}
+
+func (s reconcilerStorage) CollectionQ() data.CollectionQ {
+	return reconcilerCollectionsQ{state: s.state}
+}
+
+func (s reconcilerStorage) CollectionChainMappingQ() data.CollectionChainMappingQ {
+	return reconcilerCollectionDataQ{state: s.state}
+}
+
+func (s reconcilerStorage) ItemQ() data.ItemQ {
+	return reconcilerItemsQ{state: s.state}
+}
+
+func (s reconcilerStorage) ItemChainMappingQ() data.ItemChainMappingQ {
+	return reconcilerItemMappingsQ{state: s.state}
+}
+
+type reconcilerCollectionsQ struct {
+	data.CollectionQ
+	state *reconcilerState
+}
+
+func (q reconcilerCollectionsQ) SelectCtx(_ context.Context, selector data.CollectionsSelector) ([]data.Collection, error) {
+	result := make([]data.Collection, 0, selector.PageSize)
+	for _, collection := range q.state.collections {
+		if uint64(collection.ID) > selector.PageCursor && uint64(len(result)) < selector.PageSize {
+			result = append(result, collection)
+		}
+	}
+
+	return result, nil
+}
+
+type reconcilerCollectionDataQ struct {
+	data.CollectionChainMappingQ
+	state *reconcilerState
+}
+
+func (q reconcilerCollectionDataQ) CollectionChainMappingsByCollectionCtx(_ context.Context, collection int64, _ bool) ([]data.CollectionChainMapping, error) {
+	var result []data.CollectionChainMapping
+	for _, mapping := range q.state.collectionData {
+		if mapping.Collection == collection {
+			result = append(result, mapping)
+		}
+	}
+
+	return result, nil
+}
+
+type reconcilerItemsQ struct {
+	data.ItemQ
+	state *reconcilerState
+}
+
+func (q reconcilerItemsQ) SelectCtx(_ context.Context, selector data.ItemsSelector) ([]data.Item, error) {
+	result := make([]data.Item, 0, selector.PageSize)
+	for _, item := range q.state.items {
+		if uint64(item.ID) > selector.PageCursor && uint64(len(result)) < selector.PageSize {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+type reconcilerItemMappingsQ struct {
+	data.ItemChainMappingQ
+	state *reconcilerState
+}
+
+func (q reconcilerItemMappingsQ) ItemChainMappingsByItemCtx(_ context.Context, item int64, _ bool) ([]data.ItemChainMapping, error) {
+	var result []data.ItemChainMapping
+	for _, mapping := range q.state.itemMappings {
+		if mapping.Item == item {
+			result = append(result, mapping)
+		}
+	}
+
+	return result, nil
+}
+
+type reconcilerTokenmanager struct {
+	core.Tokenmanager
+	state *reconcilerState
+}
+
+func (t reconcilerTokenmanager) GetCollections(_ context.Context) ([]tokenmanager.Collection, error) {
+	return t.state.coreCollections, nil
+}
+
+func (t reconcilerTokenmanager) GetCollectionData(_ context.Context, chain, address string) (*tokenmanager.CollectionData, error) {
+	for _, collectionData := range t.state.coreCollectionData {
+		if collectionData.Index.Chain == chain && collectionData.Index.Address == address {
+			collectionData := collectionData
+			return &collectionData, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t reconcilerTokenmanager) GetItems(_ context.Context) ([]tokenmanager.Item, error) {
+	return t.state.coreItems, nil
+}
+
+func (t reconcilerTokenmanager) GetCollection(_ context.Context, index string) (*tokenmanager.Collection, error) {
+	for _, collection := range append(t.state.createdCollections, t.state.coreCollections...) {
+		if collection.Index == index {
+			collection := collection
+			return &collection, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t reconcilerTokenmanager) GetCollectionDataByCollectionForChain(ctx context.Context, index, chain string) (*tokenmanager.CollectionData, error) {
+	collection, _ := t.GetCollection(ctx, index)
+	if collection == nil {
+		return nil, nil
+	}
+
+	for _, dataIndex := range collection.Data {
+		if dataIndex.Chain == chain {
+			return &tokenmanager.CollectionData{Index: dataIndex, Collection: index}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t reconcilerTokenmanager) GetItem(_ context.Context, index string) (*tokenmanager.Item, error) {
+	for _, item := range append(t.state.createdItems, t.state.coreItems...) {
+		if item.Index == index {
+			item := item
+			return &item, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t reconcilerTokenmanager) GetOnChainItemByItemForChain(ctx context.Context, index, chain string) (*tokenmanager.OnChainItem, error) {
+	item, _ := t.GetItem(ctx, index)
+	if item == nil {
+		return nil, nil
+	}
+
+	for _, onChain := range item.OnChain {
+		if onChain.Chain == chain {
+			return &tokenmanager.OnChainItem{Index: onChain, Item: index}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func mustMarshal(t *testing.T, value interface{}) []byte {
+	raw, err := json.Marshal(value)
+	require.NoError(t, err)
+	return raw
+}
+
+// newSyncedReconcilerState returns the state with one collection and one item stored the same way as in core, the
+// collection and the item are also on the chain which is not supported on horizon
+func newSyncedReconcilerState(t *testing.T) *reconcilerState {
+	collectionMeta := tokenmanager.CollectionMetadata{Name: "Collection", Symbol: "COL"}
+	itemMeta := tokenmanager.ItemMetadata{Uri: "https://example.com/1.json"}
+
+	return &reconcilerState{
+		coreCollections: []tokenmanager.Collection{{
+			Index: "collection",
+			Meta:  collectionMeta,
+			Data: []*tokenmanager.CollectionDataIndex{
+				{Chain: "Goerli", Address: "0xcollection"},
+				{Chain: "Unsupported", Address: "0xunsupported"},
+			},
+		}},
+		coreCollectionData: []tokenmanager.CollectionData{{
+			Index:      &tokenmanager.CollectionDataIndex{Chain: "Goerli", Address: "0xcollection"},
+			Collection: "collection",
+			TokenType:  tokenmanager.Type_ERC721,
+		}},
+		coreItems: []tokenmanager.Item{{
+			Index:      "item",
+			Collection: "collection",
+			Meta:       itemMeta,
+			OnChain: []*tokenmanager.OnChainItemIndex{
+				{Chain: "Goerli", Address: "0xcollection", TokenID: "1"},
+				{Chain: "Unsupported", Address: "0xunsupported", TokenID: "1"},
+			},
+		}},
+
+		collections: []data.Collection{{
+			ID:       1,
+			Index:    []byte("collection"),
+			Metadata: mustMarshal(t, collectionMeta),
+		}},
+		collectionData: []data.CollectionChainMapping{{
+			Collection: 1,
+			Network:    1,
+			Address:    []byte("0xcollection"),
+			TokenType:  sql.NullInt64{Int64: int64(tokenmanager.Type_ERC721), Valid: true},
+			Wrapped:    sql.NullBool{Bool: false, Valid: true},
+			Decimals:   sql.NullInt64{Int64: 0, Valid: true},
+		}},
+		items: []data.Item{{
+			ID:         1,
+			Index:      []byte("item"),
+			Collection: sql.NullInt64{Int64: 1, Valid: true},
+			Metadata:   mustMarshal(t, itemMeta),
+		}},
+		itemMappings: []data.ItemChainMapping{{
+			Item:    1,
+			Network: 1,
+			Address: []byte("0xcollection"),
+			TokenID: []byte("1"),
+		}},
+	}
+}
+
+func TestReconcilerDifferences(t *testing.T) {
+	deletedAt := sql.NullTime{Time: time.Unix(1700000000, 0).UTC(), Valid: true}
+
+	cases := []struct {
+		name     string
+		modify   func(state *reconcilerState)
+		expected []data.ReconcileDifference
+	}{
+		{
+			name:     "in sync",
+			modify:   func(state *reconcilerState) {},
+			expected: nil,
+		},
+		{
+			name: "collection is not stored",
+			modify: func(state *reconcilerState) {
+				state.collections = nil
+				state.collectionData = nil
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionMissing, Collection: "collection"},
+				{Kind: data.ReconcileItemChanged, Collection: "collection", Item: "item"},
+			},
+		},
+		{
+			name: "collection is deleted",
+			modify: func(state *reconcilerState) {
+				state.collections[0].DeletedAt = deletedAt
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionMissing, Collection: "collection"},
+			},
+		},
+		{
+			name: "collection is not in core",
+			modify: func(state *reconcilerState) {
+				state.coreCollections = nil
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionExtra, Collection: "collection"},
+			},
+		},
+		{
+			name: "deleted collection is not in core",
+			modify: func(state *reconcilerState) {
+				state.coreCollections = nil
+				state.collections[0].DeletedAt = deletedAt
+			},
+			expected: nil,
+		},
+		{
+			name: "collection is created on core after it was read",
+			modify: func(state *reconcilerState) {
+				state.createdCollections = state.coreCollections
+				state.coreCollections = nil
+			},
+			expected: nil,
+		},
+		{
+			name: "collection metadata",
+			modify: func(state *reconcilerState) {
+				state.coreCollections[0].Meta.Name = "Renamed"
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionMetadata, Collection: "collection"},
+			},
+		},
+		{
+			name: "collection data is not stored",
+			modify: func(state *reconcilerState) {
+				state.collectionData = nil
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionDataMissing, Collection: "collection", Chain: "Goerli"},
+			},
+		},
+		{
+			name: "collection data is changed",
+			modify: func(state *reconcilerState) {
+				state.coreCollectionData[0].Wrapped = true
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionDataChanged, Collection: "collection", Chain: "Goerli"},
+			},
+		},
+		{
+			name: "collection data is not in core",
+			modify: func(state *reconcilerState) {
+				state.collectionData = append(state.collectionData, data.CollectionChainMapping{
+					Collection: 1,
+					Network:    2,
+					Address:    []byte("collection-mint"),
+				})
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileCollectionDataExtra, Collection: "collection", Chain: "Solana"},
+			},
+		},
+		{
+			name: "collection data is created on core after it was read",
+			modify: func(state *reconcilerState) {
+				created := state.coreCollections[0]
+				created.Data = append(created.Data, &tokenmanager.CollectionDataIndex{Chain: "Solana", Address: "collection-mint"})
+				state.createdCollections = []tokenmanager.Collection{created}
+
+				state.collectionData = append(state.collectionData, data.CollectionChainMapping{
+					Collection: 1,
+					Network:    2,
+					Address:    []byte("collection-mint"),
+				})
+			},
+			expected: nil,
+		},
+		{
+			name: "item is not stored",
+			modify: func(state *reconcilerState) {
+				state.items = nil
+				state.itemMappings = nil
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemMissing, Collection: "collection", Item: "item"},
+			},
+		},
+		{
+			name: "item is deleted",
+			modify: func(state *reconcilerState) {
+				state.items[0].DeletedAt = deletedAt
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemMissing, Collection: "collection", Item: "item"},
+			},
+		},
+		{
+			name: "item is not in core",
+			modify: func(state *reconcilerState) {
+				state.coreItems = nil
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemExtra, Item: "item"},
+			},
+		},
+		{
+			name: "item is created on core after it was read",
+			modify: func(state *reconcilerState) {
+				state.createdItems = state.coreItems
+				state.coreItems = nil
+			},
+			expected: nil,
+		},
+		{
+			name: "item metadata",
+			modify: func(state *reconcilerState) {
+				state.coreItems[0].Meta.Uri = "https://example.com/2.json"
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemChanged, Collection: "collection", Item: "item"},
+			},
+		},
+		{
+			name: "item collection",
+			modify: func(state *reconcilerState) {
+				state.items[0].Collection = sql.NullInt64{}
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemChanged, Collection: "collection", Item: "item"},
+			},
+		},
+		{
+			name: "on-chain item is not stored",
+			modify: func(state *reconcilerState) {
+				state.itemMappings = nil
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemOnChainMissing, Collection: "collection", Item: "item", Chain: "Goerli"},
+			},
+		},
+		{
+			name: "on-chain item is changed",
+			modify: func(state *reconcilerState) {
+				state.coreItems[0].OnChain[0].TokenID = "2"
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemOnChainChanged, Collection: "collection", Item: "item", Chain: "Goerli"},
+			},
+		},
+		{
+			name: "on-chain item is not in core",
+			modify: func(state *reconcilerState) {
+				state.itemMappings = append(state.itemMappings, data.ItemChainMapping{
+					Item:    1,
+					Network: 2,
+					Address: []byte("item-mint"),
+				})
+			},
+			expected: []data.ReconcileDifference{
+				{Kind: data.ReconcileItemOnChainExtra, Collection: "collection", Item: "item", Chain: "Solana"},
+			},
+		},
+		{
+			name: "on-chain item is created on core after it was read",
+			modify: func(state *reconcilerState) {
+				created := state.coreItems[0]
+				created.OnChain = append(created.OnChain, &tokenmanager.OnChainItemIndex{Chain: "Solana", Address: "item-mint"})
+				state.createdItems = []tokenmanager.Item{created}
+
+				state.itemMappings = append(state.itemMappings, data.ItemChainMapping{
+					Item:    1,
+					Network: 2,
+					Address: []byte("item-mint"),
+				})
+			},
+			expected: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := newSyncedReconcilerState(t)
+			c.modify(state)
+
+			reconciler := &Reconciler{
+				storage: reconcilerStorage{state: state},
+				chains: mem.NewChainsQ([]data.Chain{
+					{ID: 1, Name: "Goerli", Type: tokenmanager.NetworkType_EVM},
+					{ID: 2, Name: "Solana", Type: tokenmanager.NetworkType_Solana},
+				}),
+				tokenmanager:   reconcilerTokenmanager{state: state},
+				pageSize:       1,
+				maxDifferences: 100,
+			}
+
+			report := data.ReconcileReport{Counts: make(map[string]int64)}
+
+			collections, err := reconciler.reconcileCollections(context.Background(), &report)
+			require.NoError(t, err)
+			require.NoError(t, reconciler.reconcileItems(context.Background(), &report, collections))
+
+			assert.ElementsMatch(t, c.expected, report.Differences)
+			assert.Zero(t, report.Repaired)
+
+			for _, diff := range c.expected {
+				assert.Equal(t, int64(1), report.Counts[diff.Kind], diff.Kind)
+			}
+		})
+	}
+}
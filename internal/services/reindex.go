@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const defaultReindexPageSize = 100
+
+type ReindexOpts struct {
+	// FromHeight and ToHeight limit the blocks of the transactions to reindex, both inclusive,
+	// zero means no limit
+	FromHeight int64
+	ToHeight   int64
+	PageSize   uint64
+}
+
+// RunReindexFromDB re-derives transfers, votes, approvals, rejections and confirmations from
+// the events of the transactions stored in the database, so the chain does not have to be
+// crawled once again after the schema changes or indexer fixes. Operations details are still
+// requested from the core.
+func RunReindexFromDB(ctx context.Context, cfg config.Config, opts ReindexOpts) error {
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultReindexPageSize
+	}
+
+	log := cfg.Log().WithField("who", "reindex_from_db")
+
+	storage := cfg.NewStorage()
+	stats := &inlineStats{}
+
+	producer := newInlineRarimoCoreOpProducer(cfg, log, storage, func(handler msgs.Handler) QPublisher {
+		return &inlinePublisher{
+			handler: handler,
+			stats:   stats,
+		}
+	})
+
+	selector := data.TransactionSelector{
+		PageSize: opts.PageSize,
+	}
+
+	if opts.FromHeight != 0 {
+		selector.FromHeight = &opts.FromHeight
+	}
+
+	if opts.ToHeight != 0 {
+		selector.ToHeight = &opts.ToHeight
+	}
+
+	processed := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "died by context")
+		}
+
+		transactions, err := storage.TransactionQ().SelectCtx(ctx, selector)
+		if err != nil {
+			return errors.Wrap(err, "failed to select transactions")
+		}
+
+		if len(transactions) == 0 {
+			log.WithFields(stats.fields().Merge(logan.F{
+				"transactions": processed,
+			})).Info("finished reindexing")
+			return nil
+		}
+
+		txs := make([]txWithBlockInfo, 0, len(transactions))
+		for _, tx := range transactions {
+			txInfo, err := txWithBlockInfoFromData(tx)
+			if err != nil {
+				log.WithError(err).WithField("hash", tmbytes.HexBytes(tx.Hash).String()).
+					Warn("skipping transaction with malformed result")
+				continue
+			}
+
+			txs = append(txs, *txInfo)
+		}
+
+		last := transactions[len(transactions)-1]
+
+		f := logan.F{
+			"from_height": transactions[0].BlockHeight.Int64,
+			"to_height":   last.BlockHeight.Int64,
+		}
+
+		if err := producer.publishEvents(ctx, producer.extractEvents(txs), f); err != nil {
+			return errors.Wrap(err, "failed to handle events", f)
+		}
+
+		processed += len(transactions)
+
+		log.WithFields(f.Merge(stats.fields()).Merge(logan.F{
+			"transactions": processed,
+		})).Info("reindexed transactions page")
+
+		selector.AfterHeight = &last.BlockHeight.Int64
+		selector.AfterIndex = &last.Index.Int64
+	}
+}
+
+func txWithBlockInfoFromData(tx data.Transaction) (*txWithBlockInfo, error) {
+	if !tx.TxResult.Valid {
+		return nil, errors.New("transaction result is empty")
+	}
+
+	var txResult abcitypes.ResponseDeliverTx
+	if err := txResult.UnmarshalJSON(tx.TxResult.Jsonb); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transaction result")
+	}
+
+	return &txWithBlockInfo{
+		tx: &coretypes.ResultTx{
+			Hash:     tmbytes.HexBytes(tx.Hash),
+			Height:   tx.BlockHeight.Int64,
+			Index:    uint32(tx.Index.Int64),
+			TxResult: txResult,
+		},
+		blockHeight: tx.BlockHeight.Int64,
+		blockTime:   tx.TxTimestamp,
+	}, nil
+}
@@ -33,13 +33,23 @@ type rejectionIndexer struct {
 	storage data.Storage
 }
 
-func (p *rejectionIndexer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	rejectedTransferIndices := make([]string, 0, len(msgs))
-	rejections := make([]data.Rejection, 0, len(msgs))
+func (p *rejectionIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	return &fork
+}
+
+func (p *rejectionIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
+	statusChanges := make([]data.TransferStatusChange, 0, len(batch))
+	rejections := make([]data.Rejection, 0, len(batch))
 
-	for _, msg := range msgs {
-		rmsg := msg.MustRejectionOpMessage()
-		rejectedTransferIndices = append(rejectedTransferIndices, rmsg.OperationID)
+	for _, msg := range batch {
+		var rmsg msgs.RejectionOpMsg
+		if err := msg.Decode(&rmsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
+		statusChanges = append(statusChanges,
+			newStatusChange(rmsg.OperationID, rarimocore.OpStatus_NOT_APPROVED, rmsg.TransactionHash, rmsg.BlockTime))
 		rejections = append(rejections, data.Rejection{
 			TransferIndex:     []byte(rmsg.OperationID),
 			RarimoTransaction: data.MustDBHash(rmsg.TransactionHash),
@@ -47,24 +57,12 @@ func (p *rejectionIndexer) Handle(ctx context.Context, msgs []msgs.Message) erro
 		})
 	}
 
-	return p.storage.Transaction(func() error {
-		err := p.storage.
-			TransferQ().
-			SetStatusByIndexCtx(ctx, int(rarimocore.OpStatus_NOT_APPROVED), rejectedTransferIndices...)
-		if err != nil {
-			return errors.Wrap(err, "failed to set status by index", logan.F{
-				"status":  int(rarimocore.OpStatus_NOT_APPROVED),
-				"indices": rejectedTransferIndices,
-			})
-		}
-
-		err = p.storage.
-			RejectionQ().
-			InsertBatchCtx(ctx, rejections...)
-		if err != nil {
-			return errors.Wrap(err, "failed to insert rejections")
-		}
+	err := setOperationsStatus(ctx, p.storage, rarimocore.OpStatus_NOT_APPROVED, statusChanges, func() error {
+		return errors.Wrap(p.storage.RejectionQ().InsertBatchCtx(ctx, rejections...), "failed to insert rejections")
+	})
 
-		return nil
+	return errors.Wrap(err, "failed to set status by index", logan.F{
+		"status":  int(rarimocore.OpStatus_NOT_APPROVED),
+		"changes": len(statusChanges),
 	})
 }
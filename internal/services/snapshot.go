@@ -0,0 +1,568 @@
+package services
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const (
+	// SnapshotFormatVersion is increased on every incompatible change of the snapshot archive layout
+	SnapshotFormatVersion = 1
+
+	snapshotManifestFile = "manifest.json"
+	snapshotTablesDir    = "tables/"
+
+	// migrationsTable is managed by the migrate command, so it is compared instead of being restored
+	migrationsTable = "gorp_migrations"
+
+	snapshotImportBatchSize = 500
+)
+
+// DefaultSnapshotCursors matches the block range, per-range and bridge producers cursors, as all of their keys
+// contain the word
+var DefaultSnapshotCursors = []string{"*cursor*", IsGenesisParsedKey}
+
+type SnapshotManifest struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Migrations are the ids of the migrations applied to the exported database, the database to import the snapshot
+	// into must have the same ones
+	Migrations []string `json:"migrations"`
+	// Tables are listed in the order they have to be restored in to satisfy the foreign keys
+	Tables  []SnapshotTable `json:"tables"`
+	Cursors []data.KeyValue `json:"cursors"`
+}
+
+type SnapshotTable struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+type SnapshotExportOpts struct {
+	Output io.Writer
+	// Cursors are the redis key patterns of the cursors to export, DefaultSnapshotCursors are used if empty
+	Cursors []string
+}
+
+// ExportSnapshot writes the zip archive with all the tables of the database and the cursors of the producers.
+// Cursors are read before the database, so the resumed indexing could only handle some blocks again, which is
+// safe, instead of skipping the ones not reflected in the snapshot.
+func ExportSnapshot(ctx context.Context, cfg config.Config, opts SnapshotExportOpts) (*SnapshotManifest, error) {
+	log := cfg.Log().WithField("who", "snapshot_export")
+
+	patterns := opts.Cursors
+	if len(patterns) == 0 {
+		patterns = DefaultSnapshotCursors
+	}
+
+	cursors, err := readSnapshotCursors(ctx, cfg.RedisClient(), patterns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read cursors")
+	}
+
+	// repeatable read makes all the tables to be read from the same database state
+	tx, err := cfg.DB().RawDB().BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	manifest := SnapshotManifest{
+		FormatVersion: SnapshotFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Cursors:       cursors,
+	}
+
+	manifest.Migrations, err = selectMigrations(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select applied migrations")
+	}
+
+	tables, err := selectTablesInOrder(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select tables")
+	}
+
+	archive := zip.NewWriter(opts.Output)
+
+	for _, table := range tables {
+		rows, err := exportTable(ctx, tx, archive, table)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to export table", logan.F{
+				"table": table,
+			})
+		}
+
+		manifest.Tables = append(manifest.Tables, SnapshotTable{Name: table, Rows: rows})
+		log.WithFields(logan.F{"table": table, "rows": rows}).Debug("table exported")
+	}
+
+	if err := writeZipJSON(archive, snapshotManifestFile, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to write manifest")
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close archive")
+	}
+
+	log.WithFields(logan.F{
+		"tables":  len(manifest.Tables),
+		"cursors": len(manifest.Cursors),
+	}).Info("snapshot exported")
+
+	return &manifest, nil
+}
+
+type SnapshotImportOpts struct {
+	Input *zip.Reader
+	// Force truncates the tables which are not empty, otherwise import fails on them
+	Force bool
+}
+
+// ImportSnapshot restores the tables and the cursors from the archive written by ExportSnapshot, so indexing resumes
+// from the exported heights. Database has to be migrated to the same version as the exported one.
+func ImportSnapshot(ctx context.Context, cfg config.Config, opts SnapshotImportOpts) (*SnapshotManifest, error) {
+	log := cfg.Log().WithField("who", "snapshot_import")
+
+	var manifest SnapshotManifest
+	if err := readZipJSON(opts.Input, snapshotManifestFile, &manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+
+	if manifest.FormatVersion != SnapshotFormatVersion {
+		return nil, errors.From(errors.New("unsupported snapshot format version"), logan.F{
+			"format_version":   manifest.FormatVersion,
+			"supported_format": SnapshotFormatVersion,
+		})
+	}
+
+	tx, err := cfg.DB().RawDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	migrations, err := selectMigrations(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select applied migrations")
+	}
+
+	if !equalStrings(migrations, manifest.Migrations) {
+		return nil, errors.From(errors.New("database is migrated to the other version than the snapshot one"), logan.F{
+			"database_migrations": migrations,
+			"snapshot_migrations": manifest.Migrations,
+		})
+	}
+
+	for _, table := range manifest.Tables {
+		if err := prepareTable(ctx, tx, table.Name, opts.Force); err != nil {
+			return nil, errors.Wrap(err, "failed to prepare table", logan.F{
+				"table": table.Name,
+			})
+		}
+	}
+
+	for _, table := range manifest.Tables {
+		if err := importTable(ctx, tx, opts.Input, table); err != nil {
+			return nil, errors.Wrap(err, "failed to import table", logan.F{
+				"table": table.Name,
+			})
+		}
+
+		log.WithFields(logan.F{"table": table.Name, "rows": table.Rows}).Debug("table imported")
+	}
+
+	if err := resetSequences(ctx, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to reset sequences")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// cursors are restored only after the tables, so the indexing started in the meantime can not skip blocks
+	if err := writeSnapshotCursors(ctx, cfg.RedisClient(), manifest.Cursors); err != nil {
+		return nil, errors.Wrap(err, "failed to restore cursors")
+	}
+
+	log.WithFields(logan.F{
+		"tables":  len(manifest.Tables),
+		"cursors": len(manifest.Cursors),
+	}).Info("snapshot imported")
+
+	return &manifest, nil
+}
+
+func readSnapshotCursors(ctx context.Context, cli *redis.Client, patterns []string) ([]data.KeyValue, error) {
+	seen := make(map[string]struct{})
+	cursors := make([]data.KeyValue, 0)
+
+	for _, pattern := range patterns {
+		iter := cli.Scan(ctx, 0, pattern, 100).Iterator()
+
+		for iter.Next(ctx) {
+			key := iter.Val()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			raw, err := cli.Get(ctx, key).Result()
+			if err != nil {
+				if err == redis.Nil || isWrongTypeError(err) {
+					continue // expired or not a key value entry, e.g. the queue matching the pattern
+				}
+
+				return nil, errors.Wrap(err, "failed to get cursor", logan.F{"key": key})
+			}
+
+			var kv data.KeyValue
+			if err := json.Unmarshal([]byte(raw), &kv); err != nil || kv.Key != key {
+				continue // not stored by the key value provider
+			}
+
+			cursors = append(cursors, kv)
+		}
+
+		if err := iter.Err(); err != nil {
+			return nil, errors.Wrap(err, "failed to scan cursors", logan.F{"pattern": pattern})
+		}
+	}
+
+	sort.Slice(cursors, func(i, j int) bool {
+		return cursors[i].Key < cursors[j].Key
+	})
+
+	return cursors, nil
+}
+
+func writeSnapshotCursors(ctx context.Context, cli *redis.Client, cursors []data.KeyValue) error {
+	for _, kv := range cursors {
+		raw, err := json.Marshal(kv)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal cursor", logan.F{"key": kv.Key})
+		}
+
+		if err := cli.Set(ctx, kv.Key, raw, 0).Err(); err != nil {
+			return errors.Wrap(err, "failed to set cursor", logan.F{"key": kv.Key})
+		}
+	}
+
+	return nil
+}
+
+func isWrongTypeError(err error) bool {
+	return strings.HasPrefix(err.Error(), "WRONGTYPE")
+}
+
+func selectMigrations(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM public."+migrationsTable+" ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query migrations")
+	}
+	defer rows.Close()
+
+	migrations := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "failed to scan migration")
+		}
+
+		migrations = append(migrations, id)
+	}
+
+	return migrations, rows.Err()
+}
+
+// selectTablesInOrder returns the tables of the public schema ordered so the referenced tables go first
+func selectTablesInOrder(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	tables := make([]string, 0)
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename <> $1 ORDER BY tablename", migrationsTable)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query tables")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, errors.Wrap(err, "failed to scan table")
+		}
+
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate tables")
+	}
+
+	refs, err := tx.QueryContext(ctx, `
+		SELECT t.relname, r.relname
+		FROM pg_constraint c
+			JOIN pg_class t ON t.oid = c.conrelid
+			JOIN pg_class r ON r.oid = c.confrelid
+		WHERE c.contype = 'f' AND c.connamespace = 'public'::regnamespace`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query foreign keys")
+	}
+	defer refs.Close()
+
+	dependencies := make(map[string][]string)
+	for refs.Next() {
+		var table, referenced string
+		if err := refs.Scan(&table, &referenced); err != nil {
+			return nil, errors.Wrap(err, "failed to scan foreign key")
+		}
+
+		if table != referenced {
+			dependencies[table] = append(dependencies[table], referenced)
+		}
+	}
+
+	if err := refs.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate foreign keys")
+	}
+
+	return orderTables(tables, dependencies), nil
+}
+
+// orderTables sorts the tables topologically by the tables they reference, keeping the given order otherwise
+func orderTables(tables []string, dependencies map[string][]string) []string {
+	ordered := make([]string, 0, len(tables))
+	visited := make(map[string]bool, len(tables))
+
+	var visit func(table string)
+	visit = func(table string) {
+		if visited[table] {
+			return
+		}
+
+		visited[table] = true
+		for _, referenced := range dependencies[table] {
+			visit(referenced)
+		}
+
+		ordered = append(ordered, table)
+	}
+
+	for _, table := range tables {
+		visit(table)
+	}
+
+	return ordered
+}
+
+func exportTable(ctx context.Context, tx *sql.Tx, archive *zip.Writer, table string) (int64, error) {
+	file, err := archive.Create(snapshotTablesDir + table + ".jsonl")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create archive file")
+	}
+
+	// bytea columns are encoded as the hex strings, which are parsed back by json_populate_recordset on import
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT row_to_json(t)::text FROM public.%q t", table))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query rows")
+	}
+	defer rows.Close()
+
+	w := bufio.NewWriter(file)
+
+	var count int64
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return 0, errors.Wrap(err, "failed to scan row")
+		}
+
+		if _, err := w.WriteString(row + "\n"); err != nil {
+			return 0, errors.Wrap(err, "failed to write row")
+		}
+
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "failed to iterate rows")
+	}
+
+	return count, w.Flush()
+}
+
+func prepareTable(ctx context.Context, tx *sql.Tx, table string, force bool) error {
+	if force {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("TRUNCATE public.%q CASCADE", table))
+		return errors.Wrap(err, "failed to truncate table")
+	}
+
+	var notEmpty bool
+	err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM public.%q)", table)).Scan(&notEmpty)
+	if err != nil {
+		return errors.Wrap(err, "failed to check table is empty")
+	}
+
+	if notEmpty {
+		return errors.New("table is not empty, use force to truncate it")
+	}
+
+	return nil
+}
+
+func importTable(ctx context.Context, tx *sql.Tx, archive *zip.Reader, table SnapshotTable) error {
+	file, err := archive.Open(snapshotTablesDir + table.Name + ".jsonl")
+	if err != nil {
+		return errors.Wrap(err, "failed to open archive file")
+	}
+	defer file.Close()
+
+	stmt := fmt.Sprintf("INSERT INTO public.%[1]q SELECT * FROM json_populate_recordset(NULL::public.%[1]q, $1::json)", table.Name)
+
+	scanner := bufio.NewScanner(file)
+	// rows with the big metadata or transaction bodies do not fit the default buffer
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	batch := make([]json.RawMessage, 0, snapshotImportBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		raw, err := json.Marshal(batch)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal rows batch")
+		}
+
+		if _, err := tx.ExecContext(ctx, stmt, string(raw)); err != nil {
+			return errors.Wrap(err, "failed to insert rows batch")
+		}
+
+		batch = batch[:0]
+		return nil
+	}
+
+	var count int64
+	for scanner.Scan() {
+		batch = append(batch, json.RawMessage(append([]byte{}, scanner.Bytes()...)))
+		count++
+
+		if len(batch) == snapshotImportBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "failed to read rows")
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if count != table.Rows {
+		return errors.From(errors.New("number of the imported rows differs from the manifest"), logan.F{
+			"imported": count,
+			"expected": table.Rows,
+		})
+	}
+
+	return nil
+}
+
+// resetSequences moves the sequences of the serial columns after the imported values
+func resetSequences(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT table_name::text, column_name::text, pg_get_serial_sequence(quote_ident(table_name), column_name)
+		FROM information_schema.columns
+		WHERE table_schema = 'public' AND column_default LIKE 'nextval(%'`)
+	if err != nil {
+		return errors.Wrap(err, "failed to query serial columns")
+	}
+
+	type serial struct {
+		table, column, sequence string
+	}
+
+	serials := make([]serial, 0)
+	for rows.Next() {
+		var s serial
+		if err := rows.Scan(&s.table, &s.column, &s.sequence); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "failed to scan serial column")
+		}
+
+		serials = append(serials, s)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to iterate serial columns")
+	}
+
+	for _, s := range serials {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("SELECT setval($1, COALESCE((SELECT max(%q) FROM public.%q), 0) + 1, false)",
+			s.column, s.table), s.sequence)
+		if err != nil {
+			return errors.Wrap(err, "failed to reset sequence", logan.F{
+				"table":  s.table,
+				"column": s.column,
+			})
+		}
+	}
+
+	return nil
+}
+
+func writeZipJSON(archive *zip.Writer, name string, value interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive file", logan.F{"name": name})
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+func readZipJSON(archive *zip.Reader, name string, value interface{}) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to open archive file", logan.F{"name": name})
+	}
+	defer file.Close()
+
+	return json.NewDecoder(file).Decode(value)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderTables(t *testing.T) {
+	cases := []struct {
+		name         string
+		tables       []string
+		dependencies map[string][]string
+		expected     []string
+	}{
+		{
+			name:     "no foreign keys",
+			tables:   []string{"blocks", "chains", "transfers"},
+			expected: []string{"blocks", "chains", "transfers"},
+		},
+		{
+			name:   "referenced table goes first",
+			tables: []string{"collections", "items"},
+			dependencies: map[string][]string{
+				"collections": {"items"},
+			},
+			expected: []string{"items", "collections"},
+		},
+		{
+			name:   "chain of references",
+			tables: []string{"item_chain_mappings", "items", "collections"},
+			dependencies: map[string][]string{
+				"item_chain_mappings": {"items"},
+				"items":               {"collections"},
+			},
+			expected: []string{"collections", "items", "item_chain_mappings"},
+		},
+		{
+			name:   "table referencing several tables",
+			tables: []string{"approvals", "confirmations", "transactions", "transfers"},
+			dependencies: map[string][]string{
+				"approvals":    {"transactions", "transfers"},
+				"transfers":    {"transactions"},
+				"transactions": {},
+			},
+			expected: []string{"transactions", "transfers", "approvals", "confirmations"},
+		},
+		{
+			name:   "table referenced by several tables is exported once",
+			tables: []string{"item_chain_mappings", "items", "seeds"},
+			dependencies: map[string][]string{
+				"item_chain_mappings": {"items"},
+				"seeds":               {"items"},
+			},
+			expected: []string{"items", "item_chain_mappings", "seeds"},
+		},
+		{
+			name:   "cycle does not loop",
+			tables: []string{"a", "b"},
+			dependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+			},
+			expected: []string{"b", "a"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, orderTables(c.tables, c.dependencies))
+		})
+	}
+}
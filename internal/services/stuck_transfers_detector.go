@@ -0,0 +1,227 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"gitlab.com/distributed_lab/running"
+)
+
+var stuckTransferKinds = []string{
+	data.StuckTransferInitialized,
+	data.StuckTransferNotWithdrawn,
+	data.StuckTransferWithdrawalFailed,
+}
+
+func RunStuckTransfersDetector(ctx context.Context, cfg config.Config) {
+	log := cfg.Log().WithField("who", cfg.StuckTransfersDetector().RunnerName)
+
+	detector := stuckTransfersDetector{
+		log:     log,
+		storage: cfg.NewStorage(),
+		config:  cfg.StuckTransfersDetector(),
+		client: &http.Client{
+			Timeout: cfg.StuckTransfersDetector().Webhook.Timeout,
+		},
+	}
+
+	// replicas would notify about the same alerts, so only the lease holder runs the detector
+	RunAsLeader(ctx, cfg, "stuck_transfers_detector", func(ctx context.Context) {
+		running.WithBackOff(ctx,
+			log,
+			cfg.StuckTransfersDetector().RunnerName,
+			detector.detectOnce,
+			cfg.StuckTransfersDetector().Period,
+			time.Second*10,
+			time.Minute*5,
+		)
+	})
+}
+
+type stuckTransfersDetector struct {
+	log     *logan.Entry
+	storage data.Storage
+	config  *config.StuckTransfersDetectorConfig
+	client  *http.Client
+}
+
+func (d *stuckTransfersDetector) detectOnce(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	for _, kind := range stuckTransferKinds {
+		if err := d.detect(ctx, kind, now); err != nil {
+			return errors.Wrap(err, "failed to detect stuck transfers", logan.F{
+				"kind": kind,
+			})
+		}
+	}
+
+	return d.notify(ctx)
+}
+
+// detect stores the alerts of the transfers stuck longer than the threshold of their destination chain and
+// resolves the alerts of the transfers which are not stuck anymore
+func (d *stuckTransfersDetector) detect(ctx context.Context, kind string, now time.Time) error {
+	candidates, err := d.storage.StuckTransferAlertQ().SelectCandidatesCtx(ctx, data.StuckTransferCandidatesSelector{
+		Kind:   kind,
+		Before: now.Add(-d.minThreshold(kind)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to select stuck transfers candidates")
+	}
+
+	alerts := make([]data.StuckTransferAlert, 0, len(candidates))
+	indexes := make([][]byte, 0, len(candidates))
+
+	for _, alert := range candidates {
+		if alert.Since.After(now.Add(-d.threshold(kind, alert.Chain))) {
+			continue
+		}
+
+		alert.DetectedAt = now
+		alerts = append(alerts, alert)
+		indexes = append(indexes, alert.TransferIndex)
+	}
+
+	return d.storage.Transaction(func() error {
+		if err := d.storage.StuckTransferAlertQ().UpsertBatchCtx(ctx, alerts...); err != nil {
+			return errors.Wrap(err, "failed to upsert stuck transfer alerts")
+		}
+
+		if err := d.storage.StuckTransferAlertQ().ResolveCtx(ctx, kind, indexes...); err != nil {
+			return errors.Wrap(err, "failed to resolve stuck transfer alerts")
+		}
+
+		return nil
+	})
+}
+
+// threshold returns the time the transfer to the chain may stay in the state of the alert kind, failed
+// withdrawals are reported right away
+func (d *stuckTransfersDetector) threshold(kind, chain string) time.Duration {
+	thresholds := d.config.ChainThresholds(chain)
+
+	switch kind {
+	case data.StuckTransferInitialized:
+		return thresholds.Initialized
+	case data.StuckTransferNotWithdrawn:
+		return thresholds.NotWithdrawn
+	default:
+		return 0
+	}
+}
+
+// minThreshold returns the lowest threshold among all the chains, so candidates of all of them are selected at once
+func (d *stuckTransfersDetector) minThreshold(kind string) time.Duration {
+	result := d.threshold(kind, "")
+
+	for _, chain := range d.config.Chains {
+		if threshold := d.threshold(kind, chain.Name); threshold < result {
+			result = threshold
+		}
+	}
+
+	return result
+}
+
+type stuckTransferEvent struct {
+	ID            int64     `json:"id"`
+	Kind          string    `json:"kind"`
+	TransferIndex string    `json:"transfer_index"`
+	Origin        string    `json:"origin"`
+	Chain         string    `json:"chain"`
+	Status        int       `json:"status"`
+	Since         time.Time `json:"since"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+// notify logs the open alerts which were not notified yet and sends them to the webhook if it is configured
+func (d *stuckTransfersDetector) notify(ctx context.Context) error {
+	resolved, notified := false, false
+
+	alerts, err := d.storage.StuckTransferAlertQ().SelectCtx(ctx, data.StuckTransferAlertSelector{
+		Resolved: &resolved,
+		Notified: &notified,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to select alerts to notify")
+	}
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	events := make([]stuckTransferEvent, len(alerts))
+	ids := make([]int64, len(alerts))
+
+	for i, alert := range alerts {
+		events[i] = stuckTransferEvent{
+			ID:            alert.ID,
+			Kind:          alert.Kind,
+			TransferIndex: string(alert.TransferIndex),
+			Origin:        alert.Origin,
+			Chain:         alert.Chain,
+			Status:        alert.Status,
+			Since:         alert.Since,
+			DetectedAt:    alert.DetectedAt,
+		}
+		ids[i] = alert.ID
+
+		d.log.WithFields(logan.F{
+			"kind":        alert.Kind,
+			"transfer":    string(alert.TransferIndex),
+			"origin":      alert.Origin,
+			"chain":       alert.Chain,
+			"stuck_since": alert.Since,
+		}).Warn("transfer is stuck")
+	}
+
+	if d.config.Webhook.URL != "" {
+		if err := d.sendWebhook(ctx, events); err != nil {
+			return errors.Wrap(err, "failed to send alerts to the webhook")
+		}
+	}
+
+	if err := d.storage.StuckTransferAlertQ().SetNotifiedCtx(ctx, ids...); err != nil {
+		return errors.Wrap(err, "failed to mark alerts notified")
+	}
+
+	return nil
+}
+
+func (d *stuckTransfersDetector) sendWebhook(ctx context.Context, events []stuckTransferEvent) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"alerts": events,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal alerts")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.config.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send webhook request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.From(errors.New("webhook responded with unexpected status"), logan.F{
+			"status_code": resp.StatusCode,
+		})
+	}
+
+	return nil
+}
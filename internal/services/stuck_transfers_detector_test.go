@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/config"
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// alertsStorage keeps the alerts of the detector in memory
+type alertsStorage struct {
+	data.Storage
+	alerts *alertsQ
+}
+
+func (s alertsStorage) StuckTransferAlertQ() data.StuckTransferAlertQ {
+	return s.alerts
+}
+
+func (s alertsStorage) Transaction(fn func() error) error {
+	return fn()
+}
+
+type alertsQ struct {
+	data.StuckTransferAlertQ
+	candidates []data.StuckTransferAlert
+
+	selector data.StuckTransferCandidatesSelector
+	upserted []data.StuckTransferAlert
+	kept     [][]byte
+}
+
+func (q *alertsQ) SelectCandidatesCtx(_ context.Context, selector data.StuckTransferCandidatesSelector) ([]data.StuckTransferAlert, error) {
+	q.selector = selector
+	return q.candidates, nil
+}
+
+func (q *alertsQ) UpsertBatchCtx(_ context.Context, alerts ...data.StuckTransferAlert) error {
+	q.upserted = alerts
+	return nil
+}
+
+func (q *alertsQ) ResolveCtx(_ context.Context, _ string, except ...[]byte) error {
+	q.kept = except
+	return nil
+}
+
+func newTestStuckTransfersDetector(alerts *alertsQ) *stuckTransfersDetector {
+	return &stuckTransfersDetector{
+		storage: alertsStorage{alerts: alerts},
+		config: &config.StuckTransfersDetectorConfig{
+			Thresholds: config.StuckTransferThresholds{
+				Initialized:  time.Hour,
+				NotWithdrawn: 2 * time.Hour,
+			},
+			Chains: []*config.StuckTransfersChainConfig{
+				{Name: "Fast", Thresholds: config.StuckTransferThresholds{Initialized: 10 * time.Minute}},
+				{Name: "Slow", Thresholds: config.StuckTransferThresholds{NotWithdrawn: 6 * time.Hour}},
+			},
+		},
+	}
+}
+
+func TestStuckTransfersDetectorThresholds(t *testing.T) {
+	detector := newTestStuckTransfersDetector(&alertsQ{})
+
+	cases := []struct {
+		name     string
+		kind     string
+		chain    string
+		expected time.Duration
+	}{
+		{"chain threshold", data.StuckTransferInitialized, "Fast", 10 * time.Minute},
+		{"chain name is case insensitive", data.StuckTransferNotWithdrawn, "slow", 6 * time.Hour},
+		{"fallback for the unset chain threshold", data.StuckTransferNotWithdrawn, "Fast", 2 * time.Hour},
+		{"fallback for the unknown chain", data.StuckTransferInitialized, "Unknown", time.Hour},
+		{"failed withdrawals are reported right away", data.StuckTransferWithdrawalFailed, "Slow", 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, detector.threshold(c.kind, c.chain))
+		})
+	}
+
+	for kind, expected := range map[string]time.Duration{
+		data.StuckTransferInitialized:      10 * time.Minute,
+		data.StuckTransferNotWithdrawn:     2 * time.Hour,
+		data.StuckTransferWithdrawalFailed: 0,
+	} {
+		assert.Equal(t, expected, detector.minThreshold(kind), kind)
+	}
+}
+
+func TestStuckTransfersDetectorDetect(t *testing.T) {
+	now := time.Now().UTC()
+
+	candidate := func(index, chain string, stuckFor time.Duration) data.StuckTransferAlert {
+		return data.StuckTransferAlert{
+			TransferIndex: []byte(index),
+			Chain:         chain,
+			Since:         now.Add(-stuckFor),
+		}
+	}
+
+	cases := []struct {
+		name       string
+		kind       string
+		candidates []data.StuckTransferAlert
+		expected   []string
+	}{
+		{
+			name: "chain thresholds",
+			kind: data.StuckTransferInitialized,
+			candidates: []data.StuckTransferAlert{
+				candidate("fast-stuck", "Fast", 15*time.Minute),
+				candidate("default-waiting", "Unknown", 15*time.Minute),
+				candidate("default-stuck", "Unknown", 2*time.Hour),
+			},
+			expected: []string{"fast-stuck", "default-stuck"},
+		},
+		{
+			name: "fallback to the default threshold",
+			kind: data.StuckTransferNotWithdrawn,
+			candidates: []data.StuckTransferAlert{
+				candidate("fast-stuck", "Fast", 3*time.Hour),
+				candidate("slow-waiting", "Slow", 3*time.Hour),
+				candidate("slow-stuck", "Slow", 7*time.Hour),
+			},
+			expected: []string{"fast-stuck", "slow-stuck"},
+		},
+		{
+			name: "failed withdrawals",
+			kind: data.StuckTransferWithdrawalFailed,
+			candidates: []data.StuckTransferAlert{
+				candidate("failed", "Slow", 0),
+			},
+			expected: []string{"failed"},
+		},
+		{
+			name:     "nothing stuck",
+			kind:     data.StuckTransferInitialized,
+			expected: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			alerts := &alertsQ{candidates: c.candidates}
+			detector := newTestStuckTransfersDetector(alerts)
+
+			require.NoError(t, detector.detect(context.Background(), c.kind, now))
+
+			assert.Equal(t, c.kind, alerts.selector.Kind)
+			assert.Equal(t, now.Add(-detector.minThreshold(c.kind)), alerts.selector.Before)
+
+			stored := make([]string, len(alerts.upserted))
+			for i, alert := range alerts.upserted {
+				stored[i] = string(alert.TransferIndex)
+				assert.Equal(t, now, alert.DetectedAt)
+			}
+
+			kept := make([]string, len(alerts.kept))
+			for i, index := range alerts.kept {
+				kept[i] = string(index)
+			}
+
+			assert.Equal(t, c.expected, stored)
+			assert.Equal(t, c.expected, kept)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+type Status string
+
+const (
+	StatusRunning    Status = "running"
+	StatusRestarting Status = "restarting"
+	StatusStopping   Status = "stopping"
+	StatusStopped    Status = "stopped"
+)
+
+// Service is the long-running routine supervised in the process, it has to return once the context is done
+type Service struct {
+	Name string
+	Run  func(ctx context.Context)
+	// DependsOn are the services started before this one and stopped after it. Producers depend on the indexers
+	// consuming their queues, so the indexers handle the published messages after the producer is stopped.
+	DependsOn []string
+}
+
+type Opts struct {
+	MinBackoff      time.Duration
+	MaxBackoff      time.Duration
+	StableAfter     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type State struct {
+	Name      string     `json:"name"`
+	Status    Status     `json:"status"`
+	Restarts  int        `json:"restarts"`
+	LastError string     `json:"last_error,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	CrashedAt *time.Time `json:"crashed_at,omitempty"`
+}
+
+// StatesProvider returns the states of the supervised services in their start order
+type StatesProvider interface {
+	States() []State
+}
+
+type Supervisor struct {
+	log      *logan.Entry
+	opts     Opts
+	services []Service
+
+	mu     sync.RWMutex
+	states map[string]*State
+}
+
+// New returns the supervisor of the enabled services from the known ones, ordered by their dependencies.
+// Dependencies which are not enabled are ignored.
+func New(log *logan.Entry, opts Opts, known []Service, enabled []string) (*Supervisor, error) {
+	byName := make(map[string]Service, len(known))
+	for _, service := range known {
+		byName[service.Name] = service
+	}
+
+	isEnabled := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		if _, ok := byName[name]; !ok {
+			return nil, errors.From(errors.New("unknown service"), logan.F{"service": name})
+		}
+
+		isEnabled[name] = true
+	}
+
+	if len(isEnabled) == 0 {
+		return nil, errors.New("no services enabled")
+	}
+
+	s := &Supervisor{
+		log:    log.WithField("who", "supervisor"),
+		opts:   opts,
+		states: make(map[string]*State, len(isEnabled)),
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	marks := make(map[string]int, len(isEnabled))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.From(errors.New("services dependency cycle"), logan.F{
+				"cycle": fmt.Sprint(append(path, name)),
+			})
+		}
+
+		marks[name] = visiting
+		for _, dependency := range byName[name].DependsOn {
+			if !isEnabled[dependency] {
+				continue
+			}
+
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+
+		s.services = append(s.services, byName[name])
+		s.states[name] = &State{Name: name, Status: StatusStopped}
+
+		return nil
+	}
+
+	for _, name := range enabled {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Run starts the services with their dependencies first and blocks until the context is done, then stops them in
+// the reverse order waiting for every service to return before stopping the next one
+func (s *Supervisor) Run(ctx context.Context) {
+	type supervised struct {
+		name   string
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+
+	running := make([]supervised, 0, len(s.services))
+
+	for _, service := range s.services {
+		// services are not stopped by the parent context, so the order of their shutdown is controlled here
+		serviceCtx, cancel := context.WithCancel(WithStates(context.Background(), s))
+		done := make(chan struct{})
+
+		go s.supervise(serviceCtx, service, done)
+
+		running = append(running, supervised{name: service.Name, cancel: cancel, done: done})
+		s.log.WithField("service", service.Name).Info("service started")
+	}
+
+	<-ctx.Done()
+
+	for i := len(running) - 1; i >= 0; i-- {
+		service := running[i]
+
+		s.setStatus(service.name, StatusStopping)
+		service.cancel()
+
+		select {
+		case <-service.done:
+			s.log.WithField("service", service.name).Info("service stopped")
+		case <-time.After(s.opts.ShutdownTimeout):
+			s.log.WithField("service", service.name).Warn("service did not stop in time, stopping the next one")
+		}
+	}
+}
+
+// States returns the states of the services in their start order
+func (s *Supervisor) States() []State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make([]State, len(s.services))
+	for i, service := range s.services {
+		states[i] = *s.states[service.Name]
+	}
+
+	return states
+}
+
+func (s *Supervisor) supervise(ctx context.Context, service Service, done chan struct{}) {
+	defer close(done)
+
+	log := s.log.WithField("service", service.Name)
+	backoff := s.opts.MinBackoff
+
+	for {
+		startedAt := time.Now().UTC()
+		s.update(service.Name, func(state *State) {
+			state.Status = StatusRunning
+			state.StartedAt = &startedAt
+		})
+
+		err := runService(ctx, service)
+		if ctx.Err() != nil {
+			s.setStatus(service.Name, StatusStopped)
+			return
+		}
+
+		if err == nil {
+			err = errors.New("service returned before it was stopped")
+		}
+
+		if time.Since(startedAt) >= s.opts.StableAfter {
+			backoff = s.opts.MinBackoff
+		}
+
+		crashedAt := time.Now().UTC()
+		s.update(service.Name, func(state *State) {
+			state.Status = StatusRestarting
+			state.Restarts++
+			state.LastError = err.Error()
+			state.CrashedAt = &crashedAt
+		})
+
+		log.WithError(err).WithField("backoff", backoff.String()).Error("service crashed, restarting")
+
+		select {
+		case <-ctx.Done():
+			s.setStatus(service.Name, StatusStopped)
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > s.opts.MaxBackoff {
+			backoff = s.opts.MaxBackoff
+		}
+	}
+}
+
+// runService turns the panic of the service into the error, so it is restarted as the returned one
+func runService(ctx context.Context, service Service) (err error) {
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			err = errors.Wrap(errors.FromPanic(rvr), "service panicked")
+		}
+	}()
+
+	service.Run(ctx)
+	return nil
+}
+
+func (s *Supervisor) setStatus(name string, status Status) {
+	s.update(name, func(state *State) {
+		state.Status = status
+	})
+}
+
+func (s *Supervisor) update(name string, f func(state *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f(s.states[name])
+}
+
+type ctxKey int
+
+const statesCtxKey ctxKey = iota
+
+// WithStates puts the states provider to the context of the supervised services, so the API could expose them
+func WithStates(ctx context.Context, provider StatesProvider) context.Context {
+	return context.WithValue(ctx, statesCtxKey, provider)
+}
+
+// StatesFromContext returns the states provider of the supervisor or nil if the service is not supervised
+func StatesFromContext(ctx context.Context) StatesProvider {
+	provider, _ := ctx.Value(statesCtxKey).(StatesProvider)
+	return provider
+}
@@ -0,0 +1,121 @@
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+var testOpts = Opts{
+	MinBackoff:      time.Millisecond,
+	MaxBackoff:      10 * time.Millisecond,
+	StableAfter:     time.Second,
+	ShutdownTimeout: time.Second,
+}
+
+func TestNewOrdersDependenciesFirst(t *testing.T) {
+	known := []Service{
+		{Name: "producer", DependsOn: []string{"indexer", "disabled"}},
+		{Name: "indexer"},
+		{Name: "api"},
+		{Name: "disabled"},
+	}
+
+	s, err := New(logan.New(), testOpts, known, []string{"producer", "api", "indexer"})
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, state := range s.States() {
+		names = append(names, state.Name)
+	}
+
+	assert.Equal(t, []string{"indexer", "producer", "api"}, names)
+}
+
+func TestNewRejectsCyclesAndUnknown(t *testing.T) {
+	known := []Service{
+		{Name: "a", DependsOn: []string{"b"}},
+		{Name: "b", DependsOn: []string{"a"}},
+	}
+
+	_, err := New(logan.New(), testOpts, known, []string{"a", "b"})
+	assert.Error(t, err)
+
+	_, err = New(logan.New(), testOpts, known, []string{"c"})
+	assert.Error(t, err)
+}
+
+func TestRunRestartsAndStopsInOrder(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		stopped []string
+		runs    int
+	)
+
+	crashed := make(chan struct{})
+
+	service := func(name string, crashes int) Service {
+		return Service{
+			Name: name,
+			Run: func(ctx context.Context) {
+				mu.Lock()
+				runs++
+				crash := name == "producer" && runs <= crashes+1
+				mu.Unlock()
+
+				if crash {
+					panic("crash")
+				}
+
+				if name == "producer" {
+					close(crashed)
+				}
+
+				<-ctx.Done()
+
+				mu.Lock()
+				stopped = append(stopped, name)
+				mu.Unlock()
+			},
+		}
+	}
+
+	producer := service("producer", 2)
+	producer.DependsOn = []string{"indexer"}
+
+	s, err := New(logan.New(), testOpts, []Service{producer, service("indexer", 0)}, []string{"producer", "indexer"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-crashed:
+	case <-time.After(time.Second):
+		t.Fatal("producer was not restarted")
+	}
+
+	cancel()
+	<-done
+
+	assert.Equal(t, []string{"producer", "indexer"}, stopped)
+
+	for _, state := range s.States() {
+		assert.Equal(t, StatusStopped, state.Status)
+
+		if state.Name == "producer" {
+			assert.Equal(t, 2, state.Restarts)
+			assert.Contains(t, state.LastError, "service panicked")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/rarimo/horizon-svc/internal/data"
+	"github.com/rarimo/horizon-svc/pkg/msgs"
+	tokenmanager "github.com/rarimo/rarimo-core/x/tokenmanager/types"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// tokenmanagerEvent is the entry of the items and collections history, it is appended on every
+// change of them, so the history stays available after they are removed
+type tokenmanagerEvent struct {
+	typ        msgs.MessageType
+	source     msgs.EventSource
+	collection string
+	item       string
+	chain      string
+	details    tokenmanagerEventDetails
+}
+
+// tokenmanagerEventDetails is the state of the changed item, collection or their chain data right
+// after the change
+type tokenmanagerEventDetails struct {
+	Collection string          `json:"collection,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+	Address    string          `json:"address,omitempty"`
+	TokenID    string          `json:"token_id,omitempty"`
+	TokenType  *int32          `json:"token_type,omitempty"`
+	Wrapped    *bool           `json:"wrapped,omitempty"`
+	Decimals   *uint32         `json:"decimals,omitempty"`
+	Seed       string          `json:"seed,omitempty"`
+}
+
+// key identifies the event among the others emitted by the same transaction, it is empty for
+// the messages without the source, so such events are never deduplicated
+func (e tokenmanagerEvent) key() string {
+	if e.source.TxHash == "" {
+		return ""
+	}
+
+	return strings.Join([]string{e.source.TxHash, string(e.typ), e.collection, e.item, e.chain, e.details.Seed}, ":")
+}
+
+// reconcileEventType is the type of the event recording the difference with core repaired by the reconciler,
+// e.g. `reconcile_item_missing`, so such changes are told apart from the ones made on core
+func reconcileEventType(kind string) msgs.MessageType {
+	return msgs.MessageType("reconcile_" + kind)
+}
+
+func appendTokenmanagerEvent(ctx context.Context, storage data.Storage, event tokenmanagerEvent) error {
+	details, err := json.Marshal(event.details)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event details")
+	}
+
+	return storage.TokenmanagerEventQ().AppendCtx(ctx, &data.TokenmanagerEvent{
+		Type:            string(event.typ),
+		CollectionIndex: nullString(event.collection),
+		ItemIndex:       nullString(event.item),
+		Chain:           nullString(event.chain),
+		Details:         details,
+		RarimoTx:        nullString(event.source.TxHash),
+		Height: sql.NullInt64{
+			Int64: event.source.Height,
+			Valid: event.source.Height != 0,
+		},
+		EventKey:  nullString(event.key()),
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+func collectionDataEventDetails(collectionData tokenmanager.CollectionData) tokenmanagerEventDetails {
+	tokenType := int32(collectionData.TokenType)
+
+	return tokenmanagerEventDetails{
+		Address:   collectionData.Index.Address,
+		TokenType: &tokenType,
+		Wrapped:   &collectionData.Wrapped,
+		Decimals:  &collectionData.Decimals,
+	}
+}
@@ -26,14 +26,14 @@ import (
 func RunTokenManagerEventsProducer(ctx context.Context, cfg config.Config) {
 	log := cfg.Log().WithField("who", cfg.TokenManagerProducer().RunnerName)
 
-	itemEventsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	itemEventsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.TokenManagerProducer().RunnerName+"_item_events_publisher",
 		cfg.TokenManagerProducer().ItemsQueueName)
 	if err != nil {
 		panic(errors.Wrap(err, "failed to create item events publisher"))
 	}
 
-	collectionEventsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.RedisClient(),
+	collectionEventsPublisher, err := msgs.NewPublisher(cfg.Log(), cfg.Queues(),
 		cfg.TokenManagerProducer().RunnerName+"_collection_events_publisher",
 		cfg.TokenManagerProducer().CollectionsQueueName)
 	if err != nil {
@@ -68,15 +68,21 @@ type tokenManagerOpProducer struct {
 	txQ data.TransactionQ
 }
 
-func (p *tokenManagerOpProducer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	for _, msg := range msgs {
-		brMsg := msg.MustBlockRangeMessage()
+func (p *tokenManagerOpProducer) Handle(ctx context.Context, batch []msgs.Message) error {
+	for _, msg := range batch {
+		var brMsg msgs.BlockRangeMessage
+		if err := msg.Decode(&brMsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
+
 		p.log.WithFields(logan.F{
-			"start": brMsg.Start,
-			"end":   brMsg.End,
+			"start":    brMsg.Start,
+			"end":      brMsg.End,
+			"trace_id": msg.TraceID(),
 		}).Info("received block range message")
 
-		if err := p.produceMsgs(ctx, brMsg); err != nil {
+		// events of the range are published within the trace of the block range message
+		if err := p.produceMsgs(msgs.ContextWithTraceID(ctx, msg.TraceID()), brMsg); err != nil {
 			return errors.Wrap(err, "failed to produce op msgs", logan.F{
 				"start": brMsg.Start,
 				"end":   brMsg.End,
@@ -325,7 +331,10 @@ func extractTxEvents(log *logan.Entry, txs []txWithBlockInfo) tokenManagerEvents
 			"raw": txInfo.tx.TxResult.Log,
 		}).Debug("extracting events from transaction")
 
-		txEventsSet := eventsToMsgs(txInfo.tx.Hash.String(), txInfo.tx.TxResult.Events)
+		txEventsSet := eventsToMsgs(msgs.EventSource{
+			TxHash: txInfo.tx.Hash.String(),
+			Height: txInfo.blockHeight,
+		}, txInfo.tx.TxResult.Events)
 
 		eventsSet.itemEvents = append(eventsSet.itemEvents, txEventsSet.itemEvents...)
 		eventsSet.collectionEvents = append(eventsSet.collectionEvents, txEventsSet.collectionEvents...)
@@ -335,10 +344,13 @@ func extractTxEvents(log *logan.Entry, txs []txWithBlockInfo) tokenManagerEvents
 }
 
 func extractBlockEndEvents(blockResults *coretypes.ResultBlockResults) tokenManagerEvents {
-	return eventsToMsgs(fmt.Sprintf("block:%d", blockResults.Height), blockResults.EndBlockEvents)
+	return eventsToMsgs(msgs.EventSource{
+		TxHash: fmt.Sprintf("block:%d", blockResults.Height),
+		Height: blockResults.Height,
+	}, blockResults.EndBlockEvents)
 }
 
-func eventsToMsgs(txHash string, events []abcitypes.Event) tokenManagerEvents {
+func eventsToMsgs(source msgs.EventSource, events []abcitypes.Event) tokenManagerEvents {
 	eventsSet := tokenManagerEvents{
 		itemEvents:       make([]msgs.Message, 0, len(events)),
 		collectionEvents: make([]msgs.Message, 0, len(events)),
@@ -348,37 +360,37 @@ func eventsToMsgs(txHash string, events []abcitypes.Event) tokenManagerEvents {
 		switch event.Type {
 		case tokentypes.EventTypeCollectionCreated:
 			eventsSet.collectionEvents = append(eventsSet.collectionEvents,
-				mustCollectionCreatedMsg(cosmostypes.Event(event)).Message())
+				mustCollectionCreatedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeCollectionRemoved:
 			eventsSet.collectionEvents = append(eventsSet.collectionEvents,
-				mustCollectionRemovedMsg(cosmostypes.Event(event)).Message())
+				mustCollectionRemovedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeCollectionDataCreated:
 			eventsSet.collectionEvents = append(eventsSet.collectionEvents,
-				mustCollectionDataCreatedMsg(cosmostypes.Event(event)).Message())
+				mustCollectionDataCreatedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeCollectionDataRemoved:
 			eventsSet.collectionEvents = append(eventsSet.collectionEvents,
-				mustCollectionDataRemovedMsg(cosmostypes.Event(event)).Message())
+				mustCollectionDataRemovedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeCollectionDataUpdated:
 			eventsSet.collectionEvents = append(eventsSet.collectionEvents,
-				mustCollectionDataUpdatedMsg(cosmostypes.Event(event)).Message())
+				mustCollectionDataUpdatedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeItemCreated:
 			eventsSet.itemEvents = append(eventsSet.itemEvents,
-				mustItemCreatedMsg(cosmostypes.Event(event)).Message())
+				mustItemCreatedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeItemRemoved:
 			eventsSet.itemEvents = append(eventsSet.itemEvents,
-				mustItemRemovedMsg(cosmostypes.Event(event)).Message())
+				mustItemRemovedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeOnChainItemCreated:
 			eventsSet.itemEvents = append(eventsSet.itemEvents,
-				mustOnChainItemCreatedMsg(cosmostypes.Event(event)).Message())
+				mustOnChainItemCreatedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeOnChainItemRemoved:
 			eventsSet.itemEvents = append(eventsSet.itemEvents,
-				mustOnChainItemRemovedMsg(cosmostypes.Event(event)).Message())
+				mustOnChainItemRemovedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeSeedCreated:
 			eventsSet.itemEvents = append(eventsSet.itemEvents,
-				mustSeedCreatedMsg(cosmostypes.Event(event)).Message())
+				mustSeedCreatedMsg(source, cosmostypes.Event(event)).Message())
 		case tokentypes.EventTypeSeedRemoved:
 			eventsSet.itemEvents = append(eventsSet.itemEvents,
-				mustSeedRemovedMsg(cosmostypes.Event(event)).Message())
+				mustSeedRemovedMsg(source, cosmostypes.Event(event)).Message())
 		}
 	}
 
@@ -388,15 +400,17 @@ func eventsToMsgs(txHash string, events []abcitypes.Event) tokenManagerEvents {
 	return eventsSet
 }
 
-func mustCollectionCreatedMsg(event cosmostypes.Event) msgs.CollectionCreatedMessage {
+func mustCollectionCreatedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.CollectionCreatedMessage {
 	return msgs.CollectionCreatedMessage{
-		Index: mustGetCollectionIndex(event),
+		EventSource: source,
+		Index:       mustGetCollectionIndex(event),
 	}
 }
 
-func mustCollectionRemovedMsg(event cosmostypes.Event) msgs.CollectionRemovedMessage {
+func mustCollectionRemovedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.CollectionRemovedMessage {
 	return msgs.CollectionRemovedMessage{
-		Index: mustGetCollectionIndex(event),
+		EventSource: source,
+		Index:       mustGetCollectionIndex(event),
 	}
 }
 
@@ -410,20 +424,20 @@ func mustGetCollectionIndex(event cosmostypes.Event) string {
 	panic(fmt.Sprintf("failed to find collection index in event attributes, event_type=[%s]", event.Type))
 }
 
-func mustCollectionDataCreatedMsg(event cosmostypes.Event) msgs.CollectionDataCreatedMessage {
-	var msg msgs.CollectionDataCreatedMessage
+func mustCollectionDataCreatedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.CollectionDataCreatedMessage {
+	msg := msgs.CollectionDataCreatedMessage{EventSource: source}
 	msg.CollectionIndex, msg.Chain = mustGetCollectionDataParams(event)
 	return msg
 }
 
-func mustCollectionDataRemovedMsg(event cosmostypes.Event) msgs.CollectionDataRemovedMessage {
-	var msg msgs.CollectionDataRemovedMessage
+func mustCollectionDataRemovedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.CollectionDataRemovedMessage {
+	msg := msgs.CollectionDataRemovedMessage{EventSource: source}
 	msg.CollectionIndex, msg.Chain = mustGetCollectionDataParams(event)
 	return msg
 }
 
-func mustCollectionDataUpdatedMsg(event cosmostypes.Event) msgs.CollectionDataUpdatedMessage {
-	var msg msgs.CollectionDataUpdatedMessage
+func mustCollectionDataUpdatedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.CollectionDataUpdatedMessage {
+	msg := msgs.CollectionDataUpdatedMessage{EventSource: source}
 	msg.CollectionIndex, msg.Chain = mustGetCollectionDataParams(event)
 	return msg
 }
@@ -449,15 +463,17 @@ func mustGetCollectionDataParams(event cosmostypes.Event) (colIndex string, chai
 	return colIndex, chain
 }
 
-func mustItemCreatedMsg(event cosmostypes.Event) msgs.ItemCreatedMessage {
+func mustItemCreatedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.ItemCreatedMessage {
 	return msgs.ItemCreatedMessage{
-		Index: mustGetItemIndex(event),
+		EventSource: source,
+		Index:       mustGetItemIndex(event),
 	}
 }
 
-func mustItemRemovedMsg(event cosmostypes.Event) msgs.ItemRemovedMessage {
+func mustItemRemovedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.ItemRemovedMessage {
 	return msgs.ItemRemovedMessage{
-		Index: mustGetItemIndex(event),
+		EventSource: source,
+		Index:       mustGetItemIndex(event),
 	}
 }
 
@@ -471,14 +487,14 @@ func mustGetItemIndex(event cosmostypes.Event) string {
 	panic(fmt.Sprintf("failed to find item index in event attributes, event_type=[%s]", event.Type))
 }
 
-func mustOnChainItemCreatedMsg(event cosmostypes.Event) msgs.ItemOnChainDataCreatedMessage {
-	var msg msgs.ItemOnChainDataCreatedMessage
+func mustOnChainItemCreatedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.ItemOnChainDataCreatedMessage {
+	msg := msgs.ItemOnChainDataCreatedMessage{EventSource: source}
 	msg.ItemIndex, msg.Chain = mustGetOnChainItemParams(event)
 	return msg
 }
 
-func mustOnChainItemRemovedMsg(event cosmostypes.Event) msgs.ItemOnChainDataRemovedMessage {
-	var msg msgs.ItemOnChainDataRemovedMessage
+func mustOnChainItemRemovedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.ItemOnChainDataRemovedMessage {
+	msg := msgs.ItemOnChainDataRemovedMessage{EventSource: source}
 	msg.ItemIndex, msg.Chain = mustGetOnChainItemParams(event)
 	return msg
 }
@@ -504,14 +520,14 @@ func mustGetOnChainItemParams(event cosmostypes.Event) (itemIndex string, chain
 	return itemIndex, chain
 }
 
-func mustSeedCreatedMsg(event cosmostypes.Event) msgs.SeedCreatedMessage {
-	var msg msgs.SeedCreatedMessage
+func mustSeedCreatedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.SeedCreatedMessage {
+	msg := msgs.SeedCreatedMessage{EventSource: source}
 	msg.ItemIndex, msg.Seed = mustGetSeedParams(event)
 	return msg
 }
 
-func mustSeedRemovedMsg(event cosmostypes.Event) msgs.SeedRemovedMessage {
-	var msg msgs.SeedRemovedMessage
+func mustSeedRemovedMsg(source msgs.EventSource, event cosmostypes.Event) msgs.SeedRemovedMessage {
+	msg := msgs.SeedRemovedMessage{EventSource: source}
 	msg.ItemIndex, msg.Seed = mustGetSeedParams(event)
 	return msg
 }
@@ -39,9 +39,17 @@ func NewTokenmanagerSaver(cfg config.Config) *TokenmanagerSaver {
 	}
 }
 
+// fork returns saver with its own storage connection to be used by the other worker
+func (s *TokenmanagerSaver) fork() *TokenmanagerSaver {
+	fork := *s
+	fork.storage = s.storage.Clone()
+	return &fork
+}
+
 func (s *TokenmanagerSaver) ParseAndSaveGenesis(ctx context.Context) error {
 	if s.genesis.Disabled {
 		s.log.Info("skipping genesis items loading (disabled in config)")
+		return nil
 	}
 
 	isParsed, err := s.getIsParsed(ctx)
@@ -116,6 +124,28 @@ func (s *TokenmanagerSaver) SaveCollection(ctx context.Context, coreCollection t
 		})
 	}
 
+	existing, err := s.storage.CollectionQ().CollectionByIndexCtx(ctx, col.Index, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get collection from storage", logan.F{
+			"index": coreCollection.Index,
+		})
+	}
+
+	// removed collection could be created again with the same index, so it is restored
+	if existing != nil {
+		existing.Metadata = col.Metadata
+		existing.UpdatedAt = now
+		existing.DeletedAt = sql.NullTime{}
+
+		if err := s.storage.CollectionQ().UpdateCtx(ctx, existing); err != nil {
+			return nil, errors.Wrap(err, "failed to update collection", logan.F{
+				"index": coreCollection.Index,
+			})
+		}
+
+		return existing, nil
+	}
+
 	if err := s.storage.CollectionQ().InsertCtx(ctx, &col); err != nil {
 		return nil, errors.Wrap(err, "failed to insert collection", logan.F{
 			"index": coreCollection.Index,
@@ -299,12 +329,47 @@ func (s *TokenmanagerSaver) SaveItem(ctx context.Context, coreItem tokenmanager.
 		})
 	}
 
-	if err := s.storage.ItemQ().InsertCtx(ctx, &item); err != nil {
+	existing, err := s.storage.ItemQ().ItemByIndexCtx(ctx, item.Index, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item from storage", logan.F{
+			"index": coreItem.Index,
+		})
+	}
+
+	// removed item could be created again with the same index, so it is restored
+	if existing != nil {
+		existing.Collection = item.Collection
+		existing.Metadata = item.Metadata
+		existing.UpdatedAt = now
+		existing.DeletedAt = sql.NullTime{}
+		item = *existing
+
+		if err := s.storage.ItemQ().UpdateCtx(ctx, &item); err != nil {
+			return nil, errors.Wrap(err, "failed to update item", logan.F{
+				"index": coreItem.Index,
+			})
+		}
+	} else if err := s.storage.ItemQ().InsertCtx(ctx, &item); err != nil {
 		return nil, errors.Wrap(err, "failed to insert item", logan.F{
 			"index": item.Index,
 		})
 	}
 
+	if coreItem.Meta.Seed != "" {
+		// the creating tx is unknown here, it is set by the seed created event if there is one
+		err := s.storage.SeedQ().RelinkCtx(ctx, &data.Seed{
+			Seed:      coreItem.Meta.Seed,
+			Item:      item.ID,
+			CreatedAt: now,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to upsert item seed", logan.F{
+				"index": coreItem.Index,
+				"seed":  coreItem.Meta.Seed,
+			})
+		}
+	}
+
 	return &item, nil
 }
 
@@ -40,18 +40,31 @@ type transfersIndexer struct {
 	storage data.Storage
 }
 
-func (p *transfersIndexer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	transfers := make([]data.Transfer, len(msgs))
+func (p *transfersIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	return &fork
+}
+
+func (p *transfersIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
+	transfers := make([]data.Transfer, 0, len(batch))
+	operations := make([]data.Operation, 0)
+	identityTransfers := make([]data.IdentityTransfer, 0)
+	statusChanges := make([]data.TransferStatusChange, 0, len(batch))
 
-	p.log.WithField("messages", len(msgs)).Debug("starting handling messages")
+	p.log.WithField("messages", len(batch)).Debug("starting handling messages")
 
-	for i, msg := range msgs {
-		tmsg := msg.MustTransferOpMessage()
+	for _, msg := range batch {
+		var tmsg msgs.TransferOpMsg
+		if err := msg.Decode(&tmsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
 
 		p.log.WithFields(logan.F{
 			"transfer_id": tmsg.TransferID,
 			"tx_hash":     tmsg.TransactionHash,
-		}).Debug("handling transfer")
+			"type":        tmsg.OperationType,
+		}).Debug("handling operation")
 
 		operation, err := p.rarimocore.GetOperation(ctx, tmsg.TransferID)
 		if err != nil {
@@ -63,43 +76,95 @@ func (p *transfersIndexer) Handle(ctx context.Context, msgs []msgs.Message) erro
 			"type":  operation.OperationType.String(),
 		}).Debug("got operation")
 
-		var operationDetails rarimocore.Transfer
-		if err := proto.Unmarshal(operation.Details.Value, &operationDetails); err != nil {
-			return errors.Wrap(err, "failed to unmarshal operation details", logan.F{
+		switch {
+		case operation.OperationType == rarimocore.OpType_TRANSFER:
+			transferData, err := p.makeTransferFromOperation(ctx, tmsg.TransactionHash, *operation)
+			if err != nil {
+				return errors.Wrap(err, "failed to make transfer", logan.F{
+					"index": operation.Index,
+				})
+			}
+
+			transfers = append(transfers, *transferData)
+		case isIdentityOperation(operation.OperationType):
+			identityTransfer, err := p.makeIdentityTransfer(ctx, tmsg.TransactionHash, *operation)
+			if err != nil {
+				return errors.Wrap(err, "failed to make identity transfer", logan.F{
+					"index": operation.Index,
+				})
+			}
+
+			identityTransfers = append(identityTransfers, *identityTransfer)
+		case newOperationDetails(operation.OperationType) != nil:
+			operationData, err := p.makeOperation(ctx, tmsg.TransactionHash, *operation)
+			if err != nil {
+				return errors.Wrap(err, "failed to make operation", logan.F{
+					"index": operation.Index,
+				})
+			}
+
+			operations = append(operations, *operationData)
+		default:
+			p.log.WithFields(logan.F{
 				"index": operation.Index,
-			})
+				"type":  operation.OperationType.String(),
+			}).Warn("operations of the type are not indexed, skipping")
+			continue
 		}
 
-		onChainItem, err := p.tokenmanager.GetOnChainItem(ctx, operationDetails.From.Chain, operationDetails.From.Address, operationDetails.From.TokenID)
+		statusChanges = append(statusChanges,
+			newStatusChange(operation.Index, rarimocore.OpStatus_INITIALIZED, tmsg.TransactionHash, int64(operation.Timestamp)))
+	}
 
-		if err != nil {
-			return errors.Wrap(err, "failed to get item", logan.F{
-				"chain":    operationDetails.From.Chain,
-				"address":  operationDetails.From.Address,
-				"token_id": operationDetails.From.TokenID,
-			})
+	return p.storage.Transaction(func() error {
+		if err := p.storage.OperationQ().UpsertBatchCtx(ctx, operations...); err != nil {
+			return errors.Wrap(err, "failed to upsert operations")
 		}
 
-		transferData, err := p.makeTransfer(ctx, tmsg.TransactionHash, *operation, operationDetails, onChainItem.Item)
-		if err != nil {
-			return errors.Wrap(err, "failed to make transfer", logan.F{
-				"index": operation.Index,
-			})
+		if err := p.storage.IdentityTransferQ().UpsertBatchCtx(ctx, identityTransfers...); err != nil {
+			return errors.Wrap(err, "failed to upsert identity transfers")
 		}
 
-		p.log.WithFields(logan.F{
-			"transfer": string(transferData.Index),
-			"status":   transferData.Status,
-			"item":     transferData.ItemIndex,
-		}).Debug("made transfer")
+		if err := p.storage.TransferQ().UpsertBatchCtx(ctx, transfers...); err != nil {
+			return errors.Wrap(err, "failed to upsert transfers")
+		}
+
+		return p.storage.TransferStatusChangeQ().InsertBatchCtx(ctx, statusChanges...)
+	})
+}
 
-		transfers[i] = *transferData
+func (p *transfersIndexer) makeTransferFromOperation(ctx context.Context, txHash string, operation rarimocore.Operation) (*data.Transfer, error) {
+	var operationDetails rarimocore.Transfer
+	if err := proto.Unmarshal(operation.Details.Value, &operationDetails); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal operation details")
 	}
 
-	return p.storage.TransferQ().UpsertBatchCtx(ctx, transfers...)
+	onChainItem, err := p.tokenmanager.GetOnChainItem(ctx, operationDetails.From.Chain, operationDetails.From.Address, operationDetails.From.TokenID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item", logan.F{
+			"chain":    operationDetails.From.Chain,
+			"address":  operationDetails.From.Address,
+			"token_id": operationDetails.From.TokenID,
+		})
+	}
+
+	transferData, err := p.makeTransfer(ctx, txHash, operation, operationDetails, onChainItem.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	p.log.WithFields(logan.F{
+		"transfer": string(transferData.Index),
+		"status":   transferData.Status,
+		"item":     transferData.ItemIndex,
+	}).Debug("made transfer")
+
+	return transferData, nil
 }
 
-func (p *transfersIndexer) getTransferStatus(ctx context.Context, transfer rarimocore.Operation) (rarimocore.OpStatus, error) {
+// getOperationStatus is used for transfers and the other operations, as votes, approvals, rejections
+// and confirmations are stored by the operation index for all of them
+func (p *transfersIndexer) getOperationStatus(ctx context.Context, transfer rarimocore.Operation) (rarimocore.OpStatus, error) {
 	// FIXME
 	//  slight race condition may occur here, should be fixed on database level
 	//  by inserting with status based on a content of confirmations/approvals/rejections tables
@@ -144,7 +209,7 @@ func (p *transfersIndexer) makeTransfer(ctx context.Context,
 	txHash string, operation rarimocore.Operation,
 	operationDetails rarimocore.Transfer, itemIndex string,
 ) (*data.Transfer, error) {
-	transferStatus, err := p.getTransferStatus(ctx, operation)
+	transferStatus, err := p.getOperationStatus(ctx, operation)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transfer status", logan.F{
 			"transfer_index": operation.Index,
@@ -9,6 +9,7 @@ import (
 	"github.com/rarimo/horizon-svc/pkg/msgs"
 
 	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 
 	"github.com/rarimo/horizon-svc/internal/config"
 	"github.com/rarimo/horizon-svc/internal/data"
@@ -32,11 +33,20 @@ type votesIndexer struct {
 	storage data.Storage
 }
 
-func (p *votesIndexer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	votes := make([]data.Vote, len(msgs))
+func (p *votesIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	return &fork
+}
+
+func (p *votesIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
+	votes := make([]data.Vote, len(batch))
 
-	for i, msg := range msgs {
-		vmsg := msg.MustVoteOpMessage()
+	for i, msg := range batch {
+		var vmsg msgs.VoteOpMsg
+		if err := msg.Decode(&vmsg); err != nil {
+			return errors.Wrap(err, "failed to decode message", msg.Fields())
+		}
 
 		vote := data.Vote{
 			TransferIndex:     []byte(vmsg.OperationID),
@@ -8,6 +8,7 @@ import (
 	"github.com/rarimo/horizon-svc/internal/data"
 	"github.com/rarimo/horizon-svc/pkg/msgs"
 	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 	"time"
 )
 
@@ -29,24 +30,68 @@ type withdrawalsIndexer struct {
 	storage data.Storage
 }
 
-func (p *withdrawalsIndexer) Handle(ctx context.Context, msgs []msgs.Message) error {
-	withdrawals := make([]data.Withdrawal, 0, len(msgs))
-
-	for _, msg := range msgs {
-		wmsg := msg.MustWithdrawalMessage()
-		withdrawals = append(withdrawals, data.Withdrawal{
-			Origin: hexutil.MustDecode(wmsg.Origin),
-			Hash: sql.NullString{
-				String: wmsg.Hash,
-				Valid:  true,
-			},
-			Success: sql.NullBool{
-				Bool:  wmsg.Success,
-				Valid: true,
-			},
-			CreatedAt: time.Now().UTC(),
-		})
+func (p *withdrawalsIndexer) Fork() msgs.Handler {
+	fork := *p
+	fork.storage = p.storage.Clone()
+	return &fork
+}
+
+func (p *withdrawalsIndexer) Handle(ctx context.Context, batch []msgs.Message) error {
+	withdrawals := make([]data.Withdrawal, 0, len(batch))
+
+	// retractions have to be applied in the same order they were published relatively to withdrawals,
+	// so inserts collected before the retraction are flushed first
+	flush := func() error {
+		if err := p.storage.WithdrawalQ().InsertBatchCtx(ctx, withdrawals...); err != nil {
+			return errors.Wrap(err, "failed to insert withdrawals")
+		}
+		withdrawals = withdrawals[:0]
+		return nil
 	}
 
-	return p.storage.WithdrawalQ().InsertBatchCtx(ctx, withdrawals...)
+	return p.storage.Transaction(func() error {
+		for _, msg := range batch {
+			decoded, err := msg.DecodeAny()
+			if err != nil {
+				return errors.Wrap(err, "failed to decode message", msg.Fields())
+			}
+
+			switch wmsg := decoded.(type) {
+			case msgs.WithdrawalMsg:
+				withdrawals = append(withdrawals, data.Withdrawal{
+					Origin: hexutil.MustDecode(wmsg.Origin),
+					Hash: sql.NullString{
+						String: wmsg.Hash,
+						Valid:  true,
+					},
+					Success: sql.NullBool{
+						Bool:  wmsg.Success,
+						Valid: true,
+					},
+					CreatedAt: time.Now().UTC(),
+				})
+			case msgs.WithdrawalRetractedMsg:
+				if err := flush(); err != nil {
+					return err
+				}
+
+				rmsg := wmsg
+				p.log.WithFields(logan.F{
+					"origin": rmsg.Origin,
+					"hash":   rmsg.Hash,
+				}).Info("retracting withdrawal")
+
+				if err := p.storage.WithdrawalQ().RetractCtx(ctx, hexutil.MustDecode(rmsg.Origin), rmsg.Hash); err != nil {
+					return errors.Wrap(err, "failed to retract withdrawal", logan.F{
+						"origin": rmsg.Origin,
+						"hash":   rmsg.Hash,
+					})
+				}
+			default:
+				p.log.WithField("type", msg.Type()).Warn("unknown message type")
+			}
+		}
+
+		return flush()
+	})
 }
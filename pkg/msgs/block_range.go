@@ -1,11 +1,5 @@
 package msgs
 
-import (
-	"encoding/json"
-
-	"gitlab.com/distributed_lab/logan/v3/errors"
-)
-
 const MessageTypeBlockRange MessageType = "block_range"
 
 type BlockRangeMessage struct {
@@ -16,20 +10,3 @@ type BlockRangeMessage struct {
 func (br BlockRangeMessage) Message() Message {
 	return marshalToMsg(br, MessageTypeBlockRange)
 }
-
-type rawMessage struct {
-	Raw  interface{} `json:"raw"`
-	Type MessageType `json:"type"`
-}
-
-func marshalToMsg(v interface{}, msgType MessageType) Message {
-	raw, err := json.Marshal(rawMessage{
-		Raw:  v,
-		Type: msgType,
-	})
-	if err != nil {
-		panic(errors.Wrap(err, "failed to marshal message"))
-	}
-
-	return Message{raw: raw, typ: msgType}
-}
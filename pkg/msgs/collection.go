@@ -11,6 +11,7 @@ const (
 )
 
 type CollectionCreatedMessage struct {
+	EventSource
 	Index string `json:"index"`
 }
 
@@ -18,7 +19,12 @@ func (m CollectionCreatedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeCollectionCreated)
 }
 
+func (m CollectionCreatedMessage) MessageKey() string {
+	return m.Index
+}
+
 type CollectionRemovedMessage struct {
+	EventSource
 	Index string `json:"index"`
 }
 
@@ -26,7 +32,12 @@ func (m CollectionRemovedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeCollectionRemoved)
 }
 
+func (m CollectionRemovedMessage) MessageKey() string {
+	return m.Index
+}
+
 type CollectionDataCreatedMessage struct {
+	EventSource
 	CollectionIndex string `json:"index"`
 	Chain           string `json:"chain"`
 }
@@ -35,7 +46,12 @@ func (m CollectionDataCreatedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeCollectionDataCreated)
 }
 
+func (m CollectionDataCreatedMessage) MessageKey() string {
+	return m.CollectionIndex
+}
+
 type CollectionDataRemovedMessage struct {
+	EventSource
 	CollectionIndex string `json:"index"`
 	Chain           string `json:"chain"`
 }
@@ -44,7 +60,12 @@ func (m CollectionDataRemovedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeCollectionDataRemoved)
 }
 
+func (m CollectionDataRemovedMessage) MessageKey() string {
+	return m.CollectionIndex
+}
+
 type CollectionDataUpdatedMessage struct {
+	EventSource
 	CollectionIndex string `json:"index"`
 	Chain           string `json:"chain"`
 }
@@ -52,3 +73,7 @@ type CollectionDataUpdatedMessage struct {
 func (m CollectionDataUpdatedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeCollectionDataUpdated)
 }
+
+func (m CollectionDataUpdatedMessage) MessageKey() string {
+	return m.CollectionIndex
+}
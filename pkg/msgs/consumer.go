@@ -2,10 +2,12 @@ package msgs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"sync"
 	"time"
 
-	"github.com/adjust/rmq/v5"
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
 	"gitlab.com/distributed_lab/running"
@@ -15,32 +17,64 @@ type Handler interface {
 	Handle(ctx context.Context, msgs []Message) error
 }
 
+// Forker is implemented by the handlers able to run on the several consumer workers. Every
+// worker handles its messages with its own fork, so forks must not share the state which
+// is unsafe for the concurrent use, like database transactions.
+type Forker interface {
+	Fork() Handler
+}
+
 type Consumer struct {
-	log     *logan.Entry
-	handler Handler
-	cfg     ConsumerConfig
+	log *logan.Entry
+	cfg ConsumerConfig
+	// handlers are the handlers of the workers, messages are partitioned between them by key
+	handlers []Handler
 
-	queue rmq.Queue
-	conn  rmq.Connection // for cleanup purposes
+	queue       Queue
+	deadLetters *DeadLetterQueue
 }
 
 func NewConsumer(log *logan.Entry, cfg ConsumerConfig, handler Handler) *Consumer {
+	log = log.WithField("who", cfg.Name)
+
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	forker, ok := handler.(Forker)
+	if workers > 1 && !ok {
+		log.WithField("workers", workers).Warn("handler can not be forked, consuming with the single worker")
+		workers = 1
+	}
+
+	handlers := make([]Handler, workers)
+	handlers[0] = handler
+	for i := 1; i < workers; i++ {
+		handlers[i] = forker.Fork()
+	}
+
 	return &Consumer{
-		log:     log.WithField("who", cfg.Name),
-		handler: handler,
-		cfg:     cfg,
+		log:         log,
+		cfg:         cfg,
+		handlers:    handlers,
+		deadLetters: NewDeadLetterQueue(log, cfg.Queues, cfg.Queue),
 	}
 }
 
+// Run consumes the queue until the context is canceled. Canceling it stops fetching, while the
+// messages being handled are given the drain timeout to finish and to be acked, so the batch is not
+// left partially written to be delivered once more.
 func (c *Consumer) Run(ctx context.Context) {
-	c.init()
-
 	defer func() {
 		if rvr := recover(); rvr != nil {
 			c.log.WithRecover(rvr).Error("consumer panicked")
 		}
 	}()
 
+	handleCtx, cancelHandling := c.drainContext(ctx)
+	defer cancelHandling()
+
 	for {
 		if err := ctx.Err(); err != nil {
 			c.log.Info("stopped by context")
@@ -50,7 +84,7 @@ func (c *Consumer) Run(ctx context.Context) {
 
 		running.UntilSuccess(ctx, c.log, "consumer",
 			func(ctx context.Context) (bool, error) {
-				err := c.runConsumingOnce(ctx)
+				err := c.runConsumingOnce(ctx, handleCtx)
 				switch err {
 				case nil:
 					return true, nil
@@ -58,7 +92,6 @@ func (c *Consumer) Run(ctx context.Context) {
 					return false, nil
 				default:
 					c.cleanup()
-					c.init()
 					return false, err
 				}
 			},
@@ -67,139 +100,276 @@ func (c *Consumer) Run(ctx context.Context) {
 }
 
 func (c *Consumer) cleanup() {
-	c.log.Info("cleaning up")
-
-	unacked, err := rmq.NewCleaner(c.conn).Clean()
-	if err != nil {
-		panic(errors.Wrap(err, "unexpected error on cleaning up connection and queue"))
+	if c.queue == nil {
+		return
 	}
 
-	c.log.WithField("cnt", unacked).Info("returned unacked back to queue")
+	c.log.Info("closing queue")
 
-	rejected, err := c.queue.PurgeRejected()
-	if err != nil {
-		panic(errors.Wrap(err, "unexpected error on cleaning up rejected messages"))
+	if err := c.queue.Close(); err != nil {
+		c.log.WithError(err).Error("failed to close queue")
 	}
-	c.log.WithField("cnt", rejected).Info("cleaned up rejected messages")
 
-	c.log.Info("shutting down connection")
-	<-c.conn.StopAllConsuming()
-	c.log.Info("connection shut down")
+	c.queue = nil
 }
 
-func (c *Consumer) init() {
-	var err error
+// drainContext returns the context of handling with the values of the consumer context, which is
+// canceled only the drain timeout after the consumer one
+func (c *Consumer) drainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	handleCtx, cancel := context.WithCancel(detachedContext{ctx})
 
-	c.conn, err = rmq.OpenConnectionWithRedisClient(
-		fmt.Sprintf("%s-consumer", c.cfg.Name),
-		c.cfg.RedisClient,
-		nil,
-	)
-	if err != nil {
-		panic(errors.Wrap(err, "failed to open connection"))
-	}
+	go func() {
+		select {
+		case <-handleCtx.Done():
+			return
+		case <-ctx.Done():
+		}
 
-	c.queue, err = c.conn.OpenQueue(c.cfg.Queue)
-	if err != nil {
-		panic(errors.Wrap(err, "failed to open queue", logan.F{
-			"queue_name": fmt.Sprintf("%s-consumer-queue", c.cfg.Name),
-		}))
-	}
+		timer := time.NewTimer(c.cfg.DrainTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-handleCtx.Done():
+		case <-timer.C:
+			c.log.WithField("drain_timeout", c.cfg.DrainTimeout.String()).
+				Warn("messages were not handled in drain timeout, canceling handling")
+			cancel()
+		}
+	}()
+
+	return handleCtx, cancel
 }
 
-func (c *Consumer) runConsumingOnce(ctx context.Context) error {
-	err := c.queue.StartConsuming(c.cfg.PrefetchLimit, c.cfg.PollDuration)
-	if err != nil {
-		return errors.Wrap(err, "failed to start consuming")
-	}
-
-	consumerName, err := c.queue.AddBatchConsumer(
-		fmt.Sprintf("%s-consumer", c.cfg.Name),
-		c.cfg.PrefetchLimit,
-		c.cfg.PollDuration,
-		&handlerConsumer{
-			ctx:            ctx,
-			handler:        c.handler,
-			log:            c.log.WithField("consumer", c.cfg.Name),
-			minRetryPeriod: c.cfg.MinRetryPeriod,
-			maxRetryPeriod: c.cfg.MaxRetryPeriod,
-			attempts:       c.cfg.RetryConsumeAttempts,
-		})
+func (c *Consumer) runConsumingOnce(ctx, handleCtx context.Context) error {
+	if c.queue == nil {
+		queue, err := c.cfg.Queues.Open(fmt.Sprintf("%s-consumer", c.cfg.Name), c.cfg.Queue)
+		if err != nil {
+			return errors.Wrap(err, "failed to open queue", logan.F{
+				"queue_name": c.cfg.Queue,
+			})
+		}
 
-	if err != nil {
-		return errors.Wrap(err, "failed to add batch consumer")
+		c.queue = queue
 	}
 
-	c.log.WithField("consumer", consumerName).Info("added consumer")
+	handler := &handlerConsumer{
+		handlers:       c.handlers,
+		log:            c.log.WithField("consumer", c.cfg.Name),
+		name:           c.cfg.Name,
+		queue:          c.queue,
+		deadLetters:    c.deadLetters,
+		minRetryPeriod: c.cfg.MinRetryPeriod,
+		maxRetryPeriod: c.cfg.MaxRetryPeriod,
+		attempts:       c.cfg.RetryConsumeAttempts,
+	}
 
-	// only possible way to return from here is context cancellation
-	// from outside and here we'll catch this stop
-	// (same context is also provided to the consumer to control it)
-	<-ctx.Done()
+	// only possible way to return without error from here is context cancellation
+	// from outside, handler gets the draining context to finish the batch in progress
+	err := c.queue.Consume(ctx, ConsumeOpts{
+		Consumer:      c.cfg.Name,
+		PrefetchLimit: c.cfg.PrefetchLimit,
+		PollDuration:  c.cfg.PollDuration,
+	}, func(_ context.Context, batch []Delivery) {
+		handler.Consume(handleCtx, batch)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to consume queue")
+	}
 
 	return nil
 }
 
 type handlerConsumer struct {
-	ctx     context.Context // fixme(hp) make a parameter after rmq update (if it will be)
-	handler Handler
-	log     *logan.Entry
+	handlers []Handler
+	log      *logan.Entry
+
+	name        string
+	queue       Queue
+	deadLetters *DeadLetterQueue
 
 	minRetryPeriod time.Duration
 	maxRetryPeriod time.Duration
 	attempts       uint64
 }
 
-func (h *handlerConsumer) Consume(batch rmq.Deliveries) {
-	msgs := make([]Message, 0, len(batch))
+// Consume partitions batch between the workers by the message key, so the messages of the
+// same entity are handled in order while the unrelated ones are handled in parallel
+func (h *handlerConsumer) Consume(ctx context.Context, batch []Delivery) {
+	log := h.log.WithField("messages", len(batch))
+	if reporter, ok := h.queue.(LagReporter); ok {
+		if lag, err := reporter.Lag(ctx); err != nil {
+			log.WithError(err).Warn("failed to get queue lag")
+		} else {
+			log = log.WithField("lag", lag)
+		}
+	}
+
+	log.Info("handling messages")
 
+	// messages keep the payload decoded for the key, so the handlers do not decode it again
+	partitions := make([][]Delivery, len(h.handlers))
+	messages := make([][]Message, len(h.handlers))
 	for _, delivery := range batch {
-		msgs = append(msgs, Message{raw: []byte(delivery.Payload())})
+		msg := Message{raw: delivery.Payload()}
+		i := partition(msg.Key(), len(h.handlers))
+		partitions[i] = append(partitions[i], delivery)
+		messages[i] = append(messages[i], msg)
 	}
 
-	h.log.Info("handling messages")
+	var wg sync.WaitGroup
+	for i, deliveries := range partitions {
+		if len(deliveries) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(handler Handler, deliveries []Delivery, msgs []Message) {
+			defer wg.Done()
+			h.handlePartition(ctx, handler, deliveries, msgs)
+		}(h.handlers[i], deliveries, messages[i])
+	}
+
+	wg.Wait()
+}
+
+// handlePartition handles deliveries of the partition with their messages in one call, falling back to
+// the handling of the messages one by one if it fails, so only the failed messages are moved to dead letters
+func (h *handlerConsumer) handlePartition(ctx context.Context, handler Handler, batch []Delivery, msgs []Message) {
+	if len(batch) > 1 {
+		err := handler.Handle(ctx, msgs)
+		if err == nil {
+			h.finalize(ctx, batch, Delivery.Ack)
+			return
+		}
 
+		if ctx.Err() != nil {
+			h.log.WithError(ctx.Err()).Error("ctx canceled")
+			return
+		}
+
+		h.log.WithError(err).WithField("messages", len(batch)).
+			Warn("failed to handle messages, handling them one by one")
+	}
+
+	for i := range msgs {
+		h.handleOne(ctx, handler, msgs[i], batch[i])
+
+		if ctx.Err() != nil {
+			h.log.WithError(ctx.Err()).Error("ctx canceled")
+			return
+		}
+	}
+}
+
+func (h *handlerConsumer) handleOne(ctx context.Context, handler Handler, msg Message, delivery Delivery) {
+	log := h.log.WithFields(msg.Fields())
+
+	firstAttemptAt := time.Now().UTC()
 	failedHandling := false
-	running.WithThreshold(h.ctx, h.log, "handle", func(ctx context.Context) (bool, error) {
-		if err := h.handler.Handle(h.ctx, msgs); err != nil {
-			attempt, ok := running.Attempt(h.ctx)
-			h.log.WithFields(logan.F{
+	var handlingErr error
+	running.WithThreshold(ctx, log, "handle", func(ctx context.Context) (bool, error) {
+		if err := handler.Handle(ctx, []Message{msg}); err != nil {
+			attempt, ok := running.Attempt(ctx)
+			log.WithFields(logan.F{
 				"attempt": attempt,
 				"ok":      ok,
 			}).Debug("got attempt from ctx")
 			if ok && attempt == h.attempts {
 				failedHandling = true
+				handlingErr = err
 			}
-			return false, errors.Wrap(err, "failed to handle messages", logan.F{
+			return false, errors.Wrap(err, "failed to handle message", logan.F{
 				"attempt": attempt,
 			})
 		}
 		return true, nil
 	}, h.minRetryPeriod, h.maxRetryPeriod, h.attempts)
 
-	if h.ctx.Err() != nil {
-		h.log.WithError(h.ctx.Err()).Error("ctx canceled")
+	if ctx.Err() != nil {
 		return
 	}
 
-	if failedHandling {
-		h.log.WithField("msgs", msgs).Debug("failed handling messages, should be retried manually")
+	if !failedHandling {
+		h.finalize(ctx, []Delivery{delivery}, Delivery.Ack)
+		return
 	}
 
-	h.log.WithField("success", !failedHandling).Info("handled, finalizing messages")
+	// failed message is rejected after it is saved in the dead letter queue
+	h.moveToDeadLetters(ctx, []Message{msg}, handlingErr, firstAttemptAt)
+	h.finalize(ctx, []Delivery{delivery}, Delivery.Reject)
+}
 
-	running.UntilSuccess(h.ctx, h.log, "ack", func(ctx context.Context) (bool, error) {
-		finalize := batch.Ack
-		if failedHandling {
-			finalize = batch.Reject
+func (h *handlerConsumer) finalize(ctx context.Context, batch []Delivery, finalize func(Delivery, context.Context) error) {
+	done := 0
+	running.UntilSuccess(ctx, h.log, "finalize", func(ctx context.Context) (bool, error) {
+		for ; done < len(batch); done++ {
+			if err := finalize(batch[done], ctx); err != nil {
+				h.log.WithError(err).Errorf("failed to finalize message %d", done)
+				return false, nil
+			}
 		}
+		return true, nil
+	}, h.minRetryPeriod, h.maxRetryPeriod)
+}
 
-		if errs := finalize(); len(errs) > 0 {
-			for i, err := range errs {
-				h.log.WithError(err).Errorf("failed to finalize message %d", i)
-			}
-			return false, nil
+func partition(key string, partitions int) int {
+	if partitions == 1 {
+		return 0
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+
+	return int(hash.Sum32() % uint32(partitions))
+}
+
+func (h *handlerConsumer) moveToDeadLetters(ctx context.Context, msgs []Message, handlingErr error, firstAttemptAt time.Time) {
+	letter := DeadLetter{
+		Consumer:       h.name,
+		Messages:       make([]json.RawMessage, len(msgs)),
+		Attempts:       h.attempts,
+		FirstAttemptAt: firstAttemptAt,
+	}
+
+	if handlingErr != nil {
+		letter.Error = handlingErr.Error()
+	}
+
+	for i, msg := range msgs {
+		letter.Messages[i] = msg.raw
+	}
+
+	running.UntilSuccess(ctx, h.log, "dead-letter", func(ctx context.Context) (bool, error) {
+		id, err := h.deadLetters.Push(ctx, letter)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to push messages to dead letter queue")
 		}
+
+		h.log.WithFields(logan.F{
+			"dead_letter_id": id,
+			"msgs":           len(msgs),
+		}).Warn("failed handling messages, moved them to dead letter queue")
 		return true, nil
 	}, h.minRetryPeriod, h.maxRetryPeriod)
 }
+
+// detachedContext keeps the values of the parent context without its cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -3,7 +3,6 @@ package msgs
 import (
 	"time"
 
-	"github.com/redis/go-redis/v9"
 	"gitlab.com/distributed_lab/figure/v3"
 	"gitlab.com/distributed_lab/kit/comfig"
 	"gitlab.com/distributed_lab/kit/kv"
@@ -19,7 +18,13 @@ type ConsumerConfig struct {
 	MinRetryPeriod       time.Duration `fig:"min_retry_period"`
 	MaxRetryPeriod       time.Duration `fig:"max_retry_period"`
 	RetryConsumeAttempts uint64        `fig:"retry_consume_attempts"`
-	RedisClient          *redis.Client `fig:"-"`
+	// Workers is the number of messages handled in parallel, messages of the same entity are
+	// always handled by the same worker
+	Workers int `fig:"workers"`
+	// DrainTimeout is the time given to the messages being handled to finish after the consumer
+	// is stopped, handling is canceled after it and the messages are delivered once more
+	DrainTimeout time.Duration `fig:"drain_timeout"`
+	Queues       *Queues       `fig:"-"`
 }
 
 type Consumerer interface {
@@ -35,12 +40,12 @@ type consumerer struct {
 }
 
 type ConsumererOpts struct {
-	RedisClient *redis.Client
+	Queues *Queues
 }
 
 func NewConsumerer(getter kv.Getter, name string, opts ConsumererOpts) Consumerer {
-	if opts.RedisClient == nil {
-		panic("redis client is required for consumerer")
+	if opts.Queues == nil {
+		panic("queues are required for consumerer")
 	}
 
 	return &consumerer{
@@ -58,7 +63,9 @@ func (c *consumerer) Consumer() ConsumerConfig {
 			MinRetryPeriod:       1 * time.Second,
 			MaxRetryPeriod:       1 * time.Minute,
 			RetryConsumeAttempts: 5,
-			RedisClient:          c.opts.RedisClient,
+			Workers:              1,
+			DrainTimeout:         30 * time.Second,
+			Queues:               c.opts.Queues,
 		}
 
 		err := figure.
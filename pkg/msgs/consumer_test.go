@@ -0,0 +1,209 @@
+package msgs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// orderingHandler records hashes of the withdrawals by origin, forks share the records
+type orderingHandler struct {
+	mu      *sync.Mutex
+	handled map[string][]string
+	stats   *orderingStats
+	want    int
+	cancel  context.CancelFunc
+}
+
+type orderingStats struct {
+	total    int
+	inFlight int
+	maxSeen  int
+}
+
+func (h *orderingHandler) Fork() Handler {
+	fork := *h
+	return &fork
+}
+
+func (h *orderingHandler) Handle(_ context.Context, msgs []Message) error {
+	h.mu.Lock()
+	h.stats.inFlight++
+	if h.stats.inFlight > h.stats.maxSeen {
+		h.stats.maxSeen = h.stats.inFlight
+	}
+	h.mu.Unlock()
+
+	// gives other workers a chance to run concurrently
+	time.Sleep(5 * time.Millisecond)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stats.inFlight--
+
+	for _, msg := range msgs {
+		var withdrawal WithdrawalMsg
+		if err := msg.Decode(&withdrawal); err != nil {
+			return err
+		}
+
+		h.handled[withdrawal.Origin] = append(h.handled[withdrawal.Origin], withdrawal.Hash)
+		h.stats.total++
+	}
+
+	if h.stats.total >= h.want {
+		h.cancel()
+	}
+
+	return nil
+}
+
+func TestConsumerKeyOrdering(t *testing.T) {
+	log := logan.New().WithField("who", "test")
+	name := fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano())
+	queues := NewQueues(log, nil, QueuesConfig{
+		Default: QueueConfig{Backend: BackendMemory},
+	})
+
+	publisher, err := NewPublisher(log, queues, "test", name)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	const origins, perOrigin = 8, 10
+
+	var published []Message
+	for i := 0; i < perOrigin; i++ {
+		for origin := 0; origin < origins; origin++ {
+			published = append(published, WithdrawalMsg{
+				Origin: fmt.Sprintf("origin-%d", origin),
+				Hash:   fmt.Sprint(i),
+			}.Message())
+		}
+	}
+
+	if !assert.NoError(t, publisher.PublishMsgs(context.Background(), published...)) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	handler := &orderingHandler{
+		mu:      &sync.Mutex{},
+		handled: make(map[string][]string),
+		stats:   &orderingStats{},
+		want:    len(published),
+		cancel:  cancel,
+	}
+
+	NewConsumer(log, ConsumerConfig{
+		Name:                 "test",
+		Queue:                name,
+		PrefetchLimit:        int64(len(published)),
+		PollDuration:         10 * time.Millisecond,
+		MinRetryPeriod:       time.Millisecond,
+		MaxRetryPeriod:       time.Millisecond,
+		RetryConsumeAttempts: 1,
+		Workers:              4,
+		Queues:               queues,
+	}, handler).Run(ctx)
+
+	if !assert.Equal(t, len(published), handler.stats.total) {
+		return
+	}
+
+	expected := make([]string, perOrigin)
+	for i := range expected {
+		expected[i] = fmt.Sprint(i)
+	}
+
+	for origin, hashes := range handler.handled {
+		assert.Equal(t, expected, hashes, origin)
+	}
+
+	assert.Greater(t, handler.stats.maxSeen, 1, "expected messages to be handled in parallel")
+}
+
+// drainingHandler stops the consumer while handling and keeps handling for the delay
+type drainingHandler struct {
+	cancel  context.CancelFunc
+	delay   time.Duration
+	handled int
+}
+
+func (h *drainingHandler) Handle(ctx context.Context, msgs []Message) error {
+	h.cancel()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(h.delay):
+	}
+
+	h.handled += len(msgs)
+	return nil
+}
+
+func TestConsumerDrain(t *testing.T) {
+	log := logan.New().WithField("who", "test")
+
+	cases := []struct {
+		name    string
+		delay   time.Duration
+		handled int
+		lag     int64
+	}{
+		{name: "finished in drain timeout", delay: 10 * time.Millisecond, handled: 1, lag: 0},
+		{name: "exceeded drain timeout", delay: time.Second, handled: 0, lag: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			name := fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano())
+			queues := NewQueues(log, nil, QueuesConfig{
+				Default: QueueConfig{Backend: BackendMemory},
+			})
+
+			publisher, err := NewPublisher(log, queues, "test", name)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			msg := WithdrawalMsg{Origin: "origin", Hash: "hash"}.Message()
+			if !assert.NoError(t, publisher.PublishMsgs(context.Background(), msg)) {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			handler := &drainingHandler{cancel: cancel, delay: c.delay}
+
+			NewConsumer(log, ConsumerConfig{
+				Name:                 "test",
+				Queue:                name,
+				PrefetchLimit:        1,
+				PollDuration:         10 * time.Millisecond,
+				MinRetryPeriod:       time.Millisecond,
+				MaxRetryPeriod:       time.Millisecond,
+				RetryConsumeAttempts: 1,
+				Workers:              1,
+				DrainTimeout:         100 * time.Millisecond,
+				Queues:               queues,
+			}, handler).Run(ctx)
+
+			assert.Equal(t, c.handled, handler.handled)
+
+			lag, err := NewMemoryQueue(name).(LagReporter).Lag(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, c.lag, lag)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package msgs
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const deadLetterKeyPrefix = "dlq:"
+
+// DeadLetter is a batch of messages which consumer failed to handle
+// after all the retry attempts were exhausted.
+type DeadLetter struct {
+	ID             string            `json:"id"`
+	Queue          string            `json:"queue"`
+	Consumer       string            `json:"consumer"`
+	Messages       []json.RawMessage `json:"messages"`
+	Error          string            `json:"error"`
+	Attempts       uint64            `json:"attempts"`
+	FirstAttemptAt time.Time         `json:"first_attempt_at"`
+	FailedAt       time.Time         `json:"failed_at"`
+}
+
+// DeadLetterQueue stores dead letters of a particular queue in redis hash,
+// so they can be inspected and replayed back into the original queue later.
+type DeadLetterQueue struct {
+	log    *logan.Entry
+	client *redis.Client
+	queues *Queues
+	queue  string
+
+	mu        sync.Mutex
+	publisher *Publisher
+}
+
+func NewDeadLetterQueue(log *logan.Entry, queues *Queues, queue string) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		log:    log.WithField("dlq", queue),
+		client: queues.RedisClient(),
+		queues: queues,
+		queue:  queue,
+	}
+}
+
+func (q *DeadLetterQueue) Queue() string {
+	return q.queue
+}
+
+func (q *DeadLetterQueue) Push(ctx context.Context, letter DeadLetter) (string, error) {
+	letter.Queue = q.queue
+	if letter.FailedAt.IsZero() {
+		letter.FailedAt = time.Now().UTC()
+	}
+
+	for {
+		letter.ID = strconv.FormatInt(time.Now().UnixNano(), 10)
+
+		raw, err := json.Marshal(letter)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal dead letter")
+		}
+
+		ok, err := q.client.HSetNX(ctx, q.key(), letter.ID, raw).Result()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to save dead letter", logan.F{
+				"id": letter.ID,
+			})
+		}
+
+		if ok {
+			return letter.ID, nil
+		}
+	}
+}
+
+// List returns all the dead letters of the queue ordered by the failure time
+func (q *DeadLetterQueue) List(ctx context.Context) ([]DeadLetter, error) {
+	raw, err := q.client.HGetAll(ctx, q.key()).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get dead letters")
+	}
+
+	letters := make([]DeadLetter, 0, len(raw))
+	for id, value := range raw {
+		var letter DeadLetter
+		if err := json.Unmarshal([]byte(value), &letter); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal dead letter", logan.F{
+				"id": id,
+			})
+		}
+
+		letters = append(letters, letter)
+	}
+
+	sort.Slice(letters, func(i, j int) bool {
+		if letters[i].FailedAt.Equal(letters[j].FailedAt) {
+			return letters[i].ID < letters[j].ID
+		}
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+
+	return letters, nil
+}
+
+// Get returns dead letter by its ID or nil if it does not exist
+func (q *DeadLetterQueue) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	raw, err := q.client.HGet(ctx, q.key(), id).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get dead letter", logan.F{
+			"id": id,
+		})
+	}
+
+	var letter DeadLetter
+	if err := json.Unmarshal([]byte(raw), &letter); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal dead letter", logan.F{
+			"id": id,
+		})
+	}
+
+	return &letter, nil
+}
+
+// Discard removes dead letter without replaying it. Returns false if there was no such letter.
+func (q *DeadLetterQueue) Discard(ctx context.Context, id string) (bool, error) {
+	removed, err := q.client.HDel(ctx, q.key(), id).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to remove dead letter", logan.F{
+			"id": id,
+		})
+	}
+
+	return removed > 0, nil
+}
+
+// Replay publishes messages of the dead letter back into the original queue
+// and removes the letter. Returns false if there was no such letter.
+func (q *DeadLetterQueue) Replay(ctx context.Context, id string) (bool, error) {
+	letter, err := q.Get(ctx, id)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get dead letter")
+	}
+
+	if letter == nil {
+		return false, nil
+	}
+
+	publisher, err := q.getPublisher()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get publisher")
+	}
+
+	msgs := make([]Message, len(letter.Messages))
+	for i, raw := range letter.Messages {
+		msgs[i] = Message{raw: raw}
+	}
+
+	if err := publisher.PublishMsgs(ctx, msgs...); err != nil {
+		return false, errors.Wrap(err, "failed to publish dead letter messages", logan.F{
+			"id": id,
+		})
+	}
+
+	// messages are already back in the queue, so even if we fail here they will be handled
+	// once more after the next replay, which is fine for idempotent handlers
+	if _, err := q.Discard(ctx, id); err != nil {
+		return true, errors.Wrap(err, "failed to remove replayed dead letter")
+	}
+
+	q.log.WithFields(logan.F{
+		"id":       id,
+		"messages": len(msgs),
+	}).Info("replayed dead letter")
+
+	return true, nil
+}
+
+func (q *DeadLetterQueue) getPublisher() (*Publisher, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.publisher != nil {
+		return q.publisher, nil
+	}
+
+	publisher, err := NewPublisher(q.log, q.queues, q.queue+"-dlq-replayer", q.queue)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create publisher")
+	}
+
+	q.publisher = publisher
+	return publisher, nil
+}
+
+func (q *DeadLetterQueue) key() string {
+	return deadLetterKeyPrefix + q.queue
+}
+
+// DeadLetterQueues lazily creates dead letter queues and caches them by the
+// queue name, so the replay publishers are not reopened on every call.
+type DeadLetterQueues struct {
+	log      *logan.Entry
+	client   *redis.Client
+	backends *Queues
+
+	mu     sync.Mutex
+	queues map[string]*DeadLetterQueue
+}
+
+func NewDeadLetterQueues(log *logan.Entry, queues *Queues) *DeadLetterQueues {
+	return &DeadLetterQueues{
+		log:      log,
+		client:   queues.RedisClient(),
+		backends: queues,
+		queues:   make(map[string]*DeadLetterQueue),
+	}
+}
+
+func (q *DeadLetterQueues) Get(queue string) *DeadLetterQueue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	dlq, ok := q.queues[queue]
+	if !ok {
+		dlq = NewDeadLetterQueue(q.log, q.backends, queue)
+		q.queues[queue] = dlq
+	}
+
+	return dlq
+}
+
+// Names returns names of the queues which have at least one dead letter
+func (q *DeadLetterQueues) Names(ctx context.Context) ([]string, error) {
+	var queues []string
+
+	iter := q.client.Scan(ctx, 0, deadLetterKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		queues = append(queues, strings.TrimPrefix(iter.Val(), deadLetterKeyPrefix))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to scan dead letter queues")
+	}
+
+	sort.Strings(queues)
+	return queues, nil
+}
@@ -0,0 +1,9 @@
+package msgs
+
+// EventSource identifies the rarimo transaction emitted the tokenmanager event, TxHash is
+// `block:<height>` for the events emitted on the end of the block. It is empty for the messages
+// published before the source was added to them.
+type EventSource struct {
+	TxHash string `json:"tx_hash,omitempty"`
+	Height int64  `json:"height,omitempty"`
+}
@@ -10,6 +10,7 @@ const (
 )
 
 type ItemCreatedMessage struct {
+	EventSource
 	Index string `json:"index"`
 }
 
@@ -17,7 +18,12 @@ func (m ItemCreatedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeItemCreated)
 }
 
+func (m ItemCreatedMessage) MessageKey() string {
+	return m.Index
+}
+
 type ItemRemovedMessage struct {
+	EventSource
 	Index string `json:"index"`
 }
 
@@ -25,7 +31,12 @@ func (m ItemRemovedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeItemRemoved)
 }
 
+func (m ItemRemovedMessage) MessageKey() string {
+	return m.Index
+}
+
 type ItemOnChainDataCreatedMessage struct {
+	EventSource
 	ItemIndex string `json:"index"`
 	Chain     string `json:"chain"`
 }
@@ -34,7 +45,12 @@ func (m ItemOnChainDataCreatedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeItemOnChainDataCreated)
 }
 
+func (m ItemOnChainDataCreatedMessage) MessageKey() string {
+	return m.ItemIndex
+}
+
 type ItemOnChainDataRemovedMessage struct {
+	EventSource
 	ItemIndex string `json:"index"`
 	Chain     string `json:"chain"`
 }
@@ -42,3 +58,7 @@ type ItemOnChainDataRemovedMessage struct {
 func (m ItemOnChainDataRemovedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeItemOnChainDataRemoved)
 }
+
+func (m ItemOnChainDataRemovedMessage) MessageKey() string {
+	return m.ItemIndex
+}
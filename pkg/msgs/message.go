@@ -1,181 +1,475 @@
 package msgs
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"reflect"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"gitlab.com/distributed_lab/logan/v3"
-
 	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
-// TODO this whole file is a mess, need to refactor it
-
 type MessageType string
 
+type Encoding string
+
+const (
+	EncodingJSON     Encoding = "json"
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+var (
+	ErrMalformedMessage   = errors.New("malformed message")
+	ErrUnknownMessageType = errors.New("unknown message type")
+	ErrWrongMessageType   = errors.New("wrong message type")
+	ErrUnsupportedVersion = errors.New("unsupported message schema version")
+)
+
+// Envelope is the wire format of the message. Payload is the JSON of the message or, for the
+// protobuf encoding, JSON string with the base64 of the protobuf bytes.
+type Envelope struct {
+	ID        string          `json:"id"`
+	Type      MessageType     `json:"type"`
+	Version   uint32          `json:"version"`
+	Producer  string          `json:"producer,omitempty"`
+	TraceID   string          `json:"trace_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	Encoding  Encoding        `json:"encoding,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
 type Message struct {
-	raw json.RawMessage
-	typ MessageType
+	raw      json.RawMessage
+	envelope *Envelope
+	// decoded is the payload decoded by DecodeAny, so the message key and the handler share it
+	decoded interface{}
+}
+
+// NewMessage wraps the value of the registered message type into the envelope of its
+// current schema version
+func NewMessage(v interface{}) (Message, error) {
+	reg, err := registrationOf(v)
+	if err != nil {
+		return Message{}, err
+	}
+
+	envelope := Envelope{
+		ID:        newID(),
+		Type:      reg.typ,
+		Version:   reg.version,
+		CreatedAt: time.Now().UTC(),
+		Encoding:  reg.encoding,
+	}
+
+	switch reg.encoding {
+	case EncodingProtobuf:
+		pb, ok := v.(proto.Message)
+		if !ok {
+			return Message{}, errors.From(errors.New("protobuf message is expected"), logan.F{
+				"type": reg.typ,
+			})
+		}
+
+		raw, err := proto.Marshal(pb)
+		if err != nil {
+			return Message{}, errors.Wrap(err, "failed to marshal protobuf payload")
+		}
+
+		envelope.Payload, err = json.Marshal(base64.StdEncoding.EncodeToString(raw))
+		if err != nil {
+			return Message{}, errors.Wrap(err, "failed to marshal protobuf payload")
+		}
+	default:
+		envelope.Payload, err = json.Marshal(v)
+		if err != nil {
+			return Message{}, errors.Wrap(err, "failed to marshal payload")
+		}
+	}
+
+	return newEnvelopeMessage(envelope)
+}
+
+func newEnvelopeMessage(envelope Envelope) (Message, error) {
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+		return Message{}, errors.Wrap(err, "failed to marshal envelope")
+	}
+
+	return Message{raw: raw, envelope: &envelope}, nil
+}
+
+func marshalToMsg(v interface{}, msgType MessageType) Message {
+	msg, err := NewMessage(v)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create message", logan.F{
+			"type": msgType,
+		}))
+	}
+
+	return msg
 }
 
 func (m Message) String() string {
 	return string(m.raw)
 }
 
+// Envelope parses the envelope of the message without decoding the payload
+func (m *Message) Envelope() (*Envelope, error) {
+	if m.envelope != nil {
+		return m.envelope, nil
+	}
+
+	var envelope struct {
+		Envelope
+		// Raw is the payload of the messages published before the envelope was introduced,
+		// they are decoded as the first schema version
+		Raw json.RawMessage `json:"raw"`
+	}
+
+	if err := json.Unmarshal(m.raw, &envelope); err != nil {
+		return nil, errors.Wrap(ErrMalformedMessage, err.Error())
+	}
+
+	if envelope.Type == "" {
+		return nil, errors.Wrap(ErrMalformedMessage, "message type is empty")
+	}
+
+	if envelope.Payload == nil {
+		envelope.Payload = envelope.Raw
+		envelope.Version = 1
+	}
+
+	m.envelope = &envelope.Envelope
+	return m.envelope, nil
+}
+
+// Type returns type of the message or empty string if the message is malformed
 func (m *Message) Type() MessageType {
-	if m.typ != "" {
-		return m.typ
+	envelope, err := m.Envelope()
+	if err != nil {
+		return ""
 	}
 
-	var raw rawMessage
-	if err := json.Unmarshal(m.raw, &raw); err != nil {
-		panic(errors.Wrap(err, "failed to unmarshal message", logan.F{
-			"raw": string(m.raw),
-		}))
+	return envelope.Type
+}
+
+// Fields returns envelope details to be logged along with the message handling
+func (m *Message) Fields() logan.F {
+	envelope, err := m.Envelope()
+	if err != nil {
+		return logan.F{"message": m.String()}
+	}
+
+	return logan.F{
+		"message_id":   envelope.ID,
+		"message_type": envelope.Type,
+		"version":      envelope.Version,
+		"producer":     envelope.Producer,
+		"trace_id":     envelope.TraceID,
 	}
+}
 
-	m.typ = raw.Type
-	return m.typ
+// Keyed is implemented by the messages about the particular entity, messages with the same
+// key are handled by the consumer in the order they were published
+type Keyed interface {
+	MessageKey() string
 }
 
+// Key returns key of the entity the message is about, or the message id if it has no key, so
+// such messages are not ordered against each other
+func (m *Message) Key() string {
+	decoded, err := m.DecodeAny()
+	if err == nil {
+		if keyed, ok := decoded.(Keyed); ok && keyed.MessageKey() != "" {
+			return keyed.MessageKey()
+		}
+	}
+
+	envelope, err := m.Envelope()
+	if err != nil {
+		return ""
+	}
+
+	return envelope.ID
+}
+
+// Decode unmarshals payload into v, which must be a pointer to the registered message type
+// matching the message one. Payloads of the older schema versions are upgraded first.
+func (m *Message) Decode(v interface{}) error {
+	if m.decoded != nil {
+		target, decoded := reflect.ValueOf(v), reflect.ValueOf(m.decoded)
+
+		switch {
+		case target.Kind() != reflect.Ptr || target.IsNil():
+		case decoded.Type() == target.Type():
+			target.Elem().Set(decoded.Elem())
+			return nil
+		case decoded.Type() == target.Elem().Type():
+			target.Elem().Set(decoded)
+			return nil
+		}
+	}
+
+	envelope, err := m.Envelope()
+	if err != nil {
+		return err
+	}
+
+	reg, err := registrationOf(v)
+	if err != nil {
+		return err
+	}
+
+	if reg.typ != envelope.Type {
+		return errors.From(ErrWrongMessageType, logan.F{
+			"expected": reg.typ,
+			"actual":   envelope.Type,
+		})
+	}
+
+	if envelope.Version > reg.version {
+		return errors.From(ErrUnsupportedVersion, logan.F{
+			"type":      envelope.Type,
+			"version":   envelope.Version,
+			"supported": reg.version,
+		})
+	}
+
+	if envelope.Encoding == EncodingProtobuf {
+		pb, ok := v.(proto.Message)
+		if !ok {
+			return errors.From(errors.New("protobuf payload requires protobuf message"), logan.F{
+				"type": envelope.Type,
+			})
+		}
+
+		var encoded string
+		if err := json.Unmarshal(envelope.Payload, &encoded); err != nil {
+			return errors.Wrap(ErrMalformedMessage, err.Error())
+		}
+
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return errors.Wrap(ErrMalformedMessage, err.Error())
+		}
+
+		if err := proto.Unmarshal(raw, pb); err != nil {
+			return errors.Wrap(ErrMalformedMessage, err.Error())
+		}
+
+		return nil
+	}
+
+	payload, err := reg.upgrade(envelope.Version, envelope.Payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to upgrade payload", logan.F{
+			"type":    envelope.Type,
+			"version": envelope.Version,
+		})
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return errors.Wrap(ErrMalformedMessage, err.Error())
+	}
+
+	return nil
+}
+
+// DecodeAny decodes payload into the value of the Go type registered for the message type, so
+// the handlers can switch over it. The value is returned as it was registered: by value for
+// the plain structures and by pointer for the protobuf messages. The value is decoded once and
+// shared by the copies of the message made after that, so it must not be modified.
+func (m *Message) DecodeAny() (interface{}, error) {
+	if m.decoded != nil {
+		return m.decoded, nil
+	}
+
+	envelope, err := m.Envelope()
+	if err != nil {
+		return nil, err
+	}
+
+	reg, err := registrationByType(envelope.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.New(reg.goType)
+	if err := m.Decode(v.Interface()); err != nil {
+		return nil, err
+	}
+
+	if reg.pointer {
+		m.decoded = v.Interface()
+	} else {
+		m.decoded = v.Elem().Interface()
+	}
+
+	return m.decoded, nil
+}
+
+// stamp sets the producer and trace id of the message if they are not set yet, so the
+// replayed and forwarded messages keep their origin
+func (m *Message) stamp(producer, traceID string) error {
+	envelope, err := m.Envelope()
+	if err != nil {
+		return err
+	}
+
+	if envelope.Producer != "" && envelope.TraceID != "" {
+		return nil
+	}
+
+	stamped := *envelope
+	if stamped.ID == "" {
+		stamped.ID = newID()
+	}
+
+	if stamped.Producer == "" {
+		stamped.Producer = producer
+	}
+
+	if stamped.TraceID == "" {
+		stamped.TraceID = traceID
+	}
+
+	if stamped.TraceID == "" {
+		stamped.TraceID = stamped.ID
+	}
+
+	*m, err = newEnvelopeMessage(stamped)
+	return err
+}
+
+func newID() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		panic(errors.Wrap(err, "failed to generate message id"))
+	}
+
+	return hex.EncodeToString(id[:])
+}
+
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustBlockRangeMessage() (msg BlockRangeMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeBlockRange)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustTransferOpMessage() (msg TransferOpMsg) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeTransferOp)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustConfirmationOpMessage() (msg ConfirmationOpMsg) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeConfirmationOp)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustVoteOpMessage() (msg VoteOpMsg) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeVoteOp)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustApprovalOpMessage() (msg ApprovalOpMsg) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeApprovalOp)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustRejectionOpMessage() (msg RejectionOpMsg) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeRejectionOp)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustCollectionCreatedMessage() (msg CollectionCreatedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeCollectionCreated)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustCollectionRemovedMessage() (msg CollectionRemovedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeCollectionRemoved)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustCollectionDataCreatedMessage() (msg CollectionDataCreatedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeCollectionDataCreated)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustCollectionDataRemovedMessage() (msg CollectionDataRemovedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeCollectionDataRemoved)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustCollectionDataUpdatedMessage() (msg CollectionDataUpdatedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeCollectionDataUpdated)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustItemCreatedMessage() (msg ItemCreatedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeItemCreated)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustItemRemovedMessage() (msg ItemRemovedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeItemRemoved)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustItemOnChainDataCreatedMessage() (msg ItemOnChainDataCreatedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeItemOnChainDataCreated)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustItemOnChainDataRemovedMessage() (msg ItemOnChainDataRemovedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeItemOnChainDataRemoved)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustSeedCreatedMessage() (msg SeedCreatedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeSeedCreated)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustSeedRemovedMessage() (msg SeedRemovedMessage) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeSeedRemoved)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
+// Deprecated: use Decode, which returns an error instead of panicking
 func (m *Message) MustWithdrawalMessage() (msg WithdrawalMsg) {
-	rm := mustUnmarshal(m.raw, &msg)
-	assertType(rm.Type, MessageTypeWithdrawal)
-	m.typ = rm.Type
+	mustDecode(m, &msg)
 	return
 }
 
-func mustUnmarshal(raw json.RawMessage, v interface{}) rawMessage {
-	rm := rawMessage{
-		Raw: v,
-	}
-	if err := json.Unmarshal(raw, &rm); err != nil {
-		panic(errors.Wrap(err, "failed to unmarshal", logan.F{"raw": string(raw)}))
-	}
-
-	return rm
+// Deprecated: use Decode, which returns an error instead of panicking
+func (m *Message) MustWithdrawalRetractedMessage() (msg WithdrawalRetractedMsg) {
+	mustDecode(m, &msg)
+	return
 }
 
-func assertType(actual MessageType, expected MessageType) {
-	if actual != expected {
-		panic(errors.From(errors.New("wrong message type"), logan.F{"expected": expected, "actual": actual}))
+func mustDecode(m *Message, v interface{}) {
+	if err := m.Decode(v); err != nil {
+		panic(errors.Wrap(err, "failed to decode message", logan.F{"raw": m.String()}))
 	}
 }
@@ -1,11 +1,40 @@
 package msgs
 
 import (
+	"encoding/json"
 	"testing"
 
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
+const (
+	messageTypeTestUpgraded MessageType = "test_upgraded"
+	messageTypeTestProto    MessageType = "test_proto"
+)
+
+type testUpgradedMessage struct {
+	Name string `json:"name"`
+}
+
+func init() {
+	Register(messageTypeTestUpgraded, 2, testUpgradedMessage{})
+	RegisterUpgrade(messageTypeTestUpgraded, 1, func(payload json.RawMessage) (json.RawMessage, error) {
+		var v1 struct {
+			Title string `json:"title"`
+		}
+
+		if err := json.Unmarshal(payload, &v1); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(testUpgradedMessage{Name: v1.Title})
+	})
+
+	Register(messageTypeTestProto, 1, &rarimocore.Transfer{})
+}
+
 func TestMessage(t *testing.T) {
 	raw := `{"raw":{"start":1734,"end":1735},"type":"block_range"}`
 
@@ -25,3 +54,131 @@ func TestMessage(t *testing.T) {
 		return
 	}
 }
+
+func TestMessageEnvelope(t *testing.T) {
+	msg := BlockRangeMessage{Start: 10, End: 20}.Message()
+	if !assert.NoError(t, msg.stamp("test-producer", "")) {
+		return
+	}
+
+	// decoded from the wire as the consumers do
+	received := Message{raw: []byte(msg.String())}
+
+	envelope, err := received.Envelope()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, MessageTypeBlockRange, envelope.Type)
+	assert.Equal(t, uint32(1), envelope.Version)
+	assert.Equal(t, "test-producer", envelope.Producer)
+	assert.NotEmpty(t, envelope.ID)
+	assert.Equal(t, envelope.ID, envelope.TraceID)
+	assert.False(t, envelope.CreatedAt.IsZero())
+
+	decoded, err := received.DecodeAny()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, BlockRangeMessage{Start: 10, End: 20}, decoded)
+
+	var wrong TransferOpMsg
+	assert.Equal(t, ErrWrongMessageType, errors.Cause(received.Decode(&wrong)))
+}
+
+func TestMessageDecodeErrors(t *testing.T) {
+	var brm BlockRangeMessage
+
+	malformed := Message{raw: []byte(`not a json`)}
+	assert.Equal(t, ErrMalformedMessage, errors.Cause(malformed.Decode(&brm)))
+	assert.Equal(t, MessageType(""), malformed.Type())
+
+	unknown := Message{raw: []byte(`{"type":"unknown","version":1,"payload":{}}`)}
+	_, err := unknown.DecodeAny()
+	assert.Equal(t, ErrUnknownMessageType, errors.Cause(err))
+
+	newer := Message{raw: []byte(`{"type":"block_range","version":2,"payload":{"start":1,"end":2}}`)}
+	assert.Equal(t, ErrUnsupportedVersion, errors.Cause(newer.Decode(&brm)))
+}
+
+func TestMessageUpgrade(t *testing.T) {
+	legacy := Message{raw: []byte(`{"raw":{"title":"old"},"type":"test_upgraded"}`)}
+
+	var msg testUpgradedMessage
+	if !assert.NoError(t, legacy.Decode(&msg)) {
+		return
+	}
+
+	assert.Equal(t, "old", msg.Name)
+
+	current := marshalToMsg(testUpgradedMessage{Name: "new"}, messageTypeTestUpgraded)
+	if !assert.NoError(t, current.Decode(&msg)) {
+		return
+	}
+
+	assert.Equal(t, "new", msg.Name)
+}
+
+func TestMessageProtobuf(t *testing.T) {
+	msg, err := NewMessage(&rarimocore.Transfer{Origin: "origin", Receiver: "receiver"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	envelope, err := msg.Envelope()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, EncodingProtobuf, envelope.Encoding)
+
+	received := Message{raw: []byte(msg.String())}
+
+	decoded, err := received.DecodeAny()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	transfer, ok := decoded.(*rarimocore.Transfer)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, "origin", transfer.Origin)
+	assert.Equal(t, "receiver", transfer.Receiver)
+}
+
+func TestMessageDecodeCached(t *testing.T) {
+	received := Message{raw: []byte(ItemCreatedMessage{Index: "item"}.Message().String())}
+
+	assert.Equal(t, "item", received.Key())
+	assert.Equal(t, ItemCreatedMessage{Index: "item"}, received.decoded)
+
+	var msg ItemCreatedMessage
+	if !assert.NoError(t, received.Decode(&msg)) {
+		return
+	}
+
+	assert.Equal(t, "item", msg.Index)
+
+	var brm BlockRangeMessage
+	assert.Equal(t, ErrWrongMessageType, errors.Cause(received.Decode(&brm)))
+
+	pb, err := NewMessage(&rarimocore.Transfer{Origin: "origin"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	received = Message{raw: []byte(pb.String())}
+	if _, err := received.DecodeAny(); !assert.NoError(t, err) {
+		return
+	}
+
+	var transfer rarimocore.Transfer
+	if !assert.NoError(t, received.Decode(&transfer)) {
+		return
+	}
+
+	assert.Equal(t, "origin", transfer.Origin)
+}
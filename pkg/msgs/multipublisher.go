@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/adjust/rmq/v5"
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
 	"gitlab.com/distributed_lab/running"
@@ -12,18 +11,26 @@ import (
 
 type MultiPublisher struct {
 	log            *logan.Entry
-	queues         []rmq.Queue
-	queueNames     []string
+	queues         []Queue
+	producer       string
 	attempts       uint64
 	minRetryPeriod time.Duration
 	maxRetryPeriod time.Duration
 }
 
 func (m *MultiPublisher) PublishMsgs(ctx context.Context, msgs ...Message) error {
-	publishable := make([]string, len(msgs))
+	payloads := make([][]byte, len(msgs))
+
+	traceID := TraceIDFromContext(ctx)
 
 	for i, msg := range msgs {
-		publishable[i] = msg.String()
+		if err := msg.stamp(m.producer, traceID); err != nil {
+			return errors.Wrap(err, "failed to stamp message", logan.F{
+				"message": msg.String(),
+			})
+		}
+
+		payloads[i] = msg.raw
 	}
 
 	m.log.
@@ -35,9 +42,9 @@ func (m *MultiPublisher) PublishMsgs(ctx context.Context, msgs ...Message) error
 	running.WithThreshold(ctx, m.log, "publishing", func(ctx context.Context) (bool, error) {
 		for ; cursor < len(m.queues); cursor++ {
 			queue := m.queues[cursor]
-			if err := queue.Publish(publishable...); err != nil {
+			if err := queue.Publish(ctx, payloads...); err != nil {
 				return false, errors.Wrap(err, "failed to publish messages", logan.F{
-					"queue": m.queueNames[cursor],
+					"queue": queue.Name(),
 				})
 			}
 		}
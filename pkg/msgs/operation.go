@@ -7,20 +7,35 @@ const (
 	MessageTypeConfirmationOp MessageType = rarimocore.EventTypeNewConfirmation
 )
 
+// TransferOpMsg is published for every new rarimocore operation, not only for transfers
 type TransferOpMsg struct {
 	TransferID      string `json:"transfer_id"`
 	TransactionHash string `json:"transaction_hash"`
+	// OperationType is the rarimocore.OpType name, empty in the messages published before
+	// the other operation types were indexed
+	OperationType string `json:"operation_type,omitempty"`
 }
 
 func (m TransferOpMsg) Message() Message {
 	return marshalToMsg(m, MessageTypeTransferOp)
 }
 
+func (m TransferOpMsg) MessageKey() string {
+	return m.TransferID
+}
+
 type ConfirmationOpMsg struct {
 	ConfirmationID  string `json:"confirmation_id"`
 	TransactionHash string `json:"transaction_hash"`
+	// BlockTime is the unix time of the block with the transaction, zero in the messages published
+	// before it was added
+	BlockTime int64 `json:"block_time,omitempty"`
 }
 
 func (m ConfirmationOpMsg) Message() Message {
 	return marshalToMsg(m, MessageTypeConfirmationOp)
 }
+
+func (m ConfirmationOpMsg) MessageKey() string {
+	return m.ConfirmationID
+}
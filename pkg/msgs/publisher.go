@@ -3,32 +3,25 @@ package msgs
 import (
 	"context"
 
-	"github.com/redis/go-redis/v9"
-
-	"github.com/adjust/rmq/v5"
 	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
 type Publisher struct {
-	log   *logan.Entry
-	queue rmq.Queue
+	log      *logan.Entry
+	queue    Queue
+	producer string
 }
 
-func NewPublisher(log *logan.Entry, redisClient *redis.Client, tag, queueName string) (*Publisher, error) {
-	conn, err := rmq.OpenConnectionWithRedisClient(tag, redisClient, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to open connection to redis")
-	}
-
-	queue, err := conn.OpenQueue(queueName)
+func NewPublisher(log *logan.Entry, queues *Queues, tag, queueName string) (*Publisher, error) {
+	queue, err := queues.Open(tag, queueName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open queue", logan.F{
 			"queue_name": queueName,
 		})
 	}
 
-	return &Publisher{log: log, queue: queue}, nil
+	return &Publisher{log: log, queue: queue, producer: tag}, nil
 }
 
 func (p *Publisher) PublishMsgs(ctx context.Context, msgs ...Message) error {
@@ -38,9 +31,19 @@ func (p *Publisher) PublishMsgs(ctx context.Context, msgs ...Message) error {
 	}
 
 	publishable := make([]string, len(msgs))
+	payloads := make([][]byte, len(msgs))
+
+	traceID := TraceIDFromContext(ctx)
 
 	for i, msg := range msgs {
+		if err := msg.stamp(p.producer, traceID); err != nil {
+			return errors.Wrap(err, "failed to stamp message", logan.F{
+				"message": msg.String(),
+			})
+		}
+
 		publishable[i] = msg.String()
+		payloads[i] = msg.raw
 	}
 
 	if ctx.Err() != nil {
@@ -49,7 +52,7 @@ func (p *Publisher) PublishMsgs(ctx context.Context, msgs ...Message) error {
 
 	p.log.WithField("messages", publishable).Debug("Publishing messages")
 
-	err := p.queue.Publish(publishable...)
+	err := p.queue.Publish(ctx, payloads...)
 	if err != nil {
 		return errors.Wrap(err, "failed to publish messages", logan.F{
 			"messages": publishable,
@@ -0,0 +1,38 @@
+package msgs
+
+import (
+	"context"
+	"time"
+)
+
+// Queue is the backend used to deliver messages between the services. Every message is
+// delivered to only one of the consumers of the queue.
+type Queue interface {
+	Name() string
+	Publish(ctx context.Context, payloads ...[]byte) error
+	// Consume passes batches of deliveries to the handle function one by one until the context
+	// is canceled, in which case fetching is stopped and nil is returned after the handle call in
+	// progress returns. Deliveries which were not acked are delivered once more later.
+	Consume(ctx context.Context, opts ConsumeOpts, handle func(ctx context.Context, batch []Delivery)) error
+	Close() error
+}
+
+// LagReporter is implemented by the queues which are able to tell how many messages
+// are not handled yet
+type LagReporter interface {
+	Lag(ctx context.Context) (int64, error)
+}
+
+type ConsumeOpts struct {
+	// Consumer is the name of consumer, used to identify it in the backend
+	Consumer      string
+	PrefetchLimit int64
+	PollDuration  time.Duration
+}
+
+type Delivery interface {
+	Payload() []byte
+	Ack(ctx context.Context) error
+	// Reject finalizes delivery which failed to be handled, so it is not delivered anymore
+	Reject(ctx context.Context) error
+}
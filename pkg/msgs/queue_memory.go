@@ -0,0 +1,147 @@
+package msgs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const BackendMemory = "memory"
+
+var memoryQueues = struct {
+	mu     sync.Mutex
+	queues map[string]*memoryQueue
+}{
+	queues: make(map[string]*memoryQueue),
+}
+
+// memoryQueue is the in-process queue used in tests. Queues are shared by name inside the
+// process, so publisher and consumer created separately see the same messages. Deliveries
+// which were not acked by the handler are requeued right after it returns.
+type memoryQueue struct {
+	name string
+
+	mu       sync.Mutex
+	messages [][]byte
+	notify   chan struct{}
+}
+
+// NewMemoryQueue returns in-memory queue with the given name, creating it if needed
+func NewMemoryQueue(name string) Queue {
+	memoryQueues.mu.Lock()
+	defer memoryQueues.mu.Unlock()
+
+	q, ok := memoryQueues.queues[name]
+	if !ok {
+		q = &memoryQueue{
+			name:   name,
+			notify: make(chan struct{}, 1),
+		}
+		memoryQueues.queues[name] = q
+	}
+
+	return q
+}
+
+func (q *memoryQueue) Name() string {
+	return q.name
+}
+
+func (q *memoryQueue) Publish(_ context.Context, payloads ...[]byte) error {
+	q.push(payloads...)
+	return nil
+}
+
+func (q *memoryQueue) Consume(ctx context.Context, opts ConsumeOpts, handle func(ctx context.Context, batch []Delivery)) error {
+	for {
+		batch := q.pop(opts.PrefetchLimit)
+		if len(batch) == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-q.notify:
+			case <-time.After(opts.PollDuration):
+			}
+			continue
+		}
+
+		deliveries := make([]Delivery, len(batch))
+		for i, payload := range batch {
+			deliveries[i] = &memoryDelivery{payload: payload}
+		}
+
+		handle(ctx, deliveries)
+
+		var unacked [][]byte
+		for _, delivery := range deliveries {
+			if d := delivery.(*memoryDelivery); !d.acked {
+				unacked = append(unacked, d.payload)
+			}
+		}
+
+		q.push(unacked...)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (q *memoryQueue) Lag(_ context.Context) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return int64(len(q.messages)), nil
+}
+
+func (q *memoryQueue) Close() error {
+	return nil
+}
+
+func (q *memoryQueue) push(payloads ...[]byte) {
+	if len(payloads) == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	q.messages = append(q.messages, payloads...)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *memoryQueue) pop(limit int64) [][]byte {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	n := int64(len(q.messages))
+	if limit > 0 && n > limit {
+		n = limit
+	}
+
+	batch := q.messages[:n:n]
+	q.messages = q.messages[n:]
+
+	return batch
+}
+
+type memoryDelivery struct {
+	payload []byte
+	acked   bool
+}
+
+func (d *memoryDelivery) Payload() []byte {
+	return d.payload
+}
+
+func (d *memoryDelivery) Ack(_ context.Context) error {
+	d.acked = true
+	return nil
+}
+
+func (d *memoryDelivery) Reject(ctx context.Context) error {
+	return d.Ack(ctx)
+}
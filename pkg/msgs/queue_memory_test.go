@@ -0,0 +1,106 @@
+package msgs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+type collectingHandler struct {
+	mu     sync.Mutex
+	msgs   []BlockRangeMessage
+	want   int
+	cancel context.CancelFunc
+}
+
+func (h *collectingHandler) Handle(_ context.Context, msgs []Message) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, msg := range msgs {
+		h.msgs = append(h.msgs, msg.MustBlockRangeMessage())
+	}
+
+	if len(h.msgs) >= h.want {
+		h.cancel()
+	}
+
+	return nil
+}
+
+func TestMemoryQueuePublishConsume(t *testing.T) {
+	log := logan.New().WithField("who", "test")
+	name := fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano())
+	queues := NewQueues(log, nil, QueuesConfig{
+		Default: QueueConfig{Backend: BackendMemory},
+	})
+
+	publisher, err := NewPublisher(log, queues, "test", name)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var published []Message
+	for i := int64(0); i < 5; i++ {
+		published = append(published, BlockRangeMessage{Start: i, End: i + 1}.Message())
+	}
+
+	if !assert.NoError(t, publisher.PublishMsgs(context.Background(), published...)) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	handler := &collectingHandler{want: len(published), cancel: cancel}
+
+	NewConsumer(log, ConsumerConfig{
+		Name:                 "test",
+		Queue:                name,
+		PrefetchLimit:        2,
+		PollDuration:         10 * time.Millisecond,
+		MinRetryPeriod:       time.Millisecond,
+		MaxRetryPeriod:       time.Millisecond,
+		RetryConsumeAttempts: 1,
+		Queues:               queues,
+	}, handler).Run(ctx)
+
+	if !assert.Len(t, handler.msgs, len(published)) {
+		return
+	}
+
+	for i, msg := range handler.msgs {
+		assert.Equal(t, int64(i), msg.Start)
+	}
+}
+
+func TestMemoryQueueRequeuesUnacked(t *testing.T) {
+	queue := NewMemoryQueue(fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano()))
+	assert.NoError(t, queue.Publish(context.Background(), []byte("first"), []byte("second")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := queue.Consume(ctx, ConsumeOpts{PrefetchLimit: 10, PollDuration: 10 * time.Millisecond},
+		func(ctx context.Context, batch []Delivery) {
+			attempts++
+			if attempts == 1 {
+				// leave the second delivery unacked, so it is delivered once more
+				assert.NoError(t, batch[0].Ack(ctx))
+				return
+			}
+
+			assert.Len(t, batch, 1)
+			assert.Equal(t, "second", string(batch[0].Payload()))
+			assert.NoError(t, batch[0].Ack(ctx))
+			cancel()
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
@@ -0,0 +1,177 @@
+package msgs
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const (
+	BackendNATS = "nats"
+
+	natsSubjectPrefix  = "horizon."
+	natsDurable        = "consumers"
+	defaultNATSAckWait = 5 * time.Minute
+)
+
+// natsQueue is the queue implemented with nats jetstream stream and durable pull consumer,
+// so messages not acked in the ack wait period are redelivered.
+type natsQueue struct {
+	log     *logan.Entry
+	js      jetstream.JetStream
+	name    string
+	maxLen  int64
+	ackWait time.Duration
+
+	mu       sync.Mutex
+	consumer jetstream.Consumer
+}
+
+func newNATSQueue(ctx context.Context, log *logan.Entry, js jetstream.JetStream, name string, maxLen int64, ackWait time.Duration) (*natsQueue, error) {
+	if ackWait == 0 {
+		ackWait = defaultNATSAckWait
+	}
+
+	q := &natsQueue{
+		log:     log,
+		js:      js,
+		name:    name,
+		maxLen:  maxLen,
+		ackWait: ackWait,
+	}
+
+	streamCfg := jetstream.StreamConfig{
+		Name:     q.stream(),
+		Subjects: []string{q.subject()},
+	}
+
+	if maxLen > 0 {
+		streamCfg.MaxMsgs = maxLen
+	}
+
+	if _, err := js.CreateOrUpdateStream(ctx, streamCfg); err != nil {
+		return nil, errors.Wrap(err, "failed to create stream", logan.F{
+			"stream": q.stream(),
+		})
+	}
+
+	return q, nil
+}
+
+func (q *natsQueue) Name() string {
+	return q.name
+}
+
+func (q *natsQueue) Publish(ctx context.Context, payloads ...[]byte) error {
+	for _, payload := range payloads {
+		if _, err := q.js.Publish(ctx, q.subject(), payload); err != nil {
+			return errors.Wrap(err, "failed to publish message")
+		}
+	}
+
+	return nil
+}
+
+func (q *natsQueue) Consume(ctx context.Context, opts ConsumeOpts, handle func(ctx context.Context, batch []Delivery)) error {
+	consumer, err := q.getConsumer(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get consumer")
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		batch, err := consumer.Fetch(int(opts.PrefetchLimit), jetstream.FetchMaxWait(opts.PollDuration))
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch messages")
+		}
+
+		var deliveries []Delivery
+		for msg := range batch.Messages() {
+			deliveries = append(deliveries, natsDelivery{msg})
+		}
+
+		if err := batch.Error(); err != nil && err != jetstream.ErrNoMessages {
+			q.log.WithError(err).Warn("failed to fetch messages batch")
+		}
+
+		if len(deliveries) == 0 {
+			continue
+		}
+
+		handle(ctx, deliveries)
+	}
+}
+
+func (q *natsQueue) Lag(ctx context.Context) (int64, error) {
+	consumer, err := q.getConsumer(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get consumer")
+	}
+
+	info, err := consumer.Info(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get consumer info")
+	}
+
+	return int64(info.NumPending) + int64(info.NumAckPending), nil
+}
+
+func (q *natsQueue) Close() error {
+	return nil
+}
+
+func (q *natsQueue) getConsumer(ctx context.Context) (jetstream.Consumer, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.consumer != nil {
+		return q.consumer, nil
+	}
+
+	consumer, err := q.js.CreateOrUpdateConsumer(ctx, q.stream(), jetstream.ConsumerConfig{
+		Durable:   natsDurable,
+		AckPolicy: jetstream.AckExplicitPolicy,
+		AckWait:   q.ackWait,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create consumer", logan.F{
+			"stream": q.stream(),
+		})
+	}
+
+	q.consumer = consumer
+	return consumer, nil
+}
+
+// stream returns the stream name, which can not contain dots, spaces and wildcards
+func (q *natsQueue) stream() string {
+	return strings.NewReplacer(".", "_", " ", "_", "*", "_", ">", "_").Replace(q.name)
+}
+
+func (q *natsQueue) subject() string {
+	return natsSubjectPrefix + q.stream()
+}
+
+type natsDelivery struct {
+	msg jetstream.Msg
+}
+
+func (d natsDelivery) Payload() []byte {
+	return d.msg.Data()
+}
+
+func (d natsDelivery) Ack(_ context.Context) error {
+	return d.msg.Ack()
+}
+
+func (d natsDelivery) Reject(_ context.Context) error {
+	return d.msg.Term()
+}
@@ -0,0 +1,162 @@
+package msgs
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/adjust/rmq/v5"
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const BackendRMQ = "rmq"
+
+// rmqQueue is the queue implemented with the adjust/rmq lists on top of redis
+type rmqQueue struct {
+	log    *logan.Entry
+	client *redis.Client
+	name   string
+	tag    string
+
+	conn  rmq.Connection
+	queue rmq.Queue
+}
+
+func newRMQQueue(log *logan.Entry, client *redis.Client, tag, name string) (*rmqQueue, error) {
+	conn, err := rmq.OpenConnectionWithRedisClient(tag, client, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open connection to redis")
+	}
+
+	queue, err := conn.OpenQueue(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open queue", logan.F{
+			"queue_name": name,
+		})
+	}
+
+	return &rmqQueue{
+		log:    log,
+		client: client,
+		name:   name,
+		tag:    tag,
+		conn:   conn,
+		queue:  queue,
+	}, nil
+}
+
+func (q *rmqQueue) Name() string {
+	return q.name
+}
+
+func (q *rmqQueue) Publish(_ context.Context, payloads ...[]byte) error {
+	return q.queue.PublishBytes(payloads...)
+}
+
+func (q *rmqQueue) Consume(ctx context.Context, opts ConsumeOpts, handle func(ctx context.Context, batch []Delivery)) error {
+	// consuming is done over the separate connection, so it could be cleaned up
+	// after stop without affecting publishing
+	conn, err := rmq.OpenConnectionWithRedisClient(fmt.Sprintf("%s-consumer", opts.Consumer), q.client, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to open connection")
+	}
+
+	var queue rmq.Queue
+	defer func() { q.cleanup(conn, queue) }()
+
+	queue, err = conn.OpenQueue(q.name)
+	if err != nil {
+		return errors.Wrap(err, "failed to open queue", logan.F{
+			"queue_name": q.name,
+		})
+	}
+
+	if err := queue.StartConsuming(opts.PrefetchLimit, opts.PollDuration); err != nil {
+		return errors.Wrap(err, "failed to start consuming")
+	}
+
+	consumerName, err := queue.AddBatchConsumer(
+		fmt.Sprintf("%s-consumer", opts.Consumer),
+		opts.PrefetchLimit,
+		opts.PollDuration,
+		rmq.BatchConsumerFunc(func(batch rmq.Deliveries) {
+			deliveries := make([]Delivery, len(batch))
+			for i, delivery := range batch {
+				deliveries[i] = rmqDelivery{delivery}
+			}
+
+			handle(ctx, deliveries)
+		}))
+	if err != nil {
+		return errors.Wrap(err, "failed to add batch consumer")
+	}
+
+	q.log.WithField("consumer", consumerName).Info("added consumer")
+
+	// only possible way to return from here is context cancellation
+	// from outside and here we'll catch this stop, the batch being
+	// handled is waited for in the cleanup
+	<-ctx.Done()
+
+	return nil
+}
+
+// cleanup stops fetching and waits for the batch being handled, so only the prefetched deliveries which were
+// not passed to the handler are returned back to the queue
+func (q *rmqQueue) cleanup(conn rmq.Connection, queue rmq.Queue) {
+	q.log.Info("shutting down connection")
+	<-conn.StopAllConsuming()
+	q.log.Info("connection shut down")
+
+	if queue != nil {
+		returned, err := queue.ReturnUnacked(math.MaxInt64)
+		if err != nil {
+			q.log.WithError(err).Error("failed to return unacked back to queue")
+		}
+
+		q.log.WithField("cnt", returned).Info("returned unacked back to queue")
+	}
+
+	// unacked of the other consumers which were stopped without cleanup
+	unacked, err := rmq.NewCleaner(conn).Clean()
+	if err != nil {
+		q.log.WithError(err).Error("failed to clean up connection and queue")
+	}
+
+	q.log.WithField("cnt", unacked).Info("returned unacked of stale connections back to queue")
+}
+
+func (q *rmqQueue) Lag(_ context.Context) (int64, error) {
+	stats, err := q.conn.CollectStats([]string{q.name})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to collect queue stats")
+	}
+
+	stat := stats.QueueStats[q.name]
+	return stat.ReadyCount + stat.UnackedCount(), nil
+}
+
+func (q *rmqQueue) Close() error {
+	<-q.conn.StopAllConsuming()
+	return nil
+}
+
+type rmqDelivery struct {
+	rmq.Delivery
+}
+
+func (d rmqDelivery) Payload() []byte {
+	return []byte(d.Delivery.Payload())
+}
+
+func (d rmqDelivery) Ack(_ context.Context) error {
+	return d.Delivery.Ack()
+}
+
+// Reject acks the delivery as the rejected rmq messages are never purged, failed messages are
+// kept in the dead letter queue instead
+func (d rmqDelivery) Reject(_ context.Context) error {
+	return d.Delivery.Ack()
+}
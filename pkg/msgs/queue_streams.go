@@ -0,0 +1,222 @@
+package msgs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const (
+	BackendRedisStreams = "redis_streams"
+
+	streamKeyPrefix     = "stream:"
+	streamGroup         = "consumers"
+	streamPayloadField  = "payload"
+	defaultClaimMinIdle = 5 * time.Minute
+)
+
+// streamsQueue is the queue implemented with redis stream and a consumer group. Messages which
+// were not acked by the consumer for the claim min idle period are reclaimed by other consumers,
+// stream is trimmed to the max len on publishing.
+type streamsQueue struct {
+	log          *logan.Entry
+	client       *redis.Client
+	name         string
+	maxLen       int64
+	claimMinIdle time.Duration
+}
+
+func newStreamsQueue(log *logan.Entry, client *redis.Client, name string, maxLen int64, claimMinIdle time.Duration) *streamsQueue {
+	if claimMinIdle == 0 {
+		claimMinIdle = defaultClaimMinIdle
+	}
+
+	return &streamsQueue{
+		log:          log,
+		client:       client,
+		name:         name,
+		maxLen:       maxLen,
+		claimMinIdle: claimMinIdle,
+	}
+}
+
+func (q *streamsQueue) Name() string {
+	return q.name
+}
+
+func (q *streamsQueue) Publish(ctx context.Context, payloads ...[]byte) error {
+	pipe := q.client.Pipeline()
+
+	for _, payload := range payloads {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: q.key(),
+			MaxLen: q.maxLen,
+			Approx: true,
+			Values: map[string]interface{}{
+				streamPayloadField: payload,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "failed to add messages to stream")
+	}
+
+	return nil
+}
+
+func (q *streamsQueue) Consume(ctx context.Context, opts ConsumeOpts, handle func(ctx context.Context, batch []Delivery)) error {
+	err := q.client.XGroupCreateMkStream(ctx, q.key(), streamGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return errors.Wrap(err, "failed to create consumer group")
+	}
+
+	// consumer starts with its own pending messages left after the previous run
+	pending := true
+	lastClaim := time.Time{}
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if time.Since(lastClaim) >= q.claimMinIdle/2 {
+			claimed, err := q.claim(ctx, opts)
+			if err != nil {
+				return errors.Wrap(err, "failed to claim pending messages")
+			}
+
+			if len(claimed) != 0 {
+				handle(ctx, claimed)
+				continue
+			}
+
+			lastClaim = time.Now()
+		}
+
+		id := ">"
+		if pending {
+			id = "0"
+		}
+
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    streamGroup,
+			Consumer: opts.Consumer,
+			Streams:  []string{q.key(), id},
+			Count:    opts.PrefetchLimit,
+			Block:    opts.PollDuration,
+		}).Result()
+		if err != nil {
+			if err == redis.Nil || ctx.Err() != nil {
+				continue
+			}
+
+			return errors.Wrap(err, "failed to read from stream")
+		}
+
+		var deliveries []Delivery
+		for _, stream := range streams {
+			deliveries = append(deliveries, q.toDeliveries(ctx, stream.Messages)...)
+		}
+
+		if len(deliveries) == 0 {
+			pending = false
+			continue
+		}
+
+		handle(ctx, deliveries)
+	}
+}
+
+// claim takes messages which were not acked by other consumers for too long
+func (q *streamsQueue) claim(ctx context.Context, opts ConsumeOpts) ([]Delivery, error) {
+	messages, _, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   q.key(),
+		Group:    streamGroup,
+		Consumer: opts.Consumer,
+		MinIdle:  q.claimMinIdle,
+		Start:    "0-0",
+		Count:    opts.PrefetchLimit,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(messages) != 0 {
+		q.log.WithField("messages", len(messages)).Warn("claimed messages not acked by other consumers")
+	}
+
+	return q.toDeliveries(ctx, messages), nil
+}
+
+func (q *streamsQueue) toDeliveries(ctx context.Context, messages []redis.XMessage) []Delivery {
+	deliveries := make([]Delivery, 0, len(messages))
+
+	for _, message := range messages {
+		payload, ok := message.Values[streamPayloadField].(string)
+		if !ok {
+			// message was trimmed from the stream before it was acked
+			q.log.WithField("id", message.ID).Warn("pending message is missing in stream, skipping")
+			if err := q.client.XAck(ctx, q.key(), streamGroup, message.ID).Err(); err != nil {
+				q.log.WithError(err).WithField("id", message.ID).Error("failed to ack missing message")
+			}
+			continue
+		}
+
+		deliveries = append(deliveries, &streamDelivery{
+			queue:   q,
+			id:      message.ID,
+			payload: []byte(payload),
+		})
+	}
+
+	return deliveries
+}
+
+func (q *streamsQueue) Lag(ctx context.Context) (int64, error) {
+	groups, err := q.client.XInfoGroups(ctx, q.key()).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get stream groups")
+	}
+
+	for _, group := range groups {
+		if group.Name == streamGroup {
+			return group.Lag + group.Pending, nil
+		}
+	}
+
+	return q.client.XLen(ctx, q.key()).Result()
+}
+
+func (q *streamsQueue) Close() error {
+	return nil
+}
+
+func (q *streamsQueue) key() string {
+	return streamKeyPrefix + q.name
+}
+
+type streamDelivery struct {
+	queue   *streamsQueue
+	id      string
+	payload []byte
+}
+
+func (d *streamDelivery) Payload() []byte {
+	return d.payload
+}
+
+func (d *streamDelivery) Ack(ctx context.Context) error {
+	return d.queue.client.XAck(ctx, d.queue.key(), streamGroup, d.id).Err()
+}
+
+func (d *streamDelivery) Reject(ctx context.Context) error {
+	return d.Ack(ctx)
+}
@@ -0,0 +1,248 @@
+package msgs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/distributed_lab/figure/v3"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const queueOpenTimeout = 30 * time.Second
+
+type QueueConfig struct {
+	Backend string `fig:"backend"`
+	// MaxLen is the approximate max number of messages kept in the redis stream or nats stream
+	MaxLen int64 `fig:"max_len"`
+	// ClaimMinIdle is the period after which the messages not acked by the redis streams
+	// consumer are claimed by the other consumers
+	ClaimMinIdle time.Duration `fig:"claim_min_idle"`
+	// AckWait is the period after which the messages not acked by the nats consumer are redelivered
+	AckWait time.Duration `fig:"ack_wait"`
+}
+
+type QueuesConfig struct {
+	// Default is used for all the queues without overrides
+	Default   QueueConfig
+	Overrides map[string]QueueConfig
+	NATSURL   string
+}
+
+func (c QueuesConfig) Get(queue string) QueueConfig {
+	if cfg, ok := c.Overrides[queue]; ok {
+		return cfg
+	}
+
+	return c.Default
+}
+
+func (c QueuesConfig) validate() error {
+	queues := map[string]QueueConfig{"default": c.Default}
+	for name, cfg := range c.Overrides {
+		queues[name] = cfg
+	}
+
+	for name, cfg := range queues {
+		switch cfg.Backend {
+		case BackendRMQ, BackendRedisStreams, BackendMemory:
+		case BackendNATS:
+			if c.NATSURL == "" {
+				return errors.From(errors.New("nats url is required for nats queues"), logan.F{
+					"queue": name,
+				})
+			}
+		default:
+			return errors.From(errors.New("unknown queue backend"), logan.F{
+				"queue":   name,
+				"backend": cfg.Backend,
+			})
+		}
+	}
+
+	return nil
+}
+
+// Queues opens queues of the backends configured for them, so the services do not depend on
+// the particular backend.
+type Queues struct {
+	log    *logan.Entry
+	client *redis.Client
+	cfg    QueuesConfig
+
+	mu sync.Mutex
+	js jetstream.JetStream
+}
+
+func NewQueues(log *logan.Entry, client *redis.Client, cfg QueuesConfig) *Queues {
+	return &Queues{
+		log:    log,
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// RedisClient returns client used by the redis backends and auxiliary storages like dead letters
+func (q *Queues) RedisClient() *redis.Client {
+	return q.client
+}
+
+// Open opens queue with the given name, tag is used to identify the connection in the backend
+func (q *Queues) Open(tag, name string) (Queue, error) {
+	cfg := q.cfg.Get(name)
+	log := q.log.WithFields(logan.F{
+		"queue":   name,
+		"backend": cfg.Backend,
+	})
+
+	switch cfg.Backend {
+	case BackendRMQ, "":
+		return newRMQQueue(log, q.client, tag, name)
+	case BackendRedisStreams:
+		return newStreamsQueue(log, q.client, name, cfg.MaxLen, cfg.ClaimMinIdle), nil
+	case BackendNATS:
+		js, err := q.jetstream()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to nats")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), queueOpenTimeout)
+		defer cancel()
+
+		return newNATSQueue(ctx, log, js, name, cfg.MaxLen, cfg.AckWait)
+	case BackendMemory:
+		return NewMemoryQueue(name), nil
+	default:
+		return nil, errors.From(errors.New("unknown queue backend"), logan.F{
+			"queue":   name,
+			"backend": cfg.Backend,
+		})
+	}
+}
+
+func (q *Queues) jetstream() (jetstream.JetStream, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.js != nil {
+		return q.js, nil
+	}
+
+	if q.cfg.NATSURL == "" {
+		return nil, errors.New("nats url is not configured")
+	}
+
+	conn, err := nats.Connect(q.cfg.NATSURL, nats.Name("horizon"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect")
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to create jetstream context")
+	}
+
+	q.js = js
+	return js, nil
+}
+
+type Queueser interface {
+	Queues() *Queues
+}
+
+type queueser struct {
+	getter kv.Getter
+	once   comfig.Once
+
+	opts QueueserOpts
+}
+
+type QueueserOpts struct {
+	Log         *logan.Entry
+	RedisClient *redis.Client
+}
+
+func NewQueueser(getter kv.Getter, opts QueueserOpts) Queueser {
+	if opts.RedisClient == nil {
+		panic("redis client is required for queueser")
+	}
+
+	return &queueser{
+		getter: getter,
+		opts:   opts,
+	}
+}
+
+// Queues reads the optional `queues` section, where top level keys are the defaults for all the
+// queues and `overrides` configures particular queues by their names:
+//
+//	queues:
+//	  backend: rmq
+//	  nats_url: nats://localhost:4222
+//	  overrides:
+//	    withdrawals:
+//	      backend: redis_streams
+//	      max_len: 100000
+func (q *queueser) Queues() *Queues {
+	return q.once.Do(func() interface{} {
+		raw := kv.MustGetStringMap(q.getter, "queues")
+
+		defaults := QueueConfig{
+			Backend: BackendRMQ,
+		}
+
+		if err := figure.Out(&defaults).From(raw).Please(); err != nil {
+			panic(errors.Wrap(err, "failed to figure out queues"))
+		}
+
+		var nats struct {
+			URL string `fig:"nats_url"`
+		}
+
+		if err := figure.Out(&nats).From(raw).Please(); err != nil {
+			panic(errors.Wrap(err, "failed to figure out queues nats url"))
+		}
+
+		result := QueuesConfig{
+			Default:   defaults,
+			Overrides: make(map[string]QueueConfig),
+			NATSURL:   nats.URL,
+		}
+
+		overrides, ok := raw["overrides"].(map[string]interface{})
+		if !ok && raw["overrides"] != nil {
+			panic(errors.New("queues overrides must be a map"))
+		}
+
+		for name, value := range overrides {
+			override, ok := value.(map[string]interface{})
+			if !ok {
+				panic(errors.From(errors.New("queue override must be a map"), logan.F{
+					"queue": name,
+				}))
+			}
+
+			queueCfg := result.Default
+			if err := figure.Out(&queueCfg).From(override).Please(); err != nil {
+				panic(errors.Wrap(err, "failed to figure out queue override", logan.F{
+					"queue": name,
+				}))
+			}
+
+			result.Overrides[name] = queueCfg
+		}
+
+		if err := result.validate(); err != nil {
+			panic(errors.Wrap(err, "invalid queues config"))
+		}
+
+		return NewQueues(q.opts.Log, q.opts.RedisClient, result)
+	}).(*Queues)
+}
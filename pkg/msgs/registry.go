@@ -0,0 +1,182 @@
+package msgs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// Upgrade converts the payload of the given schema version to the next one
+type Upgrade func(payload json.RawMessage) (json.RawMessage, error)
+
+type registration struct {
+	goType   reflect.Type
+	pointer  bool
+	typ      MessageType
+	version  uint32
+	encoding Encoding
+	upgrades map[uint32]Upgrade
+}
+
+var registry = struct {
+	mu       sync.RWMutex
+	byType   map[MessageType]*registration
+	byGoType map[reflect.Type]*registration
+}{
+	byType:   make(map[MessageType]*registration),
+	byGoType: make(map[reflect.Type]*registration),
+}
+
+func init() {
+	Register(MessageTypeBlockRange, 1, BlockRangeMessage{})
+
+	Register(MessageTypeTransferOp, 1, TransferOpMsg{})
+	Register(MessageTypeConfirmationOp, 1, ConfirmationOpMsg{})
+	Register(MessageTypeVoteOp, 1, VoteOpMsg{})
+	Register(MessageTypeApprovalOp, 1, ApprovalOpMsg{})
+	Register(MessageTypeRejectionOp, 1, RejectionOpMsg{})
+
+	Register(MessageTypeCollectionCreated, 1, CollectionCreatedMessage{})
+	Register(MessageTypeCollectionRemoved, 1, CollectionRemovedMessage{})
+	Register(MessageTypeCollectionDataCreated, 1, CollectionDataCreatedMessage{})
+	Register(MessageTypeCollectionDataRemoved, 1, CollectionDataRemovedMessage{})
+	Register(MessageTypeCollectionDataUpdated, 1, CollectionDataUpdatedMessage{})
+
+	Register(MessageTypeItemCreated, 1, ItemCreatedMessage{})
+	Register(MessageTypeItemRemoved, 1, ItemRemovedMessage{})
+	Register(MessageTypeItemOnChainDataCreated, 1, ItemOnChainDataCreatedMessage{})
+	Register(MessageTypeItemOnChainDataRemoved, 1, ItemOnChainDataRemovedMessage{})
+	Register(MessageTypeSeedCreated, 1, SeedCreatedMessage{})
+	Register(MessageTypeSeedRemoved, 1, SeedRemovedMessage{})
+
+	Register(MessageTypeWithdrawal, 1, WithdrawalMsg{})
+	Register(MessageTypeWithdrawalRetracted, 1, WithdrawalRetractedMsg{})
+}
+
+// Register binds message type and its current schema version to the Go type of the sample.
+// Messages of the protobuf types are encoded with protobuf. Panics on duplicates, so it is
+// supposed to be called from init.
+func Register(typ MessageType, version uint32, sample interface{}) {
+	encoding := EncodingJSON
+	if _, ok := sample.(proto.Message); ok {
+		encoding = EncodingProtobuf
+	}
+
+	goType := indirectType(sample)
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.byType[typ]; ok {
+		panic(fmt.Sprintf("message type %s is already registered", typ))
+	}
+
+	if _, ok := registry.byGoType[goType]; ok {
+		panic(fmt.Sprintf("go type %s is already registered", goType))
+	}
+
+	reg := &registration{
+		goType:   goType,
+		pointer:  reflect.TypeOf(sample).Kind() == reflect.Ptr,
+		typ:      typ,
+		version:  version,
+		encoding: encoding,
+		upgrades: make(map[uint32]Upgrade),
+	}
+
+	registry.byType[typ] = reg
+	registry.byGoType[goType] = reg
+}
+
+// RegisterUpgrade registers conversion of the JSON payload from the given schema version to
+// the next one, so the messages published before the shape change are still decoded.
+// Versions without the upgrade are considered compatible with the next one.
+func RegisterUpgrade(typ MessageType, from uint32, upgrade Upgrade) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	reg, ok := registry.byType[typ]
+	if !ok {
+		panic(fmt.Sprintf("message type %s is not registered", typ))
+	}
+
+	if from >= reg.version {
+		panic(fmt.Sprintf("upgrade from version %d of %s is not older than the current one", from, typ))
+	}
+
+	reg.upgrades[from] = upgrade
+}
+
+// IsRegistered reports whether consumers of this build are able to decode messages of the type
+func IsRegistered(typ MessageType) bool {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	_, ok := registry.byType[typ]
+	return ok
+}
+
+func registrationByType(typ MessageType) (*registration, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	reg, ok := registry.byType[typ]
+	if !ok {
+		return nil, errors.From(ErrUnknownMessageType, logan.F{
+			"type": typ,
+		})
+	}
+
+	return reg, nil
+}
+
+func registrationOf(v interface{}) (*registration, error) {
+	goType := indirectType(v)
+
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	reg, ok := registry.byGoType[goType]
+	if !ok {
+		return nil, errors.From(ErrUnknownMessageType, logan.F{
+			"go_type": goType.String(),
+		})
+	}
+
+	return reg, nil
+}
+
+func (r *registration) upgrade(version uint32, payload json.RawMessage) (json.RawMessage, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	var err error
+	for ; version < r.version; version++ {
+		upgrade, ok := r.upgrades[version]
+		if !ok {
+			continue
+		}
+
+		if payload, err = upgrade(payload); err != nil {
+			return nil, errors.Wrap(err, "failed to upgrade", logan.F{
+				"from": version,
+			})
+		}
+	}
+
+	return payload, nil
+}
+
+func indirectType(v interface{}) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
@@ -8,21 +8,29 @@ const (
 )
 
 type SeedCreatedMessage struct {
+	EventSource
 	Seed      string `json:"seed"`
 	ItemIndex string `json:"index"`
-	TxHash    string `json:"tx_hash"`
 }
 
 func (m SeedCreatedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeSeedCreated)
 }
 
+func (m SeedCreatedMessage) MessageKey() string {
+	return m.ItemIndex
+}
+
 type SeedRemovedMessage struct {
+	EventSource
 	Seed      string `json:"seed"`
 	ItemIndex string `json:"index"`
-	TxHash    string `json:"tx_hash"`
 }
 
 func (m SeedRemovedMessage) Message() Message {
 	return marshalToMsg(m, MessageTypeSeedRemoved)
 }
+
+func (m SeedRemovedMessage) MessageKey() string {
+	return m.ItemIndex
+}
@@ -0,0 +1,30 @@
+package msgs
+
+import "context"
+
+type traceIDKey struct{}
+
+// ContextWithTraceID makes publishers stamp the messages with the given trace id, so the
+// messages produced while handling another one can be correlated with it
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	if traceID == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// TraceID returns trace id of the message or empty string if the message is malformed
+func (m *Message) TraceID() string {
+	envelope, err := m.Envelope()
+	if err != nil {
+		return ""
+	}
+
+	return envelope.TraceID
+}
@@ -19,22 +19,40 @@ func (m VoteOpMsg) Message() Message {
 	return marshalToMsg(m, MessageTypeVoteOp)
 }
 
+func (m VoteOpMsg) MessageKey() string {
+	return m.OperationID
+}
+
 type ApprovalOpMsg struct {
 	OperationID     string `json:"operation_id"`
 	OperationType   string `json:"operation_type"`
 	TransactionHash string `json:"transaction_hash"`
+	// BlockTime is the unix time of the block with the transaction, zero in the messages published
+	// before it was added
+	BlockTime int64 `json:"block_time,omitempty"`
 }
 
 func (m ApprovalOpMsg) Message() Message {
 	return marshalToMsg(m, MessageTypeApprovalOp)
 }
 
+func (m ApprovalOpMsg) MessageKey() string {
+	return m.OperationID
+}
+
 type RejectionOpMsg struct {
 	OperationID     string `json:"operation_id"`
 	OperationType   string `json:"operation_type"`
 	TransactionHash string `json:"transaction_hash"`
+	// BlockTime is the unix time of the block with the transaction, zero in the messages published
+	// before it was added
+	BlockTime int64 `json:"block_time,omitempty"`
 }
 
 func (m RejectionOpMsg) Message() Message {
 	return marshalToMsg(m, MessageTypeRejectionOp)
 }
+
+func (m RejectionOpMsg) MessageKey() string {
+	return m.OperationID
+}
@@ -13,3 +13,26 @@ type WithdrawalMsg struct {
 func (m WithdrawalMsg) Message() Message {
 	return marshalToMsg(m, MessageTypeWithdrawal)
 }
+
+func (m WithdrawalMsg) MessageKey() string {
+	return m.Origin
+}
+
+const (
+	MessageTypeWithdrawalRetracted MessageType = "bridge_withdrawal_retracted"
+)
+
+// WithdrawalRetractedMsg is published when the block containing previously published
+// withdrawal was reorganized out of the canonical chain
+type WithdrawalRetractedMsg struct {
+	Origin string `json:"origin"`
+	Hash   string `json:"hash"`
+}
+
+func (m WithdrawalRetractedMsg) Message() Message {
+	return marshalToMsg(m, MessageTypeWithdrawalRetracted)
+}
+
+func (m WithdrawalRetractedMsg) MessageKey() string {
+	return m.Origin
+}
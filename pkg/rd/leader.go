@@ -0,0 +1,103 @@
+package rd
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// RunAsLeader runs f only while the mutex is held, so only one of the replicas runs it at
+// a time while the others are waiting in standby. Mutex is extended in the background, if
+// the lease is lost the context of f is canceled and the replica goes back to standby.
+// Returns when ctx is done.
+func RunAsLeader(ctx context.Context, log *logan.Entry, mutex Mutex, expiry time.Duration, f func(ctx context.Context)) {
+	log = log.WithField("lease", mutex.Name())
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := mutex.LockContext(ctx)
+		switch {
+		case err == nil:
+			log.Info("acquired lease, running as leader")
+			runLeading(ctx, log, mutex, expiry, f)
+			continue
+		case errors.Cause(err) == ErrMutexTaken:
+			log.Debug("lease is held by another replica, waiting in standby")
+		case ctx.Err() != nil:
+			return
+		default:
+			log.WithError(err).Error("failed to acquire lease")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(expiry / 2):
+		}
+	}
+}
+
+func runLeading(ctx context.Context, log *logan.Entry, mutex Mutex, expiry time.Duration, f func(ctx context.Context)) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		renewLease(leaderCtx, log, mutex, expiry, cancel)
+	}()
+
+	f(leaderCtx)
+
+	cancel()
+	<-renewed
+
+	// parent context may be already canceled, so the lease is released with the own one
+	unlockCtx, unlockCancel := context.WithTimeout(context.Background(), expiry)
+	defer unlockCancel()
+
+	if _, err := mutex.UnlockContext(unlockCtx); err != nil {
+		log.WithError(err).Warn("failed to release lease, it will expire")
+		return
+	}
+
+	log.Info("released lease")
+}
+
+// renewLease extends the lease every third of the expiry period and cancels leadership once
+// the lease is lost or could not be extended before it expires
+func renewLease(ctx context.Context, log *logan.Entry, mutex Mutex, expiry time.Duration, cancel context.CancelFunc) {
+	ticker := time.NewTicker(expiry / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ok, err := mutex.ExtendContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.WithError(err).Warn("failed to extend lease")
+			if time.Until(mutex.Until()) > expiry/3 {
+				continue
+			}
+		}
+
+		if err != nil || !ok {
+			log.Error("lost lease, stopping leader")
+			cancel()
+			return
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package rd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const (
+	defaultMutexExpiry = 10 * time.Second
+	mutexKeyPrefix     = "mutex:"
+)
+
+var ErrMutexTaken = errors.New("mutex is held by another owner")
+
+// extendScript prolongs the mutex only if it is still held by the same owner
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+else
+	return 0
+end`)
+
+// unlockScript removes the mutex only if it is still held by the same owner
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+else
+	return 0
+end`)
+
+// redisMutex is the lease stored in the single redis key with the random value of the owner,
+// lease expires after the expiry period unless it is extended by the owner
+type redisMutex struct {
+	client *redis.Client
+	name   string
+	value  string
+	opts   MutexOpts
+	until  time.Time
+}
+
+func NewMutex(client *redis.Client, name string, opts MutexOpts) Mutex {
+	if opts.Expiry == 0 {
+		opts.Expiry = defaultMutexExpiry
+	}
+
+	if opts.Tries == 0 {
+		opts.Tries = 1
+	}
+
+	return &redisMutex{
+		client: client,
+		name:   name,
+		value:  newMutexValue(),
+		opts:   opts,
+	}
+}
+
+func (m *redisMutex) Name() string {
+	return m.name
+}
+
+func (m *redisMutex) Value() string {
+	return m.value
+}
+
+func (m *redisMutex) Until() time.Time {
+	return m.until
+}
+
+// LockContext tries to take the mutex up to the configured number of tries, returns
+// ErrMutexTaken if it is held by another owner all that time
+func (m *redisMutex) LockContext(ctx context.Context) error {
+	retryDelay := m.opts.Expiry / 10
+
+	for try := 0; try < m.opts.Tries; try++ {
+		if try != 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryDelay):
+			}
+		}
+
+		start := time.Now()
+
+		ok, err := m.client.SetNX(ctx, m.key(), m.value, m.opts.Expiry).Result()
+		if err != nil {
+			return errors.Wrap(err, "failed to set mutex", logan.F{
+				"name": m.name,
+			})
+		}
+
+		if ok {
+			m.until = start.Add(m.opts.Expiry)
+			return nil
+		}
+	}
+
+	return ErrMutexTaken
+}
+
+func (m *redisMutex) UnlockContext(ctx context.Context) (bool, error) {
+	res, err := unlockScript.Run(ctx, m.client, []string{m.key()}, m.value).Int64()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to unlock mutex", logan.F{
+			"name": m.name,
+		})
+	}
+
+	m.until = time.Time{}
+	return res == 1, nil
+}
+
+// ExtendContext resets the expiry of the held mutex, returns false if it is not held anymore
+func (m *redisMutex) ExtendContext(ctx context.Context) (bool, error) {
+	start := time.Now()
+
+	res, err := extendScript.Run(ctx, m.client, []string{m.key()}, m.value, m.opts.Expiry.Milliseconds()).Int64()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to extend mutex", logan.F{
+			"name": m.name,
+		})
+	}
+
+	if res != 1 {
+		return false, nil
+	}
+
+	m.until = start.Add(m.opts.Expiry)
+	return true, nil
+}
+
+func (m *redisMutex) key() string {
+	return mutexKeyPrefix + m.name
+}
+
+func newMutexValue() string {
+	var value [16]byte
+	if _, err := rand.Read(value[:]); err != nil {
+		panic(errors.Wrap(err, "failed to generate mutex value"))
+	}
+
+	return hex.EncodeToString(value[:])
+}
@@ -0,0 +1,31 @@
+package resources
+
+type JourneyState string
+
+const (
+	JourneyStateDeposited JourneyState = "deposited"
+	JourneyStateOnRarimo  JourneyState = "on_rarimo"
+	JourneyStateApproved  JourneyState = "approved"
+	JourneyStateSigned    JourneyState = "signed"
+	JourneyStateWithdrawn JourneyState = "withdrawn"
+	JourneyStateFailed    JourneyState = "failed"
+)
+
+type JourneyStageName string
+
+const (
+	JourneyStageDeposit    JourneyStageName = "deposit"
+	JourneyStageOperation  JourneyStageName = "operation"
+	JourneyStageApproval   JourneyStageName = "approval"
+	JourneyStageSigning    JourneyStageName = "signing"
+	JourneyStageWithdrawal JourneyStageName = "withdrawal"
+)
+
+type JourneyStageStatus string
+
+const (
+	JourneyStageStatusPending JourneyStageStatus = "pending"
+	JourneyStageStatusDone    JourneyStageStatus = "done"
+	JourneyStageStatusFailed  JourneyStageStatus = "failed"
+	JourneyStageStatusSkipped JourneyStageStatus = "skipped"
+)
@@ -0,0 +1,43 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "encoding/json"
+
+type DeadLetter struct {
+	Key
+	Attributes DeadLetterAttributes `json:"attributes"`
+}
+type DeadLetterResponse struct {
+	Data     DeadLetter `json:"data"`
+	Included Included   `json:"included"`
+}
+
+type DeadLetterListResponse struct {
+	Data     []DeadLetter    `json:"data"`
+	Included Included        `json:"included"`
+	Links    *Links          `json:"links"`
+	Meta     json.RawMessage `json:"meta,omitempty"`
+}
+
+func (r *DeadLetterListResponse) PutMeta(v interface{}) (err error) {
+	r.Meta, err = json.Marshal(v)
+	return err
+}
+
+func (r *DeadLetterListResponse) GetMeta(out interface{}) error {
+	return json.Unmarshal(r.Meta, out)
+}
+
+// MustDeadLetter - returns DeadLetter from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustDeadLetter(key Key) *DeadLetter {
+	var deadLetter DeadLetter
+	if c.tryFindEntry(key, &deadLetter) {
+		return &deadLetter
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DeadLetterAttributes struct {
+	// Number of handling attempts made
+	Attempts uint64 `json:"attempts"`
+	// Name of the consumer which failed to handle messages
+	Consumer string `json:"consumer"`
+	// Error returned by the handler on the last attempt
+	Error string `json:"error"`
+	// Time (UTC) the batch was moved to the dead letter queue, RFC3339 format
+	FailedAt time.Time `json:"failed_at"`
+	// Time (UTC) of the first handling attempt, RFC3339 format
+	FirstAttemptAt time.Time `json:"first_attempt_at"`
+	// Raw messages of the batch
+	Messages []json.RawMessage `json:"messages"`
+	// Name of the queue messages were consumed from
+	Queue string `json:"queue"`
+}
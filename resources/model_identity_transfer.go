@@ -0,0 +1,44 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "encoding/json"
+
+type IdentityTransfer struct {
+	Key
+	Attributes    IdentityTransferAttributes    `json:"attributes"`
+	Relationships IdentityTransferRelationships `json:"relationships"`
+}
+type IdentityTransferResponse struct {
+	Data     IdentityTransfer `json:"data"`
+	Included Included         `json:"included"`
+}
+
+type IdentityTransferListResponse struct {
+	Data     []IdentityTransfer `json:"data"`
+	Included Included           `json:"included"`
+	Links    *Links             `json:"links"`
+	Meta     json.RawMessage    `json:"meta,omitempty"`
+}
+
+func (r *IdentityTransferListResponse) PutMeta(v interface{}) (err error) {
+	r.Meta, err = json.Marshal(v)
+	return err
+}
+
+func (r *IdentityTransferListResponse) GetMeta(out interface{}) error {
+	return json.Unmarshal(r.Meta, out)
+}
+
+// MustIdentityTransfer - returns IdentityTransfer from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustIdentityTransfer(key Key) *IdentityTransfer {
+	var identityTransfer IdentityTransfer
+	if c.tryFindEntry(key, &identityTransfer) {
+		return &identityTransfer
+	}
+	return nil
+}
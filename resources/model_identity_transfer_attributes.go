@@ -0,0 +1,44 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type IdentityTransferAttributes struct {
+	// Name of the destination chain
+	Chain string `json:"chain"`
+	// Address of the state contract on the destination chain
+	Contract string `json:"contract"`
+	// Time (UTC) of the operation creation in rarimo core, RFC3339 format
+	CreatedAt time.Time `json:"created_at"`
+	// Time (UTC) of the GIST creation, RFC3339 format
+	GistCreatedAt *time.Time `json:"gist_created_at,omitempty"`
+	// Number of the block GIST was created at
+	GistCreatedAtBlock *int64 `json:"gist_created_at_block,omitempty"`
+	// Hash of the GIST root
+	GistHash *string `json:"gist_hash,omitempty"`
+	// Identifier of the identity whose state is transferred
+	IdentityId *string `json:"identity_id,omitempty"`
+	// Index of the operation in rarimo core
+	OperationIndex string `json:"operation_index"`
+	// Merkle proof of the operation in the signed confirmation, only for the signed transfers
+	Proof *OperationProof `json:"proof,omitempty"`
+	// Hash of the GIST root replaced by the transferred one
+	ReplacedGistHash *string `json:"replaced_gist_hash,omitempty"`
+	// Hash of the identity state replaced by the transferred one
+	ReplacedStateHash *string `json:"replaced_state_hash,omitempty"`
+	// Time (UTC) of the identity state creation, RFC3339 format
+	StateCreatedAt *time.Time `json:"state_created_at,omitempty"`
+	// Number of the block identity state was created at
+	StateCreatedAtBlock *int64 `json:"state_created_at_block,omitempty"`
+	// Hash of the identity state
+	StateHash *string `json:"state_hash,omitempty"`
+	// Root of the aggregated identity states
+	StateRootHash *string `json:"state_root_hash,omitempty"`
+	// Shows state of the operation
+	Status TransferState `json:"status"`
+	// Type of the operation
+	Type OperationType `json:"type"`
+}
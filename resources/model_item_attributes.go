@@ -4,9 +4,14 @@
 
 package resources
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ItemAttributes struct {
+	// Time (UTC) the item was removed from core, RFC3339 format, absent if it was not removed
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 	// unique index of the item saved on core
 	Index string `json:"index"`
 	// free form JSON object representing item's metadata saved on core
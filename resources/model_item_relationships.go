@@ -7,4 +7,5 @@ package resources
 type ItemRelationships struct {
 	ChainMappings *map[string]interface{} `json:"chain_mappings,omitempty"`
 	Collection    Relation                `json:"collection"`
+	Seeds         *RelationCollection     `json:"seeds,omitempty"`
 }
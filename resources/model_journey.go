@@ -0,0 +1,26 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type Journey struct {
+	Key
+	Attributes    JourneyAttributes    `json:"attributes"`
+	Relationships JourneyRelationships `json:"relationships"`
+}
+type JourneyResponse struct {
+	Data     Journey  `json:"data"`
+	Included Included `json:"included"`
+}
+
+// MustJourney - returns Journey from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustJourney(key Key) *Journey {
+	var journey Journey
+	if c.tryFindEntry(key, &journey) {
+		return &journey
+	}
+	return nil
+}
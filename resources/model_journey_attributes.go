@@ -0,0 +1,14 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type JourneyAttributes struct {
+	// Identifier of the transfer origin
+	Origin string `json:"origin"`
+	// Stages of the cross-chain transfer in the chronological order
+	Stages []JourneyStage `json:"stages"`
+	// Overall state of the cross-chain transfer
+	State JourneyState `json:"state"`
+}
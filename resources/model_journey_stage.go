@@ -0,0 +1,22 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type JourneyStage struct {
+	// Name of the chain the stage happened on
+	Chain string `json:"chain"`
+	// Link to the stage transaction in the chain explorer, absent if the explorer is not configured
+	ExplorerUrl *string `json:"explorer_url,omitempty"`
+	// Hash of the stage transaction, absent if the stage has not happened yet
+	Hash *string `json:"hash,omitempty"`
+	// Name of the stage
+	Name JourneyStageName `json:"name"`
+	// Status of the stage
+	Status JourneyStageStatus `json:"status"`
+	// Time (UTC) of the stage, RFC3339 format, absent if unknown
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
@@ -4,11 +4,15 @@
 
 package resources
 
+import "time"
+
 type NftMetadataAttributes struct {
 	AnimationUrl *string                `json:"animation_url,omitempty"`
 	Attributes   []NftMetadataAttribute `json:"attributes"`
 	Description  *string                `json:"description,omitempty"`
 	ExternalUrl  *string                `json:"external_url,omitempty"`
+	// Time the metadata was fetched from the chain
+	FetchedAt *time.Time `json:"fetched_at,omitempty"`
 	// Link to image
 	ImageUrl string `json:"image_url"`
 	// original url to metadata stored in the contract
@@ -0,0 +1,23 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type OperationAttributes struct {
+	// Time (UTC) of the operation creation in rarimo core, RFC3339 format
+	CreatedAt time.Time `json:"created_at"`
+	// Operation details, the same as returned by the rarimo core API for the operation type
+	Details json.RawMessage `json:"details"`
+	// Index of the operation in rarimo core
+	Index string `json:"index"`
+	// Shows state of the operation
+	Status TransferState `json:"status"`
+	// Type of the operation
+	Type OperationType `json:"type"`
+}
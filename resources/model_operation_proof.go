@@ -0,0 +1,12 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type OperationProof struct {
+	// Hex-encoded merkle path from the operation content hash to the signed root
+	Path []string `json:"path"`
+	// Hex-encoded ECDSA signature of the merkle root by the threshold signature parties
+	Signature string `json:"signature"`
+}
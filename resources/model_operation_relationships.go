@@ -0,0 +1,12 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type OperationRelationships struct {
+	Confirmation *Relation           `json:"confirmation,omitempty"`
+	Creator      *Relation           `json:"creator,omitempty"`
+	Tx           *Relation           `json:"tx,omitempty"`
+	Votes        *RelationCollection `json:"votes,omitempty"`
+}
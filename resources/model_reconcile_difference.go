@@ -0,0 +1,18 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type ReconcileDifference struct {
+	// Name of the chain the difference was found on, absent for the differences of the core entries
+	Chain *string `json:"chain,omitempty"`
+	// Index of the collection
+	Collection *string `json:"collection,omitempty"`
+	// Index of the item, absent for the differences of the collections
+	Item *string `json:"item,omitempty"`
+	// Kind of the difference
+	Kind string `json:"kind"`
+	// Whether the difference was repaired
+	Repaired bool `json:"repaired"`
+}
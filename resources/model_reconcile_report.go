@@ -0,0 +1,31 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type ReconcileReport struct {
+	Key
+	Attributes ReconcileReportAttributes `json:"attributes"`
+}
+type ReconcileReportResponse struct {
+	Data     ReconcileReport `json:"data"`
+	Included Included        `json:"included"`
+}
+
+type ReconcileReportListResponse struct {
+	Data     []ReconcileReport `json:"data"`
+	Included Included          `json:"included"`
+	Links    *Links            `json:"links"`
+}
+
+// MustReconcileReport - returns ReconcileReport from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustReconcileReport(key Key) *ReconcileReport {
+	var reconcileReport ReconcileReport
+	if c.tryFindEntry(key, &reconcileReport) {
+		return &reconcileReport
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type ReconcileReportAttributes struct {
+	// Numbers of the found differences by their kinds
+	Counts map[string]int64 `json:"counts"`
+	// Found differences, truncated to the configured limit
+	Differences []ReconcileDifference `json:"differences"`
+	// Time (UTC) the reconciliation finished, RFC3339 format
+	FinishedAt time.Time `json:"finished_at"`
+	// Whether the differences were repaired
+	Repair bool `json:"repair"`
+	// Number of the repaired differences
+	Repaired int64 `json:"repaired"`
+	// Time (UTC) the reconciliation started, RFC3339 format
+	StartedAt time.Time `json:"started_at"`
+	// Whether the differences list was truncated
+	Truncated bool `json:"truncated"`
+}
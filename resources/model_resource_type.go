@@ -14,11 +14,21 @@ const (
 	BUILD_TX_REQUESTS        ResourceType = "build-tx-requests"
 	CHAINS                   ResourceType = "chains"
 	COLLECTIONS              ResourceType = "collections"
+	DEAD_LETTERS             ResourceType = "dead-letters"
+	IDENTITY_TRANSFERS       ResourceType = "identity-transfers"
 	ITEM_CHAIN_MAPPINGS      ResourceType = "item_chain_mappings"
 	ITEMS                    ResourceType = "items"
+	JOURNEYS                 ResourceType = "journeys"
 	NFTS_METADATA            ResourceType = "nfts-metadata"
+	OPERATIONS               ResourceType = "operations"
+	RECONCILE_REPORTS        ResourceType = "reconcile-reports"
+	SEEDS                    ResourceType = "seeds"
+	SERVICE_STATES           ResourceType = "service-states"
+	STUCK_TRANSFER_ALERTS    ResourceType = "stuck-transfer-alerts"
+	TOKENMANAGER_EVENTS      ResourceType = "tokenmanager-events"
 	TRANSACTIONS             ResourceType = "transactions"
 	TRANSFERS                ResourceType = "transfers"
 	UNSUBMITTED_TRANSACTIONS ResourceType = "unsubmitted-transactions"
+	VOTES                    ResourceType = "votes"
 	WITHDRAWALS              ResourceType = "withdrawals"
 )
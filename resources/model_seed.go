@@ -0,0 +1,44 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "encoding/json"
+
+type Seed struct {
+	Key
+	Attributes    SeedAttributes    `json:"attributes"`
+	Relationships SeedRelationships `json:"relationships"`
+}
+type SeedResponse struct {
+	Data     Seed     `json:"data"`
+	Included Included `json:"included"`
+}
+
+type SeedListResponse struct {
+	Data     []Seed          `json:"data"`
+	Included Included        `json:"included"`
+	Links    *Links          `json:"links"`
+	Meta     json.RawMessage `json:"meta,omitempty"`
+}
+
+func (r *SeedListResponse) PutMeta(v interface{}) (err error) {
+	r.Meta, err = json.Marshal(v)
+	return err
+}
+
+func (r *SeedListResponse) GetMeta(out interface{}) error {
+	return json.Unmarshal(r.Meta, out)
+}
+
+// MustSeed - returns Seed from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustSeed(key Key) *Seed {
+	var seed Seed
+	if c.tryFindEntry(key, &seed) {
+		return &seed
+	}
+	return nil
+}
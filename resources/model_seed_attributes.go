@@ -0,0 +1,14 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type SeedAttributes struct {
+	// Time (UTC) the seed was indexed, RFC3339 format
+	CreatedAt time.Time `json:"created_at"`
+	// Hash of the transaction that created the seed or `block:<height>` if it was created on the end of the block, absent if unknown
+	CreatedTx *string `json:"created_tx,omitempty"`
+}
@@ -0,0 +1,31 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type ServiceState struct {
+	Key
+	Attributes ServiceStateAttributes `json:"attributes"`
+}
+type ServiceStateResponse struct {
+	Data     ServiceState `json:"data"`
+	Included Included     `json:"included"`
+}
+
+type ServiceStateListResponse struct {
+	Data     []ServiceState `json:"data"`
+	Included Included       `json:"included"`
+	Links    *Links         `json:"links"`
+}
+
+// MustServiceState - returns ServiceState from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustServiceState(key Key) *ServiceState {
+	var serviceState ServiceState
+	if c.tryFindEntry(key, &serviceState) {
+		return &serviceState
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type ServiceStateAttributes struct {
+	// Time (UTC) the service crashed last time, RFC3339 format, absent if it never crashed
+	CrashedAt *time.Time `json:"crashed_at,omitempty"`
+	// Error the service crashed with last time
+	LastError *string `json:"last_error,omitempty"`
+	// Number of the service restarts after the crashes
+	Restarts int `json:"restarts"`
+	// Time (UTC) the service was started last time, RFC3339 format
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	// Status of the service
+	Status string `json:"status"`
+}
@@ -0,0 +1,43 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "encoding/json"
+
+type StuckTransferAlert struct {
+	Key
+	Attributes StuckTransferAlertAttributes `json:"attributes"`
+}
+type StuckTransferAlertResponse struct {
+	Data     StuckTransferAlert `json:"data"`
+	Included Included           `json:"included"`
+}
+
+type StuckTransferAlertListResponse struct {
+	Data     []StuckTransferAlert `json:"data"`
+	Included Included             `json:"included"`
+	Links    *Links               `json:"links"`
+	Meta     json.RawMessage      `json:"meta,omitempty"`
+}
+
+func (r *StuckTransferAlertListResponse) PutMeta(v interface{}) (err error) {
+	r.Meta, err = json.Marshal(v)
+	return err
+}
+
+func (r *StuckTransferAlertListResponse) GetMeta(out interface{}) error {
+	return json.Unmarshal(r.Meta, out)
+}
+
+// MustStuckTransferAlert - returns StuckTransferAlert from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustStuckTransferAlert(key Key) *StuckTransferAlert {
+	var stuckTransferAlert StuckTransferAlert
+	if c.tryFindEntry(key, &stuckTransferAlert) {
+		return &stuckTransferAlert
+	}
+	return nil
+}
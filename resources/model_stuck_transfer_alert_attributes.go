@@ -0,0 +1,26 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type StuckTransferAlertAttributes struct {
+	// Destination chain of the transfer
+	Chain string `json:"chain"`
+	// Time (UTC) the transfer was detected as stuck, RFC3339 format
+	DetectedAt time.Time `json:"detected_at"`
+	// Kind of the alert
+	Kind string `json:"kind"`
+	// Identifier of the transfer origin
+	Origin string `json:"origin"`
+	// Time (UTC) the transfer stopped being stuck, RFC3339 format, absent if the alert is open
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	// Time (UTC) the transfer got stuck in the state, RFC3339 format
+	Since time.Time `json:"since"`
+	// Status of the transfer at the moment of the detection
+	Status TransferState `json:"status"`
+	// Index of the transfer operation
+	TransferIndex string `json:"transfer_index"`
+}
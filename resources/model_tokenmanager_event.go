@@ -0,0 +1,43 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "encoding/json"
+
+type TokenmanagerEvent struct {
+	Key
+	Attributes TokenmanagerEventAttributes `json:"attributes"`
+}
+type TokenmanagerEventResponse struct {
+	Data     TokenmanagerEvent `json:"data"`
+	Included Included          `json:"included"`
+}
+
+type TokenmanagerEventListResponse struct {
+	Data     []TokenmanagerEvent `json:"data"`
+	Included Included            `json:"included"`
+	Links    *Links              `json:"links"`
+	Meta     json.RawMessage     `json:"meta,omitempty"`
+}
+
+func (r *TokenmanagerEventListResponse) PutMeta(v interface{}) (err error) {
+	r.Meta, err = json.Marshal(v)
+	return err
+}
+
+func (r *TokenmanagerEventListResponse) GetMeta(out interface{}) error {
+	return json.Unmarshal(r.Meta, out)
+}
+
+// MustTokenmanagerEvent - returns TokenmanagerEvent from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustTokenmanagerEvent(key Key) *TokenmanagerEvent {
+	var tokenmanagerEvent TokenmanagerEvent
+	if c.tryFindEntry(key, &tokenmanagerEvent) {
+		return &tokenmanagerEvent
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type TokenmanagerEventAttributes struct {
+	// Core chain name of the chain data changed by the event
+	Chain *string `json:"chain,omitempty"`
+	// Index of the collection changed by the event
+	CollectionIndex *string `json:"collection_index,omitempty"`
+	// Time (UTC) the event was indexed, RFC3339 format
+	CreatedAt time.Time `json:"created_at"`
+	// State of the item, collection or their chain data right after the change
+	Details json.RawMessage `json:"details"`
+	// Height of the rarimo block the event was emitted in, absent if unknown
+	Height *int64 `json:"height,omitempty"`
+	// Index of the item changed by the event
+	ItemIndex *string `json:"item_index,omitempty"`
+	// Hash of the rarimo transaction emitted the event or `block:<height>` if it was emitted on the end of the block, absent if unknown
+	RarimoTx *string `json:"rarimo_tx,omitempty"`
+	// Type of the tokenmanager event, e.g. `item_created` or `seed_removed`
+	Type string `json:"type"`
+}
@@ -23,6 +23,8 @@ type TransferAttributes struct {
 	Origin *string `json:"origin,omitempty"`
 	// Shows state of the transfer
 	Status TransferState `json:"status"`
+	// Status changes of the transfer ordered by the stage, available if the transfer was indexed with them
+	Timeline []TransferStatusChange `json:"timeline,omitempty"`
 	// Name of the destination chain
 	ToChain *string `json:"to_chain,omitempty"`
 }
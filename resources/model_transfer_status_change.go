@@ -0,0 +1,16 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type TransferStatusChange struct {
+	// Hash of the rarimo transaction moved the transfer to the status
+	RarimoTx string `json:"rarimo_tx"`
+	// Status the transfer was moved to
+	Status TransferState `json:"status"`
+	// Time (UTC) of the block with the rarimo transaction, RFC3339 format, absent if unknown
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
@@ -0,0 +1,44 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "encoding/json"
+
+type Vote struct {
+	Key
+	Attributes    VoteAttributes    `json:"attributes"`
+	Relationships VoteRelationships `json:"relationships"`
+}
+type VoteResponse struct {
+	Data     Vote     `json:"data"`
+	Included Included `json:"included"`
+}
+
+type VoteListResponse struct {
+	Data     []Vote          `json:"data"`
+	Included Included        `json:"included"`
+	Links    *Links          `json:"links"`
+	Meta     json.RawMessage `json:"meta,omitempty"`
+}
+
+func (r *VoteListResponse) PutMeta(v interface{}) (err error) {
+	r.Meta, err = json.Marshal(v)
+	return err
+}
+
+func (r *VoteListResponse) GetMeta(out interface{}) error {
+	return json.Unmarshal(r.Meta, out)
+}
+
+// MustVote - returns Vote from include collection.
+// if entry with specified key does not exist - returns nil
+// if entry with specified key exists but type or ID mismatches - panics
+func (c *Included) MustVote(key Key) *Vote {
+	var vote Vote
+	if c.tryFindEntry(key, &vote) {
+		return &vote
+	}
+	return nil
+}
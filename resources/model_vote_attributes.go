@@ -0,0 +1,14 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+import "time"
+
+type VoteAttributes struct {
+	// Voting choice of the validator, `YES` or `NO`
+	Choice string `json:"choice"`
+	// Time (UTC) the vote was indexed, RFC3339 format
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,9 @@
+/*
+ * GENERATED. Do not modify. Your changes might be overwritten!
+ */
+
+package resources
+
+type VoteRelationships struct {
+	Tx *Relation `json:"tx,omitempty"`
+}
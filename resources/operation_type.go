@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	rarimocore "github.com/rarimo/rarimo-core/x/rarimocore/types"
+
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+type OperationType rarimocore.OpType
+
+func (t OperationType) String() string {
+	return strings.ToLower(rarimocore.OpType(t).String())
+}
+
+func (t OperationType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Flag{
+		Name:  t.String(),
+		Value: int32(t),
+	})
+}
+
+func (t *OperationType) UnmarshalJSON(b []byte) error {
+	var res Flag
+	err := json.Unmarshal(b, &res)
+	if err != nil {
+		return err
+	}
+
+	*t = OperationType(res.Value)
+	return nil
+}
+
+// UnmarshalText accepts both the numeric value and the lowercase name of the operation type,
+// e.g. `1` or `change_parties`
+func (t *OperationType) UnmarshalText(b []byte) error {
+	if typ, ok := rarimocore.OpType_value[strings.ToUpper(string(b))]; ok {
+		*t = OperationType(typ)
+		return nil
+	}
+
+	typ, err := strconv.ParseInt(string(b), 0, 32)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := rarimocore.OpType_name[int32(typ)]; !ok {
+		return errors.From(errors.New("unsupported value"), logan.F{
+			"supported": rarimocore.OpType_name,
+		})
+	}
+
+	*t = OperationType(typ)
+	return nil
+}
+
+func (t OperationType) Int() int {
+	return int(t)
+}
+
+func (t OperationType) Intp() *int {
+	i := t.Int()
+	return &i
+}